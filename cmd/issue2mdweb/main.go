@@ -33,6 +33,8 @@ const (
 	// Keep write timeout comfortably above known upstream client timeouts (30s/45s).
 	defaultWriteTimeout = 120 * time.Second
 	defaultIdleTimeout  = 60 * time.Second
+	// A request cannot outlive the write timeout, so rate limit resets are only awaited briefly.
+	webMaxRateLimitWait = 30 * time.Second
 )
 
 const webWriteTimeoutEnv = "ISSUE2MD_WEB_WRITE_TIMEOUT"
@@ -41,14 +43,19 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	logger := newLogger(os.Stderr)
 
-	cfg, err := config.NewLoader().Load(nil)
+	cfg, err := config.NewServerLoader().Load(nil)
 	if err != nil {
 		fatal(logger, "load config", err)
 	}
 
-	fetcher, err := gh.NewFetcher(gh.Config{
-		Token: cfg.Token,
-	})
+	for host, source := range cfg.TokenSources {
+		logger.Info("github credentials", "host", host, "source", source)
+	}
+
+	fetcher, err := gh.NewHostFetcher(gh.Config{
+		Token:            cfg.Token,
+		MaxRateLimitWait: webMaxRateLimitWait,
+	}, cfg.HostTokens)
 	if err != nil {
 		fatal(logger, "create fetcher", err)
 	}
@@ -68,7 +75,7 @@ func main() {
 	}
 
 	handler := webapp.NewHandler(webapp.Deps{
-		Parser:          parser.New(),
+		Parser:          parser.NewWithOptions(parser.Options{AllowedHosts: cfg.AllowedHosts}),
 		Fetcher:         fetcher,
 		Renderer:        converter.NewRenderer(summarizer),
 		Template:        tmpl,
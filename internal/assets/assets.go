@@ -0,0 +1,335 @@
+// Package assets downloads images and attachments referenced by exported markdown so archives
+// keep working after GitHub's asset URLs expire or start requiring authentication.
+package assets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DirName is the directory created beside each output file to hold its assets.
+	DirName = "assets"
+	// DefaultMaxBytes caps a single download; GitHub rejects uploads larger than this anyway.
+	DefaultMaxBytes = 100 << 20
+
+	publicHost     = "github.com"
+	hashNameLength = 16
+
+	// markdownTargetPattern matches the URL of an inline markdown image or link: ](url) or ](<url>).
+	markdownTargetPattern = `\]\(\s*<?(https://[^\s)>]+)`
+	// htmlTargetPattern matches the URL of an HTML src or href attribute.
+	htmlTargetPattern = `(?i)\b(?:src|href)\s*=\s*["'](https://[^"'\s]+)["']`
+)
+
+// Options configures a Downloader.
+type Options struct {
+	HTTPClient *http.Client
+	// Tokens maps GitHub hosts to the token sent when downloading from them. Tokens are never
+	// sent to other hosts, including githubusercontent.com, whose URLs carry their own signature.
+	Tokens map[string]string
+	// TokenFunc, when set, supplies the token for GitHub hosts missing from Tokens, such as a
+	// GitHub App installation token that rotates during a run.
+	TokenFunc func(host string) (string, error)
+	// EnterpriseHosts lists GitHub Enterprise Server hosts whose attachments are downloaded too.
+	EnterpriseHosts []string
+	// MaxBytes caps one download; zero means DefaultMaxBytes.
+	MaxBytes int64
+}
+
+// Failure records an asset that could not be downloaded; its reference is left unchanged.
+type Failure struct {
+	Err error
+	URL string
+}
+
+// Result is the rewritten markdown and the outcome of each distinct asset.
+type Result struct {
+	Markdown []byte
+	Failures []Failure
+	// Downloaded counts distinct assets now stored locally.
+	Downloaded int
+}
+
+// Downloader stores assets in content-addressed files so the same image referenced by several
+// items, or under several URLs, is kept once.
+type Downloader struct {
+	client     *http.Client
+	targets    []*regexp.Regexp
+	tokens     map[string]string
+	tokenFunc  func(host string) (string, error)
+	enterprise map[string]struct{}
+	maxBytes   int64
+}
+
+// NewDownloader creates a Downloader.
+func NewDownloader(opts Options) *Downloader {
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 60 * time.Second}
+	}
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	enterprise := make(map[string]struct{}, len(opts.EnterpriseHosts))
+	for _, host := range opts.EnterpriseHosts {
+		enterprise[strings.ToLower(host)] = struct{}{}
+	}
+	return &Downloader{
+		client:     client,
+		targets:    []*regexp.Regexp{regexp.MustCompile(markdownTargetPattern), regexp.MustCompile(htmlTargetPattern)},
+		tokens:     opts.Tokens,
+		tokenFunc:  opts.TokenFunc,
+		enterprise: enterprise,
+		maxBytes:   maxBytes,
+	}
+}
+
+type span struct {
+	url        string
+	start, end int
+}
+
+// Localize downloads every GitHub asset referenced by markdown image or link targets and HTML
+// src/href attributes into outputDir/assets, and rewrites those references to relative paths.
+// Fenced code blocks are left untouched.
+func (d *Downloader) Localize(ctx context.Context, markdown []byte, outputDir string) Result {
+	spans := d.findAssetTargets(markdown)
+	result := Result{Markdown: markdown}
+	if len(spans) == 0 {
+		return result
+	}
+
+	local := map[string]string{}
+	for _, s := range spans {
+		if _, done := local[s.url]; done {
+			continue
+		}
+		name, err := d.download(ctx, s.url, filepath.Join(outputDir, DirName))
+		if err != nil {
+			local[s.url] = ""
+			result.Failures = append(result.Failures, Failure{URL: s.url, Err: err})
+			continue
+		}
+		local[s.url] = DirName + "/" + name
+		result.Downloaded++
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, s := range spans {
+		if local[s.url] == "" {
+			continue
+		}
+		b.Write(markdown[last:s.start])
+		b.WriteString(local[s.url])
+		last = s.end
+	}
+	b.Write(markdown[last:])
+	result.Markdown = []byte(b.String())
+	return result
+}
+
+// findAssetTargets returns asset URL spans in document order, skipping fenced code blocks.
+func (d *Downloader) findAssetTargets(markdown []byte) []span {
+	fenced := fencedRanges(markdown)
+	var spans []span
+	for _, pattern := range d.targets {
+		for _, m := range pattern.FindAllSubmatchIndex(markdown, -1) {
+			start, end := m[2], m[3]
+			if inRanges(fenced, start) {
+				continue
+			}
+			raw := string(markdown[start:end])
+			if u, err := url.Parse(raw); err == nil && d.isAsset(u) {
+				spans = append(spans, span{url: raw, start: start, end: end})
+			}
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	return spans
+}
+
+// isAsset accepts uploaded images and attachments: githubusercontent.com image hosts,
+// /user-attachments/ on GitHub hosts and the older /{owner}/{repo}/assets/ paths.
+func (d *Downloader) isAsset(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	switch host {
+	case "user-images.githubusercontent.com", "private-user-images.githubusercontent.com":
+		return true
+	}
+	if _, ok := d.enterprise[host]; !ok && host != publicHost {
+		return false
+	}
+	if strings.HasPrefix(u.Path, "/user-attachments/") {
+		return true
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	return len(segments) >= 4 && segments[2] == "assets"
+}
+
+// token returns the token for a GitHub host. Other hosts, githubusercontent.com included, never
+// get one.
+func (d *Downloader) token(host string) (string, error) {
+	if token := d.tokens[host]; token != "" || d.tokenFunc == nil {
+		return token, nil
+	}
+	if _, ok := d.enterprise[host]; !ok && host != publicHost {
+		return "", nil
+	}
+	token, err := d.tokenFunc(host)
+	if err != nil {
+		return "", fmt.Errorf("get token for asset host %q: %w", host, err)
+	}
+	return token, nil
+}
+
+// download fetches rawURL into dir under a name derived from its content hash and returns that name.
+func (d *Downloader) download(ctx context.Context, rawURL, dir string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("create asset request: %w", err)
+	}
+	token, err := d.token(strings.ToLower(req.URL.Hostname()))
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// #nosec G704 -- only GitHub asset URLs accepted by isAsset reach this request.
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("download asset: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download asset: http status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, d.maxBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("read asset: %w", err)
+	}
+	if int64(len(body)) > d.maxBytes {
+		return "", fmt.Errorf("asset exceeds %d bytes", d.maxBytes)
+	}
+
+	sum := sha256.Sum256(body)
+	name := hex.EncodeToString(sum[:])[:hashNameLength] + extension(resp.Request.URL.Path, resp.Header.Get("Content-Type"))
+	if err := storeOnce(filepath.Join(dir, name), body); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// extension prefers the URL's own extension and falls back to the content type, since
+// /user-attachments/assets/<uuid> URLs have none.
+func extension(urlPath, contentType string) string {
+	if ext := strings.ToLower(path.Ext(urlPath)); len(ext) > 1 && len(ext) <= 6 && isAlphanumeric(ext[1:]) {
+		return ext
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch mediaType {
+	case "image/jpeg":
+		// mime lists .jfif first for JPEG.
+		return ".jpg"
+	case "image/svg+xml":
+		return ".svg"
+	case "text/plain":
+		return ".txt"
+	}
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+func isAlphanumeric(value string) bool {
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// storeOnce writes body to target unless a file with the same content-derived name exists.
+func storeOnce(target string, body []byte) error {
+	if _, err := os.Stat(target); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("stat asset %q: %w", target, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("create asset directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".asset-*")
+	if err != nil {
+		return fmt.Errorf("create asset file: %w", err)
+	}
+	_, writeErr := tmp.Write(body)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write asset %q: %w", target, err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("store asset %q: %w", target, err)
+	}
+	return nil
+}
+
+// fencedRanges returns the byte ranges of ``` and ~~~ fenced code blocks.
+func fencedRanges(markdown []byte) [][2]int {
+	var ranges [][2]int
+	fence, start, offset := "", 0, 0
+	for _, line := range strings.SplitAfter(string(markdown), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			fence = trimmed[:3]
+			for len(fence) < len(trimmed) && trimmed[len(fence)] == fence[0] {
+				fence += fence[:1]
+			}
+			start = offset
+		case fence != "" && strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "":
+			ranges = append(ranges, [2]int{start, offset + len(line)})
+			fence = ""
+		}
+		offset += len(line)
+	}
+	if fence != "" {
+		ranges = append(ranges, [2]int{start, offset})
+	}
+	return ranges
+}
+
+func inRanges(ranges [][2]int, pos int) bool {
+	for _, r := range ranges {
+		if pos >= r[0] && pos < r[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,278 @@
+package assets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// fakeAssetServer serves fixed bodies keyed by URL and records the Authorization header of
+// every request.
+type fakeAssetServer struct {
+	bodies map[string]string
+	auth   map[string]string
+	mu     sync.Mutex
+}
+
+func (s *fakeAssetServer) client() *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		s.mu.Lock()
+		s.auth[req.URL.String()] = req.Header.Get("Authorization")
+		s.mu.Unlock()
+		body, ok := s.bodies[req.URL.String()]
+		if !ok {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+		}
+		header := http.Header{}
+		header.Set("Content-Type", "image/png")
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(body)), Request: req}, nil
+	})}
+}
+
+func TestLocalize(t *testing.T) {
+	t.Parallel()
+
+	const (
+		attachment = "https://github.com/user-attachments/assets/0f3c"
+		legacy     = "https://github.com/acme/widgets/assets/1/diagram.gif"
+		copyURL    = "https://user-images.githubusercontent.com/1/copy.png"
+		enterprise = "https://ghe.example.com/user-attachments/files/9/log.txt"
+		missing    = "https://github.com/user-attachments/assets/gone"
+	)
+	bodies := map[string]string{
+		attachment: "png-bytes",
+		legacy:     "gif-bytes",
+		copyURL:    "png-bytes",
+		enterprise: "log-bytes",
+	}
+	tokens := map[string]string{"github.com": "public-token", "ghe.example.com": "ghe-token"}
+
+	attachmentName := "assets/" + hashName("png-bytes") + ".png"
+	tcs := []struct {
+		name           string
+		markdown       string
+		want           string
+		wantFailures   []string
+		wantAuth       map[string]string
+		wantDownloaded int
+	}{
+		{
+			name:           "markdown image and html img share one file",
+			markdown:       "![shot](" + attachment + ")\n<img src=\"" + copyURL + "\" width=\"10\">\n",
+			want:           "![shot](" + attachmentName + ")\n<img src=\"" + attachmentName + "\" width=\"10\">\n",
+			wantDownloaded: 2,
+			wantAuth:       map[string]string{attachment: "Bearer public-token", copyURL: ""},
+		},
+		{
+			name:           "legacy repository assets keep their extension",
+			markdown:       "[diagram](<" + legacy + ">) and again [d](" + legacy + ")",
+			want:           "[diagram](<assets/" + hashName("gif-bytes") + ".gif>) and again [d](assets/" + hashName("gif-bytes") + ".gif)",
+			wantDownloaded: 1,
+			wantAuth:       map[string]string{legacy: "Bearer public-token"},
+		},
+		{
+			name:           "enterprise attachment uses the host token",
+			markdown:       "[log](" + enterprise + ")",
+			want:           "[log](assets/" + hashName("log-bytes") + ".txt)",
+			wantDownloaded: 1,
+			wantAuth:       map[string]string{enterprise: "Bearer ghe-token"},
+		},
+		{
+			name:           "failed download keeps the reference",
+			markdown:       "![a](" + missing + ") ![b](" + attachment + ")",
+			want:           "![a](" + missing + ") ![b](" + attachmentName + ")",
+			wantFailures:   []string{missing},
+			wantDownloaded: 1,
+			wantAuth:       map[string]string{missing: "Bearer public-token", attachment: "Bearer public-token"},
+		},
+		{
+			name:     "other hosts and fenced code are untouched",
+			markdown: "[site](https://example.com/a.png)\n```\n![x](" + attachment + ")\n```\n",
+			want:     "[site](https://example.com/a.png)\n```\n![x](" + attachment + ")\n```\n",
+			wantAuth: map[string]string{},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := &fakeAssetServer{bodies: bodies, auth: map[string]string{}}
+			downloader := NewDownloader(Options{
+				HTTPClient:      server.client(),
+				Tokens:          tokens,
+				EnterpriseHosts: []string{"GHE.example.com"},
+			})
+			dir := t.TempDir()
+			result := downloader.Localize(context.Background(), []byte(tc.markdown), dir)
+
+			if string(result.Markdown) != tc.want {
+				t.Fatalf("Markdown =\n%s\nwant\n%s", result.Markdown, tc.want)
+			}
+			if result.Downloaded != tc.wantDownloaded {
+				t.Fatalf("Downloaded = %d, want %d", result.Downloaded, tc.wantDownloaded)
+			}
+			var failed []string
+			for _, failure := range result.Failures {
+				failed = append(failed, failure.URL)
+			}
+			if !reflect.DeepEqual(failed, tc.wantFailures) {
+				t.Fatalf("Failures = %v, want %v", failed, tc.wantFailures)
+			}
+			if !reflect.DeepEqual(server.auth, tc.wantAuth) {
+				t.Fatalf("Authorization headers = %v, want %v", server.auth, tc.wantAuth)
+			}
+			if tc.wantDownloaded > 0 {
+				entries, err := os.ReadDir(filepath.Join(dir, DirName))
+				if err != nil {
+					t.Fatalf("read assets dir: %v", err)
+				}
+				if len(entries) == 0 {
+					t.Fatal("assets dir is empty, want stored files")
+				}
+			}
+		})
+	}
+}
+
+func TestLocalizeRejectsOversizedAssets(t *testing.T) {
+	t.Parallel()
+
+	const attachment = "https://github.com/user-attachments/assets/big"
+	server := &fakeAssetServer{bodies: map[string]string{attachment: "0123456789"}, auth: map[string]string{}}
+	downloader := NewDownloader(Options{HTTPClient: server.client(), MaxBytes: 4})
+	dir := t.TempDir()
+
+	result := downloader.Localize(context.Background(), []byte("![big]("+attachment+")"), dir)
+	if len(result.Failures) != 1 || !strings.Contains(result.Failures[0].Err.Error(), "exceeds 4 bytes") {
+		t.Fatalf("Failures = %v, want one size failure", result.Failures)
+	}
+	if _, err := os.Stat(filepath.Join(dir, DirName)); !os.IsNotExist(err) {
+		t.Fatalf("assets dir stat error = %v, want not exist", err)
+	}
+}
+
+func TestLocalizeTokenFunc(t *testing.T) {
+	t.Parallel()
+
+	const (
+		attachment = "https://github.com/user-attachments/assets/0f3c"
+		copyURL    = "https://private-user-images.githubusercontent.com/1/copy.png"
+		enterprise = "https://ghe.example.com/user-attachments/files/9/log.txt"
+	)
+	bodies := map[string]string{attachment: "png-bytes", copyURL: "png-bytes", enterprise: "log-bytes"}
+
+	tcs := []struct {
+		name         string
+		tokens       map[string]string
+		tokenErr     error
+		wantAuth     map[string]string
+		wantHosts    []string
+		wantFailures int
+	}{
+		{
+			name:      "github hosts get the supplied token",
+			wantAuth:  map[string]string{attachment: "Bearer ghs_github.com", copyURL: "", enterprise: "Bearer ghs_ghe.example.com"},
+			wantHosts: []string{"ghe.example.com", "github.com"},
+		},
+		{
+			name:      "static tokens take precedence",
+			tokens:    map[string]string{"ghe.example.com": "ghe-token"},
+			wantAuth:  map[string]string{attachment: "Bearer ghs_github.com", copyURL: "", enterprise: "Bearer ghe-token"},
+			wantHosts: []string{"github.com"},
+		},
+		{
+			name:         "token errors fail the download",
+			tokenErr:     errors.New("installation token expired"),
+			wantAuth:     map[string]string{copyURL: ""},
+			wantHosts:    []string{"ghe.example.com", "github.com"},
+			wantFailures: 2,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu    sync.Mutex
+				hosts []string
+			)
+			server := &fakeAssetServer{bodies: bodies, auth: map[string]string{}}
+			downloader := NewDownloader(Options{
+				HTTPClient:      server.client(),
+				Tokens:          tc.tokens,
+				EnterpriseHosts: []string{"ghe.example.com"},
+				TokenFunc: func(host string) (string, error) {
+					mu.Lock()
+					hosts = append(hosts, host)
+					mu.Unlock()
+					return "ghs_" + host, tc.tokenErr
+				},
+			})
+			markdown := "![a](" + attachment + ") ![b](" + copyURL + ") [c](" + enterprise + ")"
+			result := downloader.Localize(context.Background(), []byte(markdown), t.TempDir())
+
+			if len(result.Failures) != tc.wantFailures {
+				t.Fatalf("Failures = %v, want %d", result.Failures, tc.wantFailures)
+			}
+			if !reflect.DeepEqual(server.auth, tc.wantAuth) {
+				t.Fatalf("Authorization headers = %v, want %v", server.auth, tc.wantAuth)
+			}
+			sort.Strings(hosts)
+			if !reflect.DeepEqual(hosts, tc.wantHosts) {
+				t.Fatalf("TokenFunc hosts = %v, want %v", hosts, tc.wantHosts)
+			}
+		})
+	}
+}
+
+func TestExtension(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name        string
+		urlPath     string
+		contentType string
+		want        string
+	}{
+		{name: "url extension", urlPath: "/a/b/screen.PNG", contentType: "image/jpeg", want: ".png"},
+		{name: "jpeg content type", urlPath: "/user-attachments/assets/0f3c", contentType: "image/jpeg", want: ".jpg"},
+		{name: "content type with parameters", urlPath: "/x", contentType: "text/plain; charset=utf-8", want: ".txt"},
+		{name: "uuid-like suffix is not an extension", urlPath: "/x/1.2-3_4", contentType: "image/gif", want: ".gif"},
+		{name: "unknown", urlPath: "/x", contentType: "", want: ""},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := extension(tc.urlPath, tc.contentType); got != tc.want {
+				t.Fatalf("extension(%q, %q) = %q, want %q", tc.urlPath, tc.contentType, got, tc.want)
+			}
+		})
+	}
+}
+
+func hashName(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])[:hashNameLength]
+}
@@ -10,6 +10,8 @@ const (
 	ModeSingle Mode = "single"
 	// ModeBatch processes many URLs from --input-file.
 	ModeBatch Mode = "batch"
+	// ModeQuery processes every resource matched by a --query search.
+	ModeQuery Mode = "query"
 )
 
 // Args contains validated and normalized command mode inputs.
@@ -18,8 +20,19 @@ type Args struct {
 	URL  string
 }
 
-// ValidateArgs validates single-vs-batch mode constraints from config.
+// ValidateArgs validates single, batch and query mode constraints from config.
 func ValidateArgs(cfg config.Config) (Args, error) {
+	if cfg.Sync {
+		if err := validateSyncArgs(cfg); err != nil {
+			return Args{}, err
+		}
+	}
+	if cfg.Query != "" {
+		return validateQueryArgs(cfg)
+	}
+	if cfg.DryRun {
+		return Args{}, config.NewValidationError("dry-run", "--dry-run requires --query")
+	}
 	if cfg.InputFile != "" {
 		if cfg.OutputPath == "" {
 			return Args{}, config.NewValidationError("output", "--output is required when --input-file is set")
@@ -42,3 +55,53 @@ func ValidateArgs(cfg config.Config) (Args, error) {
 		URL:  cfg.Positional[0],
 	}, nil
 }
+
+func validateQueryArgs(cfg config.Config) (Args, error) {
+	if cfg.InputFile != "" {
+		return Args{}, config.NewConflictError("--query", "--input-file")
+	}
+	if cfg.Stdout {
+		return Args{}, config.NewConflictError("--stdout", "--query")
+	}
+	if len(cfg.Positional) > 0 {
+		return Args{}, config.NewValidationError("url", "positional URL is not allowed when --query is set")
+	}
+	if cfg.OutputPath == "" && !cfg.DryRun {
+		return Args{}, config.NewValidationError("output", "--output is required when --query is set")
+	}
+	return Args{Mode: ModeQuery}, nil
+}
+
+// validateRenderArgs checks the render command, which reads snapshot files named by the
+// positional arguments and never fetches.
+func validateRenderArgs(cfg config.Config) (Mode, error) {
+	switch {
+	case len(cfg.Positional) == 0:
+		return "", config.NewValidationError("snapshot", "render needs at least one JSON snapshot file")
+	case cfg.Query != "":
+		return "", config.NewConflictError("render", "--query")
+	case cfg.InputFile != "":
+		return "", config.NewConflictError("render", "--input-file")
+	case cfg.Sync:
+		return "", config.NewConflictError("render", "--sync")
+	case cfg.DownloadAssets:
+		return "", config.NewConflictError("render", "--download-assets")
+	case len(cfg.Positional) == 1:
+		return ModeSingle, nil
+	case cfg.Stdout:
+		return "", config.NewConflictError("--stdout", "several snapshots")
+	case cfg.OutputPath == "":
+		return "", config.NewValidationError("output", "--output is required when rendering several snapshots")
+	}
+	return ModeBatch, nil
+}
+
+func validateSyncArgs(cfg config.Config) error {
+	if cfg.InputFile == "" && cfg.Query == "" {
+		return config.NewValidationError("sync", "--sync requires --input-file or --query")
+	}
+	if cfg.DryRun {
+		return config.NewConflictError("--sync", "--dry-run")
+	}
+	return nil
+}
@@ -72,6 +72,78 @@ func TestValidateArgs(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "query mode valid",
+			cfg: config.Config{
+				Query:      "repo:octo/repo is:issue",
+				OutputPath: "out",
+			},
+			want: Args{
+				Mode: ModeQuery,
+			},
+		},
+		{
+			name: "query dry run needs no output path",
+			cfg: config.Config{
+				Query:  "repo:octo/repo",
+				DryRun: true,
+			},
+			want: Args{
+				Mode: ModeQuery,
+			},
+		},
+		{
+			name: "query mode requires output path",
+			cfg: config.Config{
+				Query: "repo:octo/repo",
+			},
+			wantErr: true,
+		},
+		{
+			name: "query mode conflicts with input file",
+			cfg: config.Config{
+				Query:      "repo:octo/repo",
+				InputFile:  "urls.txt",
+				OutputPath: "out",
+			},
+			wantErr: true,
+		},
+		{
+			name: "sync batch valid",
+			cfg: config.Config{
+				InputFile:  "urls.txt",
+				OutputPath: "out",
+				Sync:       true,
+			},
+			want: Args{
+				Mode: ModeBatch,
+			},
+		},
+		{
+			name: "sync requires batch or query",
+			cfg: config.Config{
+				Positional: []string{"https://github.com/octo/repo/issues/1"},
+				Sync:       true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sync conflicts with dry run",
+			cfg: config.Config{
+				Query:  "repo:octo/repo",
+				DryRun: true,
+				Sync:   true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "dry run requires query",
+			cfg: config.Config{
+				Positional: []string{"https://github.com/octo/repo/issues/1"},
+				DryRun:     true,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tcs {
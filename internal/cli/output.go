@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/johnqtcg/issue2md/internal/config"
+	"github.com/johnqtcg/issue2md/internal/converter"
 	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
@@ -60,7 +61,8 @@ func (w *fileOutputWriter) Write(cfg config.Config, mode Mode, ref gh.ResourceRe
 }
 
 func resolveOutputPath(cfg config.Config, mode Mode, ref gh.ResourceRef) (string, error) {
-	defaultName, err := defaultFileName(ref)
+	ext := outputExtension(cfg.Format)
+	defaultName, err := defaultFileName(ref, ext)
 	if err != nil {
 		return "", fmt.Errorf("build default file name: %w", err)
 	}
@@ -86,13 +88,27 @@ func resolveOutputPath(cfg config.Config, mode Mode, ref gh.ResourceRef) (string
 		return "", fmt.Errorf("stat output path %q: %w", cfg.OutputPath, err)
 	}
 
-	if strings.EqualFold(filepath.Ext(cfg.OutputPath), ".md") {
+	if strings.EqualFold(filepath.Ext(cfg.OutputPath), ext) {
 		return cfg.OutputPath, nil
 	}
 	return filepath.Join(cfg.OutputPath, defaultName), nil
 }
 
-func defaultFileName(ref gh.ResourceRef) (string, error) {
+// outputExtension is the file extension of documents in format, including the dot.
+func outputExtension(format string) string {
+	switch converter.Format(format) {
+	case converter.FormatJSON:
+		return ".json"
+	case converter.FormatHTML:
+		return ".html"
+	case converter.FormatJSONL:
+		return ".jsonl"
+	default:
+		return ".md"
+	}
+}
+
+func defaultFileName(ref gh.ResourceRef, ext string) (string, error) {
 	var resourcePart string
 	switch ref.Type {
 	case gh.ResourceIssue:
@@ -105,7 +121,10 @@ func defaultFileName(ref gh.ResourceRef) (string, error) {
 		return "", fmt.Errorf("unsupported resource type %q", ref.Type)
 	}
 
-	return fmt.Sprintf("%s-%s-%s-%d.md", ref.Owner, ref.Repo, resourcePart, ref.Number), nil
+	if ref.CommentAnchor != "" {
+		return fmt.Sprintf("%s-%s-%s-%d-%s%s", ref.Owner, ref.Repo, resourcePart, ref.Number, ref.CommentAnchor, ext), nil
+	}
+	return fmt.Sprintf("%s-%s-%s-%d%s", ref.Owner, ref.Repo, resourcePart, ref.Number, ext), nil
 }
 
 func ensureWritable(path string, force bool) error {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/johnqtcg/issue2md/internal/config"
+	"github.com/johnqtcg/issue2md/internal/converter"
 	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
@@ -16,6 +17,7 @@ func TestDefaultFileName(t *testing.T) {
 
 	tcs := []struct {
 		name string
+		ext  string
 		want string
 		ref  gh.ResourceRef
 	}{
@@ -34,6 +36,29 @@ func TestDefaultFileName(t *testing.T) {
 			ref:  gh.ResourceRef{Owner: "octo", Repo: "repo", Type: gh.ResourceDiscussion, Number: 3},
 			want: "octo-repo-discussion-3.md",
 		},
+		{
+			name: "focused comment",
+			ref:  gh.ResourceRef{Owner: "octo", Repo: "repo", Type: gh.ResourceIssue, Number: 1, CommentAnchor: "issuecomment-42"},
+			want: "octo-repo-issue-1-issuecomment-42.md",
+		},
+		{
+			name: "json snapshot",
+			ref:  gh.ResourceRef{Owner: "octo", Repo: "repo", Type: gh.ResourcePullRequest, Number: 2},
+			ext:  ".json",
+			want: "octo-repo-pr-2.json",
+		},
+		{
+			name: "html page",
+			ref:  gh.ResourceRef{Owner: "octo", Repo: "repo", Type: gh.ResourceDiscussion, Number: 3},
+			ext:  outputExtension(string(converter.FormatHTML)),
+			want: "octo-repo-discussion-3.html",
+		},
+		{
+			name: "jsonl chunks",
+			ref:  gh.ResourceRef{Owner: "octo", Repo: "repo", Type: gh.ResourceIssue, Number: 4},
+			ext:  outputExtension(string(converter.FormatJSONL)),
+			want: "octo-repo-issue-4.jsonl",
+		},
 	}
 
 	for _, tc := range tcs {
@@ -41,7 +66,11 @@ func TestDefaultFileName(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := defaultFileName(tc.ref)
+			ext := tc.ext
+			if ext == "" {
+				ext = ".md"
+			}
+			got, err := defaultFileName(tc.ref, ext)
 			if err != nil {
 				t.Fatalf("defaultFileName error = %v, want nil", err)
 			}
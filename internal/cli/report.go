@@ -17,21 +17,50 @@ const (
 	StatusFailed ItemStatus = "FAILED"
 )
 
-// ItemResult stores one URL processing result.
+// SyncStatus classifies an item processed in sync mode.
+type SyncStatus string
+
+const (
+	// SyncAdded marks an item exported for the first time.
+	SyncAdded SyncStatus = "added"
+	// SyncUpdated marks an item whose output file was rewritten.
+	SyncUpdated SyncStatus = "updated"
+	// SyncUnchanged marks an item whose output file was left as is.
+	SyncUnchanged SyncStatus = "unchanged"
+)
+
+// ItemResult stores one URL processing result. ResolvedURL is set when the fetched
+// resource lives elsewhere, e.g. a pull request linked as an issue or a transferred issue.
 type ItemResult struct {
 	URL          string
+	ResolvedURL  string
 	ResourceType gh.ResourceType
 	Status       ItemStatus
 	Reason       string
 	OutputPath   string
+	Sync         SyncStatus
+	// Snapshot is the JSON snapshot file an item was re-rendered from by the render command.
+	Snapshot string
+	// Assets and AssetFailures count the distinct assets downloaded and failed with --download-assets.
+	Assets        int
+	AssetFailures int
 }
 
-// RunSummary stores overall run stats and per-item outcomes.
+// RunSummary stores overall run stats and per-item outcomes. Added, Updated and Unchanged
+// are only counted in sync mode; CacheHits and CacheMisses only when the API cache is enabled;
+// Assets and AssetFailures only with --download-assets.
 type RunSummary struct {
-	Items     []ItemResult
-	Total     int
-	Succeeded int
-	Failed    int
+	Items         []ItemResult
+	Total         int
+	Succeeded     int
+	Failed        int
+	Added         int
+	Updated       int
+	Unchanged     int
+	Assets        int
+	AssetFailures int
+	CacheHits     int64
+	CacheMisses   int64
 }
 
 // BuildSummary computes aggregate counters from item results.
@@ -47,6 +76,16 @@ func BuildSummary(items []ItemResult) RunSummary {
 		} else {
 			out.Failed++
 		}
+		switch item.Sync {
+		case SyncAdded:
+			out.Added++
+		case SyncUpdated:
+			out.Updated++
+		case SyncUnchanged:
+			out.Unchanged++
+		}
+		out.Assets += item.Assets
+		out.AssetFailures += item.AssetFailures
 	}
 	return out
 }
@@ -55,7 +94,17 @@ func BuildSummary(items []ItemResult) RunSummary {
 func FormatSummary(summary RunSummary) string {
 	var b strings.Builder
 
-	fmt.Fprintf(&b, "OK total=%d succeeded=%d failed=%d\n", summary.Total, summary.Succeeded, summary.Failed)
+	fmt.Fprintf(&b, "OK total=%d succeeded=%d failed=%d", summary.Total, summary.Succeeded, summary.Failed)
+	if summary.Added+summary.Updated+summary.Unchanged > 0 {
+		fmt.Fprintf(&b, " added=%d updated=%d unchanged=%d", summary.Added, summary.Updated, summary.Unchanged)
+	}
+	if summary.Assets+summary.AssetFailures > 0 {
+		fmt.Fprintf(&b, " assets=%d asset_failures=%d", summary.Assets, summary.AssetFailures)
+	}
+	if summary.CacheHits+summary.CacheMisses > 0 {
+		fmt.Fprintf(&b, " cache_hits=%d cache_misses=%d", summary.CacheHits, summary.CacheMisses)
+	}
+	b.WriteString("\n")
 	for _, item := range summary.Items {
 		if item.Status != StatusFailed {
 			continue
@@ -28,6 +28,46 @@ func TestBuildSummaryCounts(t *testing.T) {
 	}
 }
 
+func TestBuildSummarySyncCounts(t *testing.T) {
+	t.Parallel()
+
+	got := BuildSummary([]ItemResult{
+		{URL: "u1", Status: StatusOK, Sync: SyncAdded},
+		{URL: "u2", Status: StatusOK, Sync: SyncUnchanged},
+		{URL: "u3", Status: StatusOK, Sync: SyncUnchanged},
+		{URL: "u4", Status: StatusFailed},
+	})
+	if got.Added != 1 || got.Updated != 0 || got.Unchanged != 2 {
+		t.Fatalf("sync counts = (%d, %d, %d), want (1, 0, 2)", got.Added, got.Updated, got.Unchanged)
+	}
+	if line := strings.SplitN(FormatSummary(got), "\n", 2)[0]; line != "OK total=4 succeeded=3 failed=1 added=1 updated=0 unchanged=2" {
+		t.Fatalf("summary line = %q", line)
+	}
+}
+
+func TestFormatSummaryCacheCounts(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		summary RunSummary
+		want    string
+	}{
+		{name: "cache disabled", summary: RunSummary{Total: 1, Succeeded: 1}, want: "OK total=1 succeeded=1 failed=0"},
+		{name: "cache used", summary: RunSummary{Total: 1, Succeeded: 1, CacheHits: 3, CacheMisses: 2}, want: "OK total=1 succeeded=1 failed=0 cache_hits=3 cache_misses=2"},
+		{name: "assets and cache", summary: RunSummary{Total: 1, Succeeded: 1, Assets: 4, AssetFailures: 1, CacheHits: 1}, want: "OK total=1 succeeded=1 failed=0 assets=4 asset_failures=1 cache_hits=1 cache_misses=0"},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FormatSummary(tc.summary); got != tc.want {
+				t.Fatalf("FormatSummary() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestFormatSummaryContainsStatusAndFailureEntries(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/johnqtcg/issue2md/internal/assets"
+	"github.com/johnqtcg/issue2md/internal/config"
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// AssetLocalizer downloads the assets referenced by rendered markdown into outputDir and
+// returns the markdown with those references rewritten to local paths.
+type AssetLocalizer interface {
+	Localize(ctx context.Context, markdown []byte, outputDir string) assets.Result
+}
+
+// newAssetLocalizer downloads with the same per-host tokens the fetcher uses. A fetcher that
+// reports its tokens also covers GitHub App auth, where cfg.Token is empty.
+func newAssetLocalizer(cfg config.Config, fetcher gh.Fetcher) AssetLocalizer {
+	opts := assets.Options{EnterpriseHosts: cfg.AllowedHosts}
+	if provider, ok := fetcher.(gh.TokenProvider); ok {
+		opts.TokenFunc = provider.HostToken
+		return assets.NewDownloader(opts)
+	}
+
+	opts.Tokens = make(map[string]string, len(cfg.HostTokens)+1)
+	for host, token := range cfg.HostTokens {
+		opts.Tokens[host] = token
+	}
+	if cfg.Token != "" {
+		opts.Tokens[gh.DefaultHost] = cfg.Token
+	}
+	return assets.NewDownloader(opts)
+}
+
+// localizeAssets downloads the assets of an item already written to item.OutputPath and
+// rewrites the file to reference them. Failed downloads are reported and counted on the item
+// but keep their original reference and never fail the item.
+func (a *App) localizeAssets(ctx context.Context, cfg config.Config, mode Mode, ref gh.ResourceRef, markdown []byte, item *ItemResult) error {
+	if !cfg.DownloadAssets || a.assets == nil {
+		return nil
+	}
+
+	result := a.assets.Localize(ctx, markdown, filepath.Dir(item.OutputPath))
+	for _, failure := range result.Failures {
+		writeAssetWarning(a.stderr, item.URL, failure)
+	}
+	item.Assets = result.Downloaded
+	item.AssetFailures = len(result.Failures)
+	if result.Downloaded == 0 {
+		return nil
+	}
+
+	// The item was just written to this path, so replacing it is always intended.
+	cfg.Force = true
+	if _, err := a.writer.Write(cfg, mode, ref, result.Markdown); err != nil {
+		return fmt.Errorf("write output with local assets: %w", err)
+	}
+	return nil
+}
+
+func writeAssetWarning(w io.Writer, rawURL string, failure assets.Failure) {
+	// #nosec G705 -- writes plain text status lines to CLI output, not HTML/browser context.
+	if _, err := fmt.Fprintf(w, "WARN url=%s asset=%s error=%v\n", rawURL, failure.URL, failure.Err); err != nil {
+		return
+	}
+}
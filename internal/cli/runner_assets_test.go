@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/johnqtcg/issue2md/internal/assets"
+	"github.com/johnqtcg/issue2md/internal/config"
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+type fakeAssetLocalizer struct {
+	result  assets.Result
+	gotDirs []string
+}
+
+func (f *fakeAssetLocalizer) Localize(_ context.Context, _ []byte, outputDir string) assets.Result {
+	f.gotDirs = append(f.gotDirs, outputDir)
+	return f.result
+}
+
+func TestAppRunDownloadAssets(t *testing.T) {
+	t.Parallel()
+
+	const (
+		issueURL = "https://github.com/octo/repo/issues/1"
+		imageURL = "https://github.com/user-attachments/assets/0f3c"
+		goneURL  = "https://github.com/user-attachments/assets/gone"
+	)
+	tcs := []struct {
+		name          string
+		result        assets.Result
+		wantWrites    []string
+		wantStatus    string
+		wantWarning   string
+		downloadFlag  bool
+		wantLocalized bool
+	}{
+		{
+			name:       "disabled",
+			wantWrites: []string{"![a](" + imageURL + ")"},
+			wantStatus: "OK url=" + issueURL + " type=issue output=out/issue.md\n",
+		},
+		{
+			name:          "downloaded assets rewrite the output",
+			downloadFlag:  true,
+			wantLocalized: true,
+			result:        assets.Result{Markdown: []byte("![a](assets/abc.png)"), Downloaded: 1},
+			wantWrites:    []string{"![a](" + imageURL + ")", "![a](assets/abc.png)"},
+			wantStatus:    "OK url=" + issueURL + " type=issue assets=1 asset_failures=0 output=out/issue.md\n",
+		},
+		{
+			name:          "failures are reported without failing the item",
+			downloadFlag:  true,
+			wantLocalized: true,
+			result: assets.Result{
+				Markdown: []byte("![a](" + goneURL + ")"),
+				Failures: []assets.Failure{{URL: goneURL, Err: errors.New("download asset: http status 404")}},
+			},
+			wantWrites:  []string{"![a](" + imageURL + ")"},
+			wantStatus:  "OK url=" + issueURL + " type=issue assets=0 asset_failures=1 output=out/issue.md\n",
+			wantWarning: "WARN url=" + issueURL + " asset=" + goneURL + " error=download asset: http status 404\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			localizer := &fakeAssetLocalizer{result: tc.result}
+			writer := &fakeOutputWriter{path: "out/issue.md", errByURL: map[string]error{}}
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			app := NewApp(AppDeps{
+				Loader: &fakeLoader{cfg: config.Config{Positional: []string{issueURL}, DownloadAssets: tc.downloadFlag}},
+				Parser: &fakeParser{refByURL: map[string]gh.ResourceRef{
+					issueURL: {Owner: "octo", Repo: "repo", Number: 1, Type: gh.ResourceIssue, URL: issueURL},
+				}},
+				FetcherFactory: &fakeFetcherFactory{fetcher: &fakeFetcher{dataByURL: map[string]gh.IssueData{
+					issueURL: minimalIssueData(gh.ResourceIssue, "i1", issueURL),
+				}}},
+				RendererFactory: &fakeRendererFactory{renderer: &fakeRenderer{out: []byte("![a](" + imageURL + ")")}},
+				Writer:          writer,
+				AssetLocalizer:  localizer,
+				Stdout:          stdout,
+				Stderr:          stderr,
+			})
+
+			if code := app.Run(context.Background(), []string{issueURL}); code != ExitOK {
+				t.Fatalf("Run exit code = %d, want %d; stderr=%s", code, ExitOK, stderr.String())
+			}
+			if !reflect.DeepEqual(writer.gotMarkdown, tc.wantWrites) {
+				t.Fatalf("writes = %q, want %q", writer.gotMarkdown, tc.wantWrites)
+			}
+			if got := len(localizer.gotDirs) > 0; got != tc.wantLocalized {
+				t.Fatalf("localized = %v, want %v", got, tc.wantLocalized)
+			}
+			if tc.wantLocalized && localizer.gotDirs[0] != "out" {
+				t.Fatalf("asset dir = %q, want out", localizer.gotDirs[0])
+			}
+			if stdout.String() != tc.wantStatus {
+				t.Fatalf("stdout = %q, want %q", stdout.String(), tc.wantStatus)
+			}
+			if !strings.Contains(stderr.String(), tc.wantWarning) {
+				t.Fatalf("stderr = %q, want %q", stderr.String(), tc.wantWarning)
+			}
+		})
+	}
+}
@@ -9,21 +9,23 @@ import (
 	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
+// runMany dispatches the multi-item modes: batch, query and their sync variants.
+func (a *App) runMany(ctx context.Context, cfg config.Config, mode Mode, fetcher gh.Fetcher, renderer converter.Renderer) (RunSummary, error) {
+	switch {
+	case cfg.Sync:
+		return a.runSync(ctx, cfg, mode, fetcher, renderer)
+	case mode == ModeQuery:
+		return a.runQuery(ctx, cfg, fetcher, renderer)
+	default:
+		return a.runBatch(ctx, cfg, fetcher, renderer)
+	}
+}
+
 func (a *App) runBatch(ctx context.Context, cfg config.Config, fetcher gh.Fetcher, renderer converter.Renderer) (RunSummary, error) {
 	var items []ItemResult
 
 	err := a.inputReader.Read(cfg.InputFile, func(line string) error {
-		item, processErr := a.processOne(ctx, cfg, ModeBatch, line, fetcher, renderer)
-		if processErr != nil {
-			item.Status = StatusFailed
-			item.Reason = processErr.Error()
-			items = append(items, item)
-			writeStatusLine(a.stdout, item)
-			return nil
-		}
-
-		items = append(items, item)
-		writeStatusLine(a.stdout, item)
+		items = append(items, a.processBatchItem(ctx, cfg, line, fetcher, renderer))
 		return nil
 	})
 	if err != nil {
@@ -32,3 +34,15 @@ func (a *App) runBatch(ctx context.Context, cfg config.Config, fetcher gh.Fetche
 
 	return BuildSummary(items), nil
 }
+
+// processBatchItem converts one URL in batch or query mode, recording failures on the item
+// instead of stopping the run.
+func (a *App) processBatchItem(ctx context.Context, cfg config.Config, rawURL string, fetcher gh.Fetcher, renderer converter.Renderer) ItemResult {
+	item, err := a.processOne(ctx, cfg, ModeBatch, rawURL, fetcher, renderer)
+	if err != nil {
+		item.Status = StatusFailed
+		item.Reason = err.Error()
+	}
+	writeStatusLine(a.stdout, item)
+	return item
+}
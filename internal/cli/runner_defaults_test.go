@@ -3,9 +3,13 @@ package cli
 import (
 	"bytes"
 	"context"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/johnqtcg/issue2md/internal/config"
+	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
 func TestAppRunWithDefaultDepsHandlesLoaderError(t *testing.T) {
@@ -18,7 +22,7 @@ func TestAppRunWithDefaultDepsHandlesLoaderError(t *testing.T) {
 		Stderr: stderr,
 	})
 
-	code := app.Run(context.Background(), []string{"--format", "json"})
+	code := app.Run(context.Background(), []string{"--format", "pdf"})
 	if code != ExitInvalidArguments {
 		t.Fatalf("Run exit code = %d, want %d", code, ExitInvalidArguments)
 	}
@@ -50,3 +54,28 @@ func TestDefaultFactoriesConstructors(t *testing.T) {
 		t.Fatal("defaultRendererFactory.New with API key returned nil renderer")
 	}
 }
+
+func TestWriteWaitLine(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	writeWaitLine(&buf, gh.RateLimitWait{
+		Resource: "core",
+		ResumeAt: time.Date(2026, 3, 1, 10, 30, 0, 0, time.FixedZone("CET", 3600)),
+	})
+
+	want := "WAIT rate_limit=core resume_at=2026-03-01T09:30:00Z\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("wait line = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultFetcherFactoryRequiresReadableAppKey(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "app.pem")
+	_, err := defaultFetcherFactory{}.New(config.Config{AppID: 7, AppKeyFile: missing, AppOwner: "acme"})
+	if err == nil || !strings.Contains(err.Error(), "read GitHub App private key") {
+		t.Fatalf("New error = %v, want private key read error", err)
+	}
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/johnqtcg/issue2md/internal/config"
+	"github.com/johnqtcg/issue2md/internal/converter"
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func (a *App) runQuery(ctx context.Context, cfg config.Config, fetcher gh.Fetcher, renderer converter.Renderer) (RunSummary, error) {
+	refs, err := search(ctx, fetcher, cfg.Query, searchHost(cfg.DefaultRepo), cfg.Limit)
+	if err != nil {
+		return RunSummary{}, err
+	}
+	if len(refs) >= gh.MaxSearchResults {
+		writeSearchCapWarning(a.stderr, cfg.Query)
+	}
+
+	items := make([]ItemResult, 0, len(refs))
+	for _, ref := range refs {
+		if cfg.DryRun {
+			writeMatchLine(a.stdout, ref)
+			items = append(items, ItemResult{URL: ref.URL, ResourceType: ref.Type, Status: StatusOK})
+			continue
+		}
+		items = append(items, a.processBatchItem(ctx, cfg, ref.URL, fetcher, renderer))
+	}
+	return BuildSummary(items), nil
+}
+
+func search(ctx context.Context, fetcher gh.Fetcher, query, host string, limit int) ([]gh.ResourceRef, error) {
+	searcher, ok := fetcher.(gh.Searcher)
+	if !ok {
+		return nil, fmt.Errorf("search %q: fetcher does not support search", query)
+	}
+	refs, err := searcher.Search(ctx, query, gh.SearchOptions{Host: host, Limit: limit})
+	if err != nil {
+		return nil, fmt.Errorf("search %q: %w", query, err)
+	}
+	return refs, nil
+}
+
+// searchHost searches the host named by a HOST/OWNER/REPO --repo value, or public GitHub.
+func searchHost(defaultRepo string) string {
+	if parts := strings.Split(defaultRepo, "/"); len(parts) == 3 {
+		return strings.ToLower(parts[0])
+	}
+	return gh.DefaultHost
+}
+
+// writeSearchCapWarning reports a query that reached the search API cap, so matches beyond it
+// were never listed.
+func writeSearchCapWarning(w io.Writer, query string) {
+	// #nosec G705 -- writes plain text status lines to CLI output, not HTML/browser context.
+	if _, err := fmt.Fprintf(w, "WARN query=%q results=%d error=search results are capped; split the query by created: ranges\n",
+		query, gh.MaxSearchResults); err != nil {
+		return
+	}
+}
+
+func writeMatchLine(w io.Writer, ref gh.ResourceRef) {
+	// #nosec G705 -- writes plain text status lines to CLI output, not HTML/browser context.
+	if _, err := fmt.Fprintf(w, "MATCH url=%s type=%s\n", ref.URL, ref.Type); err != nil {
+		return
+	}
+}
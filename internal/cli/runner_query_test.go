@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/johnqtcg/issue2md/internal/config"
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+type fakeSearchFetcher struct {
+	*fakeFetcher
+	refs     []gh.ResourceRef
+	gotQuery string
+	gotOpts  gh.SearchOptions
+}
+
+func (f *fakeSearchFetcher) Search(_ context.Context, query string, opts gh.SearchOptions) ([]gh.ResourceRef, error) {
+	f.gotQuery = query
+	f.gotOpts = opts
+	return f.refs, nil
+}
+
+func TestAppRunQuery(t *testing.T) {
+	t.Parallel()
+
+	u1 := "https://github.com/octo/repo/issues/1"
+	u2 := "https://github.com/octo/repo/pull/2"
+	refs := []gh.ResourceRef{
+		{Owner: "octo", Repo: "repo", Number: 1, Type: gh.ResourceIssue, URL: u1},
+		{Owner: "octo", Repo: "repo", Number: 2, Type: gh.ResourcePullRequest, URL: u2},
+	}
+
+	tcs := []struct {
+		name        string
+		wantLines   []string
+		dryRun      bool
+		wantFetches int
+	}{
+		{
+			name:        "exports every match",
+			wantLines:   []string{"OK url=" + u1 + " type=issue output=out.md", "OK url=" + u2 + " type=pull_request output=out.md", "OK total=2 succeeded=2 failed=0"},
+			wantFetches: 2,
+		},
+		{
+			name:      "dry run lists matches only",
+			dryRun:    true,
+			wantLines: []string{"MATCH url=" + u1 + " type=issue", "MATCH url=" + u2 + " type=pull_request", "OK total=2 succeeded=2 failed=0"},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fetcher := &fakeSearchFetcher{
+				fakeFetcher: &fakeFetcher{
+					dataByURL: map[string]gh.IssueData{
+						u1: minimalIssueData(gh.ResourceIssue, "i1", u1),
+						u2: minimalIssueData(gh.ResourcePullRequest, "p2", u2),
+					},
+					errByURL: map[string]error{},
+				},
+				refs: refs,
+			}
+			stdout := new(bytes.Buffer)
+			app := NewApp(AppDeps{
+				Loader: &fakeLoader{cfg: config.Config{
+					Query:       "repo:octo/repo",
+					Limit:       5,
+					OutputPath:  "out",
+					DryRun:      tc.dryRun,
+					DefaultRepo: "ghe.example.com/octo/repo",
+				}},
+				Parser:          &fakeParser{refByURL: map[string]gh.ResourceRef{u1: refs[0], u2: refs[1]}, errByURL: map[string]error{}},
+				FetcherFactory:  &fakeFetcherFactory{fetcher: fetcher},
+				RendererFactory: &fakeRendererFactory{renderer: &fakeRenderer{out: []byte("# ok"), errByTitle: map[string]error{}}},
+				Writer:          &fakeOutputWriter{path: "out.md", errByURL: map[string]error{}},
+				InputReader:     &fakeInputReader{},
+				Stdout:          stdout,
+				Stderr:          new(bytes.Buffer),
+			})
+
+			if code := app.Run(context.Background(), nil); code != ExitOK {
+				t.Fatalf("Run exit code = %d, want %d; stdout=%q", code, ExitOK, stdout.String())
+			}
+			if fetcher.gotQuery != "repo:octo/repo" || fetcher.gotOpts != (gh.SearchOptions{Host: "ghe.example.com", Limit: 5}) {
+				t.Fatalf("search = (%q, %#v), want repo:octo/repo on ghe.example.com limit 5", fetcher.gotQuery, fetcher.gotOpts)
+			}
+			if len(fetcher.gotRefs) != tc.wantFetches {
+				t.Fatalf("fetches = %d, want %d", len(fetcher.gotRefs), tc.wantFetches)
+			}
+			for _, line := range tc.wantLines {
+				if !strings.Contains(stdout.String(), line) {
+					t.Fatalf("stdout = %q, want line %q", stdout.String(), line)
+				}
+			}
+		})
+	}
+}
+
+func TestAppRunQuerySearchCap(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string
+		matches  int
+		wantWarn bool
+	}{
+		{name: "below cap", matches: 3},
+		{name: "at cap", matches: gh.MaxSearchResults, wantWarn: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			refs := make([]gh.ResourceRef, tc.matches)
+			for idx := range refs {
+				refs[idx] = gh.ResourceRef{Owner: "octo", Repo: "repo", Number: idx + 1, Type: gh.ResourceIssue}
+			}
+			stderr := new(bytes.Buffer)
+			app := NewApp(AppDeps{
+				Loader:          &fakeLoader{cfg: config.Config{Query: "repo:octo/repo", Limit: gh.MaxSearchResults, DryRun: true}},
+				Parser:          &fakeParser{},
+				FetcherFactory:  &fakeFetcherFactory{fetcher: &fakeSearchFetcher{fakeFetcher: &fakeFetcher{}, refs: refs}},
+				RendererFactory: &fakeRendererFactory{renderer: &fakeRenderer{}},
+				Writer:          &fakeOutputWriter{},
+				InputReader:     &fakeInputReader{},
+				Stdout:          new(bytes.Buffer),
+				Stderr:          stderr,
+			})
+
+			if code := app.Run(context.Background(), nil); code != ExitOK {
+				t.Fatalf("Run exit code = %d, want %d; stderr=%q", code, ExitOK, stderr.String())
+			}
+			if got := strings.Contains(stderr.String(), "WARN query=\"repo:octo/repo\" results=1000"); got != tc.wantWarn {
+				t.Fatalf("stderr = %q, want cap warning %t", stderr.String(), tc.wantWarn)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/johnqtcg/issue2md/internal/config"
+	"github.com/johnqtcg/issue2md/internal/converter"
+)
+
+// renderCommand is the first argument selecting offline re-rendering of JSON snapshots.
+const renderCommand = "render"
+
+// runRender re-renders snapshots written by --format json with the current flags and layout.
+// Nothing is fetched: the stored summary is replayed and no credentials are reported.
+func (a *App) runRender(ctx context.Context, args []string) int {
+	cfg, err := a.loader.Load(args)
+	if err != nil {
+		writeErrorLine(a.stderr, err)
+		return ResolveExitCode(err, false, 0)
+	}
+	mode, err := validateRenderArgs(cfg)
+	if err != nil {
+		writeErrorLine(a.stderr, err)
+		return ResolveExitCode(err, false, 0)
+	}
+	if err := a.loadTemplate(cfg); err != nil {
+		writeErrorLine(a.stderr, err)
+		return ResolveExitCode(err, false, 0)
+	}
+
+	statusOutput := a.stdout
+	if cfg.Stdout {
+		statusOutput = a.stderr
+	}
+	items := make([]ItemResult, 0, len(cfg.Positional))
+	var lastErr error
+	for _, path := range cfg.Positional {
+		item, renderErr := a.renderSnapshotFile(ctx, cfg, mode, path)
+		if renderErr != nil {
+			item.Status = StatusFailed
+			item.Reason = renderErr.Error()
+			lastErr = renderErr
+		}
+		writeStatusLine(statusOutput, item)
+		items = append(items, item)
+	}
+
+	if mode == ModeSingle {
+		return ResolveExitCode(lastErr, false, 0)
+	}
+	summary := BuildSummary(items)
+	if _, writeErr := fmt.Fprintln(a.stdout, FormatSummary(summary)); writeErr != nil {
+		writeErrorLine(a.stderr, fmt.Errorf("write summary output: %w", writeErr))
+	}
+	return ResolveExitCode(nil, true, summary.Failed)
+}
+
+func (a *App) renderSnapshotFile(ctx context.Context, cfg config.Config, mode Mode, path string) (ItemResult, error) {
+	item := ItemResult{Snapshot: path, Status: StatusFailed}
+
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return item, fmt.Errorf("read snapshot %q: %w", path, err)
+	}
+	snapshot, err := converter.DecodeSnapshot(raw)
+	if err != nil {
+		return item, fmt.Errorf("read snapshot %q: %w", path, err)
+	}
+	data := snapshot.Data
+	ref := data.Resolved
+	item.URL = data.Meta.URL
+	item.ResourceType = data.Meta.Type
+	if ref.Type == "" {
+		return item, fmt.Errorf("read snapshot %q: missing resolved resource", path)
+	}
+
+	includeComments := cfg.IncludeComments
+	switch {
+	case cfg.FocusComment && ref.CommentAnchor == "":
+		return item, fmt.Errorf("focus comment: %w", config.NewValidationError("focus-comment", "snapshot was not exported for a single comment"))
+	case cfg.FocusComment:
+		includeComments = true
+	default:
+		ref.CommentAnchor = ""
+	}
+
+	renderer := converter.NewRenderer(converter.NewSnapshotSummarizer(snapshot))
+	out, err := renderer.Render(ctx, data, a.renderOptions(cfg, includeComments, ref.CommentAnchor))
+	if err != nil {
+		return item, fmt.Errorf("render snapshot: %w", err)
+	}
+	outputPath, err := a.writer.Write(cfg, mode, ref, out)
+	if err != nil {
+		return item, fmt.Errorf("write output: %w", err)
+	}
+	item.Status = StatusOK
+	item.OutputPath = outputPath
+	return item, nil
+}
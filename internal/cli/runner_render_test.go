@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/johnqtcg/issue2md/internal/config"
+	"github.com/johnqtcg/issue2md/internal/converter"
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func writeTestSnapshot(t *testing.T, dir, name, title string) string {
+	t.Helper()
+
+	url := "https://github.com/octo/repo/issues/1"
+	data := minimalIssueData(gh.ResourceIssue, title, url)
+	data.Resolved = gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 1, Type: gh.ResourceIssue, URL: url}
+	raw, err := converter.NewRenderer(nil).Render(context.Background(), data, converter.RenderOptions{Format: converter.FormatJSON})
+	if err != nil {
+		t.Fatalf("render snapshot: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+	return path
+}
+
+func TestAppRunRender(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	first := writeTestSnapshot(t, dir, "first.json", "first title")
+	second := writeTestSnapshot(t, dir, "second.json", "second title")
+	missing := filepath.Join(dir, "missing.json")
+
+	tcs := []struct {
+		name         string
+		cfg          config.Config
+		wantStdout   []string
+		wantMarkdown []string
+		wantMode     Mode
+		wantCode     int
+	}{
+		{
+			name:         "single snapshot",
+			cfg:          config.Config{Positional: []string{first}, IncludeComments: true},
+			wantCode:     ExitOK,
+			wantMode:     ModeSingle,
+			wantStdout:   []string{"OK url=https://github.com/octo/repo/issues/1 type=issue snapshot=" + first + " output=out.md"},
+			wantMarkdown: []string{"# first title\n"},
+		},
+		{
+			name:         "several snapshots",
+			cfg:          config.Config{Positional: []string{first, second}, OutputPath: "out"},
+			wantCode:     ExitOK,
+			wantMode:     ModeBatch,
+			wantStdout:   []string{"snapshot=" + second, "total=2 succeeded=2 failed=0"},
+			wantMarkdown: []string{"# first title\n", "# second title\n"},
+		},
+		{
+			name:       "missing snapshot",
+			cfg:        config.Config{Positional: []string{missing}},
+			wantCode:   ExitRuntime,
+			wantStdout: []string{"FAILED url= type= snapshot=" + missing + " reason=read snapshot"},
+		},
+		{
+			name:     "several snapshots without output",
+			cfg:      config.Config{Positional: []string{first, second}},
+			wantCode: ExitInvalidArguments,
+		},
+		{
+			name:     "fetch-only flag",
+			cfg:      config.Config{Positional: []string{first}, DownloadAssets: true},
+			wantCode: ExitInvalidArguments,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			loader := &fakeLoader{cfg: tc.cfg}
+			writer := &fakeOutputWriter{path: "out.md", errByURL: map[string]error{}}
+			// Rendering must never build a fetcher; one that fails would surface in the exit code.
+			fetcherFactory := &fakeFetcherFactory{err: errors.New("network disabled")}
+			stdout := new(bytes.Buffer)
+			app := NewApp(AppDeps{
+				Loader:         loader,
+				FetcherFactory: fetcherFactory,
+				Writer:         writer,
+				Stdout:         stdout,
+				Stderr:         new(bytes.Buffer),
+			})
+
+			if code := app.Run(context.Background(), []string{"render", "--output", "out"}); code != tc.wantCode {
+				t.Fatalf("Run exit code = %d, want %d (stdout %q)", code, tc.wantCode, stdout.String())
+			}
+			if got := strings.Join(loader.gotArgs, " "); got != "--output out" {
+				t.Fatalf("loader args = %q, want the command name stripped", got)
+			}
+			for _, piece := range tc.wantStdout {
+				if !strings.Contains(stdout.String(), piece) {
+					t.Fatalf("stdout missing %q\n%s", piece, stdout.String())
+				}
+			}
+			if len(writer.gotMarkdown) != len(tc.wantMarkdown) {
+				t.Fatalf("written documents = %d, want %d", len(writer.gotMarkdown), len(tc.wantMarkdown))
+			}
+			for i, piece := range tc.wantMarkdown {
+				if !strings.Contains(writer.gotMarkdown[i], piece) {
+					t.Fatalf("document %d missing %q\n%s", i, piece, writer.gotMarkdown[i])
+				}
+				if writer.gotMode[i] != tc.wantMode {
+					t.Fatalf("write mode = %q, want %q", writer.gotMode[i], tc.wantMode)
+				}
+			}
+		})
+	}
+}
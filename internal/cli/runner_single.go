@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/johnqtcg/issue2md/internal/config"
 	"github.com/johnqtcg/issue2md/internal/converter"
@@ -35,8 +38,10 @@ type AppDeps struct {
 	RendererFactory RendererFactory
 	Writer          OutputWriter
 	InputReader     InputReader
-	Stdout          io.Writer
-	Stderr          io.Writer
+	// AssetLocalizer handles --download-assets; nil selects a downloader built from the loaded config.
+	AssetLocalizer AssetLocalizer
+	Stdout         io.Writer
+	Stderr         io.Writer
 }
 
 // App orchestrates CLI single and batch workflows.
@@ -47,8 +52,11 @@ type App struct {
 	rendererFactory RendererFactory
 	writer          OutputWriter
 	inputReader     InputReader
-	stdout          io.Writer
-	stderr          io.Writer
+	assets          AssetLocalizer
+	// template is the parsed --template layout; nil renders the built-in layout.
+	template *converter.Template
+	stdout   io.Writer
+	stderr   io.Writer
 }
 
 // NewApp creates a CLI runner with injected dependencies.
@@ -60,6 +68,7 @@ func NewApp(deps AppDeps) Runner {
 		rendererFactory: deps.RendererFactory,
 		writer:          deps.Writer,
 		inputReader:     deps.InputReader,
+		assets:          deps.AssetLocalizer,
 		stdout:          deps.Stdout,
 		stderr:          deps.Stderr,
 	}
@@ -71,12 +80,6 @@ func (a *App) setDefaults() {
 	if a.loader == nil {
 		a.loader = config.NewLoader()
 	}
-	if a.parser == nil {
-		a.parser = parser.New()
-	}
-	if a.fetcherFactory == nil {
-		a.fetcherFactory = defaultFetcherFactory{}
-	}
 	if a.rendererFactory == nil {
 		a.rendererFactory = defaultRendererFactory{}
 	}
@@ -92,10 +95,17 @@ func (a *App) setDefaults() {
 	if a.stderr == nil {
 		a.stderr = os.Stderr
 	}
+	if a.fetcherFactory == nil {
+		a.fetcherFactory = defaultFetcherFactory{stderr: a.stderr}
+	}
 }
 
-// Run executes the CLI workflow and returns an exit code.
+// Run executes the CLI workflow and returns an exit code. A leading "render" argument
+// re-renders saved JSON snapshots instead of fetching.
 func (a *App) Run(ctx context.Context, args []string) int {
+	if len(args) > 0 && args[0] == renderCommand {
+		return a.runRender(ctx, args[1:])
+	}
 	cfg, err := a.loader.Load(args)
 	if err != nil {
 		writeErrorLine(a.stderr, err)
@@ -107,7 +117,19 @@ func (a *App) Run(ctx context.Context, args []string) int {
 		writeErrorLine(a.stderr, err)
 		return ResolveExitCode(err, false, 0)
 	}
+	if err := a.loadTemplate(cfg); err != nil {
+		writeErrorLine(a.stderr, err)
+		return ResolveExitCode(err, false, 0)
+	}
+	if a.parser == nil {
+		// The default parser depends on the enterprise hosts and default repo in the loaded config.
+		a.parser = parser.NewWithOptions(parser.Options{
+			AllowedHosts: cfg.AllowedHosts,
+			DefaultRepo:  cfg.DefaultRepo,
+		})
+	}
 
+	writeAuthLines(a.stderr, cfg.TokenSources)
 	fetcher, err := a.fetcherFactory.New(cfg)
 	if err != nil {
 		runErr := fmt.Errorf("build fetcher: %w", err)
@@ -115,6 +137,10 @@ func (a *App) Run(ctx context.Context, args []string) int {
 		return ResolveExitCode(runErr, false, 0)
 	}
 
+	if cfg.DownloadAssets && a.assets == nil {
+		a.assets = newAssetLocalizer(cfg, fetcher)
+	}
+
 	renderer := a.rendererFactory.New(cfg)
 	singleStatusOutput := a.stdout
 	if validated.Mode == ModeSingle && cfg.Stdout {
@@ -133,8 +159,11 @@ func (a *App) Run(ctx context.Context, args []string) int {
 		}
 		writeStatusLine(singleStatusOutput, item)
 		return ExitOK
-	case ModeBatch:
-		summary, runErr := a.runBatch(ctx, cfg, fetcher, renderer)
+	case ModeBatch, ModeQuery:
+		summary, runErr := a.runMany(ctx, cfg, validated.Mode, fetcher, renderer)
+		if reporter, ok := fetcher.(gh.CacheReporter); ok {
+			summary.CacheHits, summary.CacheMisses = reporter.CacheStats()
+		}
 		if runErr != nil {
 			writeErrorLine(a.stderr, runErr)
 		}
@@ -158,47 +187,192 @@ func (a *App) runSingle(ctx context.Context, cfg config.Config, args Args, fetch
 }
 
 func (a *App) processOne(ctx context.Context, cfg config.Config, mode Mode, rawURL string, fetcher gh.Fetcher, renderer converter.Renderer) (ItemResult, error) {
-	item := ItemResult{
+	conv, err := a.convertOne(ctx, cfg, rawURL, fetcher, renderer)
+	if err != nil {
+		return conv.item, err
+	}
+
+	outputPath, err := a.writer.Write(cfg, mode, conv.ref, conv.markdown)
+	if err != nil {
+		return conv.item, fmt.Errorf("write output: %w", err)
+	}
+
+	item := conv.item
+	item.Status = StatusOK
+	item.OutputPath = outputPath
+	if err := a.localizeAssets(ctx, cfg, mode, conv.ref, conv.markdown, &item); err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// conversion is one fetched and rendered resource that has not been written yet.
+type conversion struct {
+	ref       gh.ResourceRef
+	item      ItemResult
+	data      gh.IssueData
+	opts      converter.RenderOptions
+	updatedAt string
+	markdown  []byte
+}
+
+func (a *App) convertOne(ctx context.Context, cfg config.Config, rawURL string, fetcher gh.Fetcher, renderer converter.Renderer) (conversion, error) {
+	conv, err := a.fetchOne(ctx, cfg, rawURL, fetcher)
+	if err != nil {
+		return conv, err
+	}
+	if err := conv.render(ctx, renderer); err != nil {
+		return conv, err
+	}
+	return conv, nil
+}
+
+// fetchOne fetches the resource behind rawURL and the options to render it with.
+func (a *App) fetchOne(ctx context.Context, cfg config.Config, rawURL string, fetcher gh.Fetcher) (conversion, error) {
+	conv := conversion{item: ItemResult{
 		URL:    rawURL,
 		Status: StatusFailed,
-	}
+	}}
 
 	ref, err := a.parser.Parse(rawURL)
 	if err != nil {
-		return item, fmt.Errorf("parse URL: %w", err)
+		return conv, fmt.Errorf("parse URL: %w", err)
+	}
+	conv.item.ResourceType = ref.Type
+
+	includeComments := cfg.IncludeComments
+	switch {
+	case cfg.FocusComment && ref.CommentAnchor == "":
+		return conv, fmt.Errorf("focus comment: %w", config.NewValidationError("focus-comment", "URL needs an #issuecomment-, #discussion_r, #discussioncomment- or #pullrequestreview- fragment"))
+	case cfg.FocusComment:
+		includeComments = true
+	default:
+		// A whole-thread export ignores the fragment and keeps the plain file name.
+		ref.CommentAnchor = ""
 	}
-	item.ResourceType = ref.Type
 
-	data, err := fetcher.Fetch(ctx, ref, gh.FetchOptions{IncludeComments: cfg.IncludeComments})
+	data, err := fetcher.Fetch(ctx, ref, fetchOptions(cfg, includeComments))
 	if err != nil {
-		return item, fmt.Errorf("fetch resource: %w", err)
+		return conv, fmt.Errorf("fetch resource: %w", err)
 	}
+	if resolved := data.Resolved; resolved.Type != "" {
+		if resolved.URL != ref.URL {
+			conv.item.ResolvedURL = resolved.URL
+		}
+		conv.item.ResourceType = resolved.Type
+		ref = resolved
+	}
+
+	conv.ref = ref
+	conv.data = data
+	conv.opts = a.renderOptions(cfg, includeComments, ref.CommentAnchor)
+	conv.updatedAt = data.Meta.UpdatedAt
+	return conv, nil
+}
 
-	markdown, err := renderer.Render(ctx, data, converter.RenderOptions{
-		IncludeComments: cfg.IncludeComments,
-		IncludeSummary:  true,
-		Lang:            cfg.SummaryLang,
-	})
+func (c *conversion) render(ctx context.Context, renderer converter.Renderer) error {
+	markdown, err := renderer.Render(ctx, c.data, c.opts)
 	if err != nil {
-		return item, fmt.Errorf("render markdown: %w", err)
+		return fmt.Errorf("render markdown: %w", err)
 	}
+	c.markdown = markdown
+	return nil
+}
 
-	outputPath, err := a.writer.Write(cfg, mode, ref, markdown)
+// loadTemplate parses the --template file once per run. A file that cannot be read or parsed
+// is an invalid argument.
+func (a *App) loadTemplate(cfg config.Config) error {
+	if cfg.TemplateFile == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(filepath.Clean(cfg.TemplateFile))
 	if err != nil {
-		return item, fmt.Errorf("write output: %w", err)
+		return fmt.Errorf("load template: %w", config.NewValidationError("template", err.Error()))
 	}
+	tmpl, err := converter.ParseTemplate(filepath.Base(cfg.TemplateFile), string(raw))
+	if err != nil {
+		return fmt.Errorf("load template: %w", config.NewValidationError("template", err.Error()))
+	}
+	a.template = tmpl
+	return nil
+}
 
-	item.Status = StatusOK
-	item.OutputPath = outputPath
-	return item, nil
+// fetchOptions maps the loaded config onto fetcher options for one resource.
+func fetchOptions(cfg config.Config, includeComments bool) gh.FetchOptions {
+	return gh.FetchOptions{
+		IncludeComments:      includeComments,
+		IncludeFiles:         cfg.IncludeFiles,
+		IncludeCommits:       cfg.IncludeCommits,
+		IncludeChecks:        cfg.IncludeChecks,
+		IncludeEdits:         cfg.EditHistory != "",
+		IncludeEditRevisions: cfg.EditHistory == config.EditHistoryDiff,
+	}
 }
 
-type defaultFetcherFactory struct{}
+// renderOptions maps the loaded config onto converter options for one resource.
+func (a *App) renderOptions(cfg config.Config, includeComments bool, focusAnchor string) converter.RenderOptions {
+	return converter.RenderOptions{
+		Template:            a.template,
+		Format:              converter.Format(cfg.Format),
+		IncludeComments:     includeComments,
+		IncludeSummary:      true,
+		IncludeFiles:        cfg.IncludeFiles,
+		IncludePatches:      cfg.IncludePatches,
+		IncludeCommits:      cfg.IncludeCommits,
+		IncludeChecks:       cfg.IncludeChecks,
+		MaxPatchBytes:       cfg.MaxPatchBytes,
+		MaxChunkTokens:      cfg.MaxChunkTokens,
+		HideResolvedThreads: cfg.HideResolved,
+		IncludeReactions:    cfg.IncludeReactions,
+		TopReactedComments:  cfg.TopReacted,
+		FocusComment:        focusAnchor,
+		FocusContext:        cfg.ContextComments,
+		Lang:                cfg.SummaryLang,
+		EditDiffs:           cfg.EditHistory == config.EditHistoryDiff,
+		Minimized:           converter.MinimizedMode(cfg.MinimizedComments),
+		CommentStyle:        converter.CommentStyle(cfg.CommentStyle),
+	}
+}
+
+type defaultFetcherFactory struct {
+	// stderr, when set, receives a WAIT line each time fetching pauses for a rate limit reset.
+	stderr io.Writer
+}
 
 func (f defaultFetcherFactory) New(cfg config.Config) (gh.Fetcher, error) {
-	fetcher, err := gh.NewFetcher(gh.Config{
-		Token: cfg.Token,
-	})
+	ghCfg := gh.Config{
+		Token:            cfg.Token,
+		MaxRateLimitWait: cfg.MaxRateLimitWait,
+	}
+	if f.stderr != nil {
+		stderr := f.stderr
+		ghCfg.OnRateLimitWait = func(wait gh.RateLimitWait) {
+			writeWaitLine(stderr, wait)
+		}
+	}
+	if cfg.CacheDir != "" {
+		var onStoreError func(error)
+		if f.stderr != nil {
+			stderr := f.stderr
+			onStoreError = func(err error) {
+				writeCacheWarning(stderr, cfg.CacheDir, err)
+			}
+		}
+		ghCfg.Cache = gh.NewHTTPCache(cfg.CacheDir, gh.DefaultGraphQLCacheTTL, onStoreError)
+	}
+	if cfg.AppID != 0 {
+		key, err := os.ReadFile(filepath.Clean(cfg.AppKeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("read GitHub App private key %q: %w", cfg.AppKeyFile, err)
+		}
+		ghCfg.App = &gh.AppAuth{
+			PrivateKey:     key,
+			Owner:          cfg.AppOwner,
+			AppID:          cfg.AppID,
+			InstallationID: cfg.AppInstallationID,
+		}
+	}
+	fetcher, err := gh.NewHostFetcher(ghCfg, cfg.HostTokens)
 	if err != nil {
 		return nil, fmt.Errorf("create fetcher: %w", err)
 	}
@@ -223,17 +397,66 @@ func writeStatusLine(w io.Writer, item ItemResult) {
 	switch item.Status {
 	case StatusOK:
 		// #nosec G705 -- writes plain text status lines to CLI output, not HTML/browser context.
-		if _, err := fmt.Fprintf(w, "OK url=%s type=%s output=%s\n", item.URL, item.ResourceType, item.OutputPath); err != nil {
+		extra := ""
+		if item.ResolvedURL != "" {
+			extra += " resolved=" + item.ResolvedURL
+		}
+		if item.Sync != "" {
+			extra += " sync=" + string(item.Sync)
+		}
+		if item.Assets+item.AssetFailures > 0 {
+			extra += fmt.Sprintf(" assets=%d asset_failures=%d", item.Assets, item.AssetFailures)
+		}
+		if item.Snapshot != "" {
+			extra += " snapshot=" + item.Snapshot
+		}
+		if _, err := fmt.Fprintf(w, "OK url=%s type=%s%s output=%s\n", item.URL, item.ResourceType, extra, item.OutputPath); err != nil {
 			return
 		}
 	default:
+		source := ""
+		if item.Snapshot != "" {
+			source = " snapshot=" + item.Snapshot
+		}
 		// #nosec G705 -- writes plain text status lines to CLI output, not HTML/browser context.
-		if _, err := fmt.Fprintf(w, "FAILED url=%s type=%s reason=%s\n", item.URL, item.ResourceType, item.Reason); err != nil {
+		if _, err := fmt.Fprintf(w, "FAILED url=%s type=%s%s reason=%s\n", item.URL, item.ResourceType, source, item.Reason); err != nil {
 			return
 		}
 	}
 }
 
+// writeAuthLines reports which source supplied each host's credential, never the credential.
+func writeAuthLines(w io.Writer, sources map[string]string) {
+	hosts := make([]string, 0, len(sources))
+	for host := range sources {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		// #nosec G705 -- writes plain text status lines to CLI output, not HTML/browser context.
+		if _, err := fmt.Fprintf(w, "AUTH host=%s source=%s\n", host, sources[host]); err != nil {
+			return
+		}
+	}
+}
+
+// writeWaitLine tells the user why a run went quiet and when it resumes.
+func writeWaitLine(w io.Writer, wait gh.RateLimitWait) {
+	// #nosec G705 -- writes plain text status lines to CLI output, not HTML/browser context.
+	if _, err := fmt.Fprintf(w, "WAIT rate_limit=%s resume_at=%s\n", wait.Resource, wait.ResumeAt.UTC().Format(time.RFC3339)); err != nil {
+		return
+	}
+}
+
+// writeCacheWarning reports the first response the cache could not store; fetching goes on
+// without it.
+func writeCacheWarning(w io.Writer, dir string, err error) {
+	// #nosec G705 -- writes plain text status lines to CLI output, not HTML/browser context.
+	if _, writeErr := fmt.Fprintf(w, "WARN cache_dir=%s error=%v\n", dir, err); writeErr != nil {
+		return
+	}
+}
+
 func writeErrorLine(w io.Writer, err error) {
 	if _, writeErr := fmt.Fprintf(w, "error: %v\n", err); writeErr != nil {
 		return
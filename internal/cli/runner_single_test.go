@@ -6,10 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/johnqtcg/issue2md/internal/config"
+	"github.com/johnqtcg/issue2md/internal/converter"
 	gh "github.com/johnqtcg/issue2md/internal/github"
 	"github.com/johnqtcg/issue2md/internal/parser"
 )
@@ -240,3 +243,314 @@ func TestAppRunSingleStdoutModeKeepsStdoutPureMarkdown(t *testing.T) {
 		t.Fatalf("stderr = %q, want status line", stderr.String())
 	}
 }
+
+func TestAppRunSinglePassesPullRequestOptions(t *testing.T) {
+	t.Parallel()
+
+	url := "https://github.com/octo/repo/pull/7"
+	ref := gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 7, Type: gh.ResourcePullRequest, URL: url}
+	data := gh.IssueData{Meta: gh.Metadata{Type: gh.ResourcePullRequest, Title: "pr title", Number: 7, URL: url}}
+
+	fetcher := &fakeFetcher{dataByURL: map[string]gh.IssueData{url: data}, errByURL: map[string]error{}}
+	renderer := &fakeRenderer{out: []byte("# markdown"), errByTitle: map[string]error{}}
+	app := NewApp(AppDeps{
+		Loader: &fakeLoader{cfg: config.Config{
+			Positional:        []string{url},
+			IncludeFiles:      true,
+			IncludePatches:    true,
+			IncludeChecks:     true,
+			MaxPatchBytes:     2048,
+			TopReacted:        3,
+			MinimizedComments: string(converter.MinimizedSkip),
+			CommentStyle:      string(converter.CommentStyleBlock),
+			Format:            string(converter.FormatJSON),
+		}},
+		Parser:          &fakeParser{refByURL: map[string]gh.ResourceRef{url: ref}, errByURL: map[string]error{}},
+		FetcherFactory:  &fakeFetcherFactory{fetcher: fetcher},
+		RendererFactory: &fakeRendererFactory{renderer: renderer},
+		Writer:          &fakeOutputWriter{path: "out.md", errByURL: map[string]error{}},
+		InputReader:     &fakeInputReader{},
+		Stdout:          new(bytes.Buffer),
+		Stderr:          new(bytes.Buffer),
+	})
+
+	if code := app.Run(context.Background(), []string{url}); code != ExitOK {
+		t.Fatalf("Run exit code = %d, want %d", code, ExitOK)
+	}
+	if len(fetcher.gotOpts) != 1 || !fetcher.gotOpts[0].IncludeFiles || !fetcher.gotOpts[0].IncludeChecks || fetcher.gotOpts[0].IncludeCommits {
+		t.Fatalf("fetcher opts = %#v, want files and checks without commits", fetcher.gotOpts)
+	}
+	got := renderer.gotOpts[0]
+	if !got.IncludeFiles || !got.IncludePatches || !got.IncludeChecks || got.IncludeCommits || got.MaxPatchBytes != 2048 {
+		t.Fatalf("renderer opts = %#v, want files+patches with 2048 byte cap", got)
+	}
+	if got.Minimized != converter.MinimizedSkip || got.Format != converter.FormatJSON {
+		t.Fatalf("renderer (Minimized, Format) = (%q, %q), want (%q, %q)", got.Minimized, got.Format, converter.MinimizedSkip, converter.FormatJSON)
+	}
+	if got.CommentStyle != converter.CommentStyleBlock {
+		t.Fatalf("renderer CommentStyle = %q, want %q", got.CommentStyle, converter.CommentStyleBlock)
+	}
+	if got.IncludeReactions || got.TopReactedComments != 3 {
+		t.Fatalf("renderer reaction opts = (%t, %d), want (false, 3)", got.IncludeReactions, got.TopReactedComments)
+	}
+}
+
+func TestAppRunSingleTemplate(t *testing.T) {
+	t.Parallel()
+
+	url := "https://github.com/octo/repo/issues/5"
+	ref := gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 5, Type: gh.ResourceIssue, URL: url}
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "layout.tmpl")
+	invalid := filepath.Join(dir, "broken.tmpl")
+	if err := os.WriteFile(valid, []byte("# {{.Meta.Title}}\n"), 0o644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	if err := os.WriteFile(invalid, []byte("{{.Meta.Title"), 0o644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	tcs := []struct {
+		name         string
+		file         string
+		wantStderr   string
+		wantCode     int
+		wantTemplate bool
+	}{
+		{name: "built-in layout", wantCode: ExitOK},
+		{name: "custom layout", file: valid, wantCode: ExitOK, wantTemplate: true},
+		{name: "missing file", file: filepath.Join(dir, "missing.tmpl"), wantCode: ExitInvalidArguments, wantStderr: "invalid template"},
+		{name: "parse error", file: invalid, wantCode: ExitInvalidArguments, wantStderr: `parse template "broken.tmpl"`},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			data := gh.IssueData{Meta: gh.Metadata{Type: gh.ResourceIssue, Title: "issue", Number: 5, URL: url}}
+			renderer := &fakeRenderer{out: []byte("# markdown"), errByTitle: map[string]error{}}
+			stderr := new(bytes.Buffer)
+			app := NewApp(AppDeps{
+				Loader:          &fakeLoader{cfg: config.Config{Positional: []string{url}, TemplateFile: tc.file}},
+				Parser:          &fakeParser{refByURL: map[string]gh.ResourceRef{url: ref}, errByURL: map[string]error{}},
+				FetcherFactory:  &fakeFetcherFactory{fetcher: &fakeFetcher{dataByURL: map[string]gh.IssueData{url: data}, errByURL: map[string]error{}}},
+				RendererFactory: &fakeRendererFactory{renderer: renderer},
+				Writer:          &fakeOutputWriter{path: "out.md", errByURL: map[string]error{}},
+				InputReader:     &fakeInputReader{},
+				Stdout:          new(bytes.Buffer),
+				Stderr:          stderr,
+			})
+
+			if code := app.Run(context.Background(), []string{url}); code != tc.wantCode {
+				t.Fatalf("Run exit code = %d, want %d (stderr %q)", code, tc.wantCode, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tc.wantStderr) {
+				t.Fatalf("stderr = %q, want %q", stderr.String(), tc.wantStderr)
+			}
+			if tc.wantCode != ExitOK {
+				if len(renderer.gotOpts) != 0 {
+					t.Fatalf("renderer called %d times, want 0", len(renderer.gotOpts))
+				}
+				return
+			}
+			if got := renderer.gotOpts[0].Template != nil; got != tc.wantTemplate {
+				t.Fatalf("renderer Template set = %t, want %t", got, tc.wantTemplate)
+			}
+		})
+	}
+}
+
+func TestAppRunSinglePassesEditHistoryOptions(t *testing.T) {
+	t.Parallel()
+
+	url := "https://github.com/octo/repo/issues/9"
+	ref := gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 9, Type: gh.ResourceIssue, URL: url}
+	tcs := []struct {
+		mode          string
+		wantEdits     bool
+		wantRevisions bool
+	}{
+		{mode: ""},
+		{mode: config.EditHistoryCount, wantEdits: true},
+		{mode: config.EditHistoryDiff, wantEdits: true, wantRevisions: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run("mode "+tc.mode, func(t *testing.T) {
+			t.Parallel()
+			fetcher := &fakeFetcher{dataByURL: map[string]gh.IssueData{url: minimalIssueData(gh.ResourceIssue, "edited", url)}}
+			renderer := &fakeRenderer{out: []byte("# markdown")}
+			app := NewApp(AppDeps{
+				Loader:          &fakeLoader{cfg: config.Config{Positional: []string{url}, EditHistory: tc.mode}},
+				Parser:          &fakeParser{refByURL: map[string]gh.ResourceRef{url: ref}},
+				FetcherFactory:  &fakeFetcherFactory{fetcher: fetcher},
+				RendererFactory: &fakeRendererFactory{renderer: renderer},
+				Writer:          &fakeOutputWriter{path: "out.md"},
+				Stdout:          new(bytes.Buffer),
+				Stderr:          new(bytes.Buffer),
+			})
+
+			if code := app.Run(context.Background(), []string{url}); code != ExitOK {
+				t.Fatalf("Run exit code = %d, want %d", code, ExitOK)
+			}
+			opts := fetcher.gotOpts[0]
+			if opts.IncludeEdits != tc.wantEdits || opts.IncludeEditRevisions != tc.wantRevisions {
+				t.Fatalf("fetch edit opts = (%t, %t), want (%t, %t)", opts.IncludeEdits, opts.IncludeEditRevisions, tc.wantEdits, tc.wantRevisions)
+			}
+			if got := renderer.gotOpts[0].EditDiffs; got != tc.wantRevisions {
+				t.Fatalf("renderer EditDiffs = %t, want %t", got, tc.wantRevisions)
+			}
+		})
+	}
+}
+
+func TestAppRunSingleReportsResolvedResource(t *testing.T) {
+	t.Parallel()
+
+	url := "https://github.com/octo/repo/issues/7"
+	resolvedURL := "https://github.com/octo/repo/pull/7"
+	ref := gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 7, Type: gh.ResourceIssue, URL: url}
+	data := gh.IssueData{
+		Meta:     gh.Metadata{Type: gh.ResourcePullRequest, Title: "pr title", Number: 7, URL: resolvedURL, RequestedURL: url},
+		Resolved: gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 7, Type: gh.ResourcePullRequest, URL: resolvedURL},
+	}
+	writer := &fakeOutputWriter{path: "out.md", errByURL: map[string]error{}}
+	stdout := new(bytes.Buffer)
+	app := NewApp(AppDeps{
+		Loader:          &fakeLoader{cfg: config.Config{Positional: []string{url}}},
+		Parser:          &fakeParser{refByURL: map[string]gh.ResourceRef{url: ref}, errByURL: map[string]error{}},
+		FetcherFactory:  &fakeFetcherFactory{fetcher: &fakeFetcher{dataByURL: map[string]gh.IssueData{url: data}, errByURL: map[string]error{}}},
+		RendererFactory: &fakeRendererFactory{renderer: &fakeRenderer{out: []byte("# markdown"), errByTitle: map[string]error{}}},
+		Writer:          writer,
+		InputReader:     &fakeInputReader{},
+		Stdout:          stdout,
+		Stderr:          new(bytes.Buffer),
+	})
+
+	if code := app.Run(context.Background(), []string{url}); code != ExitOK {
+		t.Fatalf("Run exit code = %d, want %d", code, ExitOK)
+	}
+	if len(writer.gotRefs) != 1 || writer.gotRefs[0].Type != gh.ResourcePullRequest {
+		t.Fatalf("writer refs = %#v, want resolved pull request ref", writer.gotRefs)
+	}
+	want := "OK url=" + url + " type=pull_request resolved=" + resolvedURL + " output=out.md"
+	if !strings.Contains(stdout.String(), want) {
+		t.Fatalf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestAppRunSingleFocusComment(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		anchor     string
+		focus      bool
+		wantCode   int
+		wantAnchor string
+	}{
+		{name: "focus with anchor", anchor: "issuecomment-42", focus: true, wantCode: ExitOK, wantAnchor: "issuecomment-42"},
+		{name: "focus without anchor", focus: true, wantCode: ExitInvalidArguments},
+		{name: "anchor ignored without focus", anchor: "issuecomment-42", wantCode: ExitOK},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			url := "https://github.com/octo/repo/issues/5#" + tc.anchor
+			ref := gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 5, Type: gh.ResourceIssue, URL: url, CommentAnchor: tc.anchor}
+			data := gh.IssueData{Meta: gh.Metadata{Type: gh.ResourceIssue, Title: "issue", Number: 5, URL: url}}
+			fetcher := &fakeFetcher{dataByURL: map[string]gh.IssueData{url: data}, errByURL: map[string]error{}}
+			renderer := &fakeRenderer{out: []byte("# markdown"), errByTitle: map[string]error{}}
+			app := NewApp(AppDeps{
+				Loader: &fakeLoader{cfg: config.Config{
+					Positional:      []string{url},
+					FocusComment:    tc.focus,
+					ContextComments: 1,
+				}},
+				Parser:          &fakeParser{refByURL: map[string]gh.ResourceRef{url: ref}, errByURL: map[string]error{}},
+				FetcherFactory:  &fakeFetcherFactory{fetcher: fetcher},
+				RendererFactory: &fakeRendererFactory{renderer: renderer},
+				Writer:          &fakeOutputWriter{path: "out.md", errByURL: map[string]error{}},
+				InputReader:     &fakeInputReader{},
+				Stdout:          new(bytes.Buffer),
+				Stderr:          new(bytes.Buffer),
+			})
+
+			if code := app.Run(context.Background(), []string{url}); code != tc.wantCode {
+				t.Fatalf("Run exit code = %d, want %d", code, tc.wantCode)
+			}
+			if tc.wantCode != ExitOK {
+				return
+			}
+			if tc.focus && !fetcher.gotOpts[0].IncludeComments {
+				t.Fatalf("fetcher opts = %#v, want comments forced on for focus", fetcher.gotOpts[0])
+			}
+			got := renderer.gotOpts[0]
+			if got.FocusComment != tc.wantAnchor || got.FocusContext != 1 {
+				t.Fatalf("renderer focus = (%q, %d), want (%q, 1)", got.FocusComment, got.FocusContext, tc.wantAnchor)
+			}
+		})
+	}
+}
+
+func TestAppRunSingleDefaultParserAcceptsAllowedEnterpriseHost(t *testing.T) {
+	t.Parallel()
+
+	url := "https://ghe.example.com/corp/app/issues/7"
+	data := gh.IssueData{Meta: gh.Metadata{Type: gh.ResourceIssue, Title: "Enterprise issue"}}
+	fetcher := &fakeFetcher{dataByURL: map[string]gh.IssueData{url: data}, errByURL: map[string]error{}}
+	app := NewApp(AppDeps{
+		Loader: &fakeLoader{cfg: config.Config{
+			Positional:   []string{url},
+			AllowedHosts: []string{"ghe.example.com"},
+		}},
+		FetcherFactory:  &fakeFetcherFactory{fetcher: fetcher},
+		RendererFactory: &fakeRendererFactory{renderer: &fakeRenderer{out: []byte("# markdown"), errByTitle: map[string]error{}}},
+		Writer:          &fakeOutputWriter{path: "out.md", errByURL: map[string]error{}},
+		InputReader:     &fakeInputReader{},
+		Stdout:          new(bytes.Buffer),
+		Stderr:          new(bytes.Buffer),
+	})
+
+	if code := app.Run(context.Background(), []string{url}); code != ExitOK {
+		t.Fatalf("Run exit code = %d, want %d", code, ExitOK)
+	}
+	if len(fetcher.gotRefs) != 1 || fetcher.gotRefs[0].Host != "ghe.example.com" || fetcher.gotRefs[0].URL != url {
+		t.Fatalf("fetched refs = %#v, want enterprise host ref", fetcher.gotRefs)
+	}
+}
+
+func TestAppRunReportsTokenSources(t *testing.T) {
+	t.Parallel()
+
+	url := "https://github.com/octo/repo/issues/1"
+	ref := gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 1, Type: gh.ResourceIssue, URL: url}
+	loader := &fakeLoader{cfg: config.Config{
+		Positional:   []string{url},
+		Token:        "gho_secret",
+		TokenSources: map[string]string{"github.com": "gh-config:/home/u/.config/gh/hosts.yml", "ghe.corp.com": "git-credential"},
+	}}
+	stderr := new(bytes.Buffer)
+	app := NewApp(AppDeps{
+		Loader:          loader,
+		Parser:          &fakeParser{refByURL: map[string]gh.ResourceRef{url: ref}, errByURL: map[string]error{}},
+		FetcherFactory:  &fakeFetcherFactory{fetcher: &fakeFetcher{dataByURL: map[string]gh.IssueData{url: minimalIssueData(gh.ResourceIssue, "t", url)}, errByURL: map[string]error{}}},
+		RendererFactory: &fakeRendererFactory{renderer: &fakeRenderer{out: []byte("# markdown"), errByTitle: map[string]error{}}},
+		Writer:          &fakeOutputWriter{path: "out.md", errByURL: map[string]error{}},
+		InputReader:     &fakeInputReader{},
+		Stdout:          new(bytes.Buffer),
+		Stderr:          stderr,
+	})
+
+	if code := app.Run(context.Background(), []string{url}); code != ExitOK {
+		t.Fatalf("Run exit code = %d, want %d; stderr=%s", code, ExitOK, stderr.String())
+	}
+	want := "AUTH host=ghe.corp.com source=git-credential\nAUTH host=github.com source=gh-config:/home/u/.config/gh/hosts.yml\n"
+	if got := stderr.String(); got != want {
+		t.Fatalf("stderr = %q, want %q", got, want)
+	}
+}
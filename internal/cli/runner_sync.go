@@ -0,0 +1,359 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johnqtcg/issue2md/internal/config"
+	"github.com/johnqtcg/issue2md/internal/converter"
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+const (
+	defaultSyncStateFile = ".issue2md-sync.json"
+	// maxSyncSearchResults is the GitHub search API result cap; a repository that reaches it
+	// may have more changes than were listed, so its items are all refetched.
+	maxSyncSearchResults = gh.MaxSearchResults
+)
+
+// syncEntry is what the state file remembers about one exported URL.
+type syncEntry struct {
+	UpdatedAt string `json:"updated_at"`
+	// Hash fingerprints the fetched data and SettingsHash the fetch and render settings the
+	// output was written with; a change to either re-renders the item.
+	Hash         string `json:"hash"`
+	SettingsHash string `json:"settings_hash"`
+	OutputPath   string `json:"output_path"`
+}
+
+// syncState is the sync mode state file: the start time of the last run and one entry per
+// exported URL.
+type syncState struct {
+	Items    map[string]syncEntry `json:"items"`
+	LastSync string               `json:"last_sync,omitempty"`
+}
+
+// changedItems maps "host/owner/repo" to the item numbers the search API reported as updated
+// since the last sync. Repositories missing from the map are refetched in full.
+type changedItems map[string]map[int]struct{}
+
+// runSync re-exports batch or query items updated since the last run recorded in the state file.
+func (a *App) runSync(ctx context.Context, cfg config.Config, mode Mode, fetcher gh.Fetcher, renderer converter.Renderer) (RunSummary, error) {
+	statePath := syncStatePath(cfg)
+	state, err := loadSyncState(statePath)
+	if err != nil {
+		return RunSummary{}, err
+	}
+	settings, err := a.syncSettingsHash(cfg)
+	if err != nil {
+		return RunSummary{}, err
+	}
+	startedAt := time.Now().UTC().Format(time.RFC3339)
+	// Sync owns the files it exported before and rewrites them in place.
+	cfg.Force = true
+
+	urls, changed, err := a.syncCandidates(ctx, cfg, mode, state, fetcher)
+	if err != nil {
+		return RunSummary{}, err
+	}
+
+	items := make([]ItemResult, 0, len(urls))
+	for _, rawURL := range urls {
+		items = append(items, a.syncOne(ctx, cfg, rawURL, settings, &state, changed, fetcher, renderer))
+	}
+
+	state.LastSync = startedAt
+	if err := saveSyncState(statePath, state); err != nil {
+		return BuildSummary(items), err
+	}
+	return BuildSummary(items), nil
+}
+
+// syncCandidates lists the URLs to consider and the items known to have changed. Batch mode reads
+// the whole input file and asks changedSince which of its items changed. Query mode asks GitHub
+// only for items updated since the last sync and adds the previously exported items it did not
+// return, which count as unchanged.
+func (a *App) syncCandidates(ctx context.Context, cfg config.Config, mode Mode, state syncState, fetcher gh.Fetcher) ([]string, changedItems, error) {
+	var urls []string
+	if mode != ModeQuery {
+		err := a.inputReader.Read(cfg.InputFile, func(line string) error {
+			urls = append(urls, line)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("read batch input file %q: %w", cfg.InputFile, err)
+		}
+		if state.LastSync == "" {
+			return urls, changedItems{}, nil
+		}
+		return urls, a.changedSince(ctx, fetcher, urls, state.LastSync), nil
+	}
+
+	query := cfg.Query
+	if state.LastSync != "" {
+		query += " updated:>=" + state.LastSync
+	}
+	refs, err := search(ctx, fetcher, query, searchHost(cfg.DefaultRepo), cfg.Limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, ref := range refs {
+		urls = append(urls, ref.URL)
+	}
+	if state.LastSync == "" || len(refs) >= cfg.Limit {
+		// A truncated listing does not vouch for the items it left out.
+		return urls, changedItems{}, nil
+	}
+	urls, changed := a.withUnlistedItems(urls, refs, state)
+	return urls, changed, nil
+}
+
+// withUnlistedItems appends the state entries a query sync did not list and marks only the listed
+// items of their repositories as changed.
+func (a *App) withUnlistedItems(urls []string, refs []gh.ResourceRef, state syncState) ([]string, changedItems) {
+	changed := changedItems{}
+	listed := make(map[string]struct{}, len(refs))
+	for _, ref := range refs {
+		key := repoKey(ref)
+		if changed[key] == nil {
+			changed[key] = map[int]struct{}{}
+		}
+		changed[key][ref.Number] = struct{}{}
+		listed[ref.URL] = struct{}{}
+	}
+
+	known := make([]string, 0, len(state.Items))
+	for rawURL := range state.Items {
+		known = append(known, rawURL)
+	}
+	sort.Strings(known)
+	for _, rawURL := range known {
+		if _, ok := listed[rawURL]; ok {
+			continue
+		}
+		ref, err := a.parser.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+		if key := repoKey(ref); changed[key] == nil {
+			changed[key] = map[int]struct{}{}
+		}
+		urls = append(urls, rawURL)
+	}
+	return urls, changed
+}
+
+// changedSince searches each input repository once for items updated since the last sync.
+// Repositories whose search fails are left out so their items are refetched.
+func (a *App) changedSince(ctx context.Context, fetcher gh.Fetcher, urls []string, since string) changedItems {
+	changed := changedItems{}
+	failed := map[string]struct{}{}
+	for _, rawURL := range urls {
+		ref, err := a.parser.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+		key := repoKey(ref)
+		if _, ok := changed[key]; ok {
+			continue
+		}
+		if _, ok := failed[key]; ok {
+			continue
+		}
+
+		query := fmt.Sprintf("repo:%s/%s updated:>=%s", ref.Owner, ref.Repo, since)
+		refs, err := search(ctx, fetcher, query, ref.Host, maxSyncSearchResults)
+		if err != nil || len(refs) >= maxSyncSearchResults {
+			failed[key] = struct{}{}
+			continue
+		}
+		numbers := make(map[int]struct{}, len(refs))
+		for _, updated := range refs {
+			numbers[updated.Number] = struct{}{}
+		}
+		changed[key] = numbers
+	}
+	return changed
+}
+
+// syncOne re-exports one item. The search only vouches for unchanged data, so items written with
+// other settings are fetched and rendered again.
+func (a *App) syncOne(ctx context.Context, cfg config.Config, rawURL, settings string, state *syncState, changed changedItems, fetcher gh.Fetcher, renderer converter.Renderer) ItemResult {
+	prev, known := state.Items[rawURL]
+	if known && prev.SettingsHash == settings && fileExists(prev.OutputPath) && a.unchangedSince(rawURL, changed) {
+		item := ItemResult{URL: rawURL, Status: StatusOK, OutputPath: prev.OutputPath, Sync: SyncUnchanged}
+		if ref, err := a.parser.Parse(rawURL); err == nil {
+			item.ResourceType = ref.Type
+		}
+		writeStatusLine(a.stdout, item)
+		return item
+	}
+
+	item, entry, err := a.syncConvert(ctx, cfg, rawURL, settings, prev, known, fetcher, renderer)
+	if err != nil {
+		// Forget failed items so the next run fetches them again.
+		delete(state.Items, rawURL)
+		item.Status = StatusFailed
+		item.Reason = err.Error()
+	} else {
+		state.Items[rawURL] = entry
+	}
+	writeStatusLine(a.stdout, item)
+	return item
+}
+
+// syncConvert fetches one item and renders and writes it only when the fetched content changed,
+// so an unchanged item costs no AI summary.
+func (a *App) syncConvert(ctx context.Context, cfg config.Config, rawURL, settings string, prev syncEntry, known bool, fetcher gh.Fetcher, renderer converter.Renderer) (ItemResult, syncEntry, error) {
+	conv, err := a.fetchOne(ctx, cfg, rawURL, fetcher)
+	if err != nil {
+		return conv.item, syncEntry{}, err
+	}
+	hash, err := contentHash(conv)
+	if err != nil {
+		return conv.item, syncEntry{}, err
+	}
+
+	item := conv.item
+	entry := syncEntry{UpdatedAt: conv.updatedAt, Hash: hash, SettingsHash: settings, OutputPath: prev.OutputPath}
+	if known && prev.Hash == entry.Hash && prev.SettingsHash == entry.SettingsHash && fileExists(prev.OutputPath) {
+		item.Status = StatusOK
+		item.OutputPath = prev.OutputPath
+		item.Sync = SyncUnchanged
+		return item, entry, nil
+	}
+	if err := conv.render(ctx, renderer); err != nil {
+		return item, syncEntry{}, err
+	}
+
+	outputPath, err := a.writer.Write(cfg, ModeBatch, conv.ref, conv.markdown)
+	if err != nil {
+		return item, syncEntry{}, fmt.Errorf("write output: %w", err)
+	}
+	entry.OutputPath = outputPath
+	item.Status = StatusOK
+	item.OutputPath = outputPath
+	if err := a.localizeAssets(ctx, cfg, ModeBatch, conv.ref, conv.markdown, &item); err != nil {
+		return item, syncEntry{}, err
+	}
+	item.Sync = SyncAdded
+	if known {
+		item.Sync = SyncUpdated
+	}
+	return item, entry, nil
+}
+
+// unchangedSince reports whether the search API vouched that rawURL did not change.
+func (a *App) unchangedSince(rawURL string, changed changedItems) bool {
+	ref, err := a.parser.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	numbers, ok := changed[repoKey(ref)]
+	if !ok {
+		return false
+	}
+	_, updated := numbers[ref.Number]
+	return !updated
+}
+
+func repoKey(ref gh.ResourceRef) string {
+	host := ref.Host
+	if gh.IsDefaultHost(host) {
+		host = gh.DefaultHost
+	}
+	return strings.ToLower(host + "/" + ref.Owner + "/" + ref.Repo)
+}
+
+func syncStatePath(cfg config.Config) string {
+	if cfg.StateFile != "" {
+		return cfg.StateFile
+	}
+	return filepath.Join(cfg.OutputPath, defaultSyncStateFile)
+}
+
+func loadSyncState(path string) (syncState, error) {
+	state := syncState{Items: map[string]syncEntry{}}
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return syncState{}, fmt.Errorf("read sync state %q: %w", path, err)
+	}
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return syncState{}, fmt.Errorf("decode sync state %q: %w", path, err)
+	}
+	if state.Items == nil {
+		state.Items = map[string]syncEntry{}
+	}
+	return state, nil
+}
+
+// saveSyncState writes through a temporary file so an interrupted run keeps the previous state.
+func saveSyncState(path string, state syncState) error {
+	raw, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode sync state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create sync state directory for %q: %w", path, err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(raw, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write sync state %q: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("replace sync state %q: %w", path, err)
+	}
+	return nil
+}
+
+// contentHash fingerprints the fetched data of an item. The rendered document is not hashed: its
+// AI summary differs between runs even when nothing else does.
+func contentHash(conv conversion) (string, error) {
+	raw, err := json.Marshal(conv.data)
+	if err != nil {
+		return "", fmt.Errorf("hash resource: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// syncSettingsHash fingerprints the fetch and render settings of a run. The template is hashed by
+// its text, which the parsed template does not expose to JSON.
+func (a *App) syncSettingsHash(cfg config.Config) (string, error) {
+	includeComments := cfg.IncludeComments || cfg.FocusComment
+	opts := a.renderOptions(cfg, includeComments, "")
+	template := opts.Template.Source()
+	opts.Template = nil
+	raw, err := json.Marshal(struct {
+		Fetch        gh.FetchOptions         `json:"fetch"`
+		Render       converter.RenderOptions `json:"render"`
+		Template     string                  `json:"template"`
+		FocusComment bool                    `json:"focus_comment"`
+	}{Fetch: fetchOptions(cfg, includeComments), Render: opts, Template: template, FocusComment: cfg.FocusComment})
+	if err != nil {
+		return "", fmt.Errorf("hash sync settings: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func fileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
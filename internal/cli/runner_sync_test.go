@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/johnqtcg/issue2md/internal/config"
+	"github.com/johnqtcg/issue2md/internal/converter"
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+type titleRenderer struct{}
+
+func (titleRenderer) Render(_ context.Context, data gh.IssueData, _ converter.RenderOptions) ([]byte, error) {
+	return []byte("# " + data.Meta.Title + "\n"), nil
+}
+
+func TestAppRunSyncBatch(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	u1 := "https://github.com/octo/repo/issues/1"
+	u2 := "https://github.com/octo/repo/issues/2"
+	refs := map[string]gh.ResourceRef{
+		u1: {Owner: "octo", Repo: "repo", Number: 1, Type: gh.ResourceIssue, URL: u1},
+		u2: {Owner: "octo", Repo: "repo", Number: 2, Type: gh.ResourceIssue, URL: u2},
+	}
+
+	run := func(data map[string]gh.IssueData, updated []gh.ResourceRef) (*fakeSearchFetcher, string) {
+		t.Helper()
+		fetcher := &fakeSearchFetcher{
+			fakeFetcher: &fakeFetcher{dataByURL: data, errByURL: map[string]error{}},
+			refs:        updated,
+		}
+		stdout := new(bytes.Buffer)
+		app := NewApp(AppDeps{
+			Loader: &fakeLoader{cfg: config.Config{
+				InputFile:  "urls.txt",
+				OutputPath: outDir,
+				Sync:       true,
+			}},
+			Parser:          &fakeParser{refByURL: refs, errByURL: map[string]error{}},
+			FetcherFactory:  &fakeFetcherFactory{fetcher: fetcher},
+			RendererFactory: &fakeRendererFactory{renderer: titleRenderer{}},
+			Writer:          NewOutputWriter(new(bytes.Buffer)),
+			InputReader:     &fakeInputReader{lines: []string{u1, u2}},
+			Stdout:          stdout,
+			Stderr:          new(bytes.Buffer),
+		})
+		if code := app.Run(context.Background(), nil); code != ExitOK {
+			t.Fatalf("Run exit code = %d, want %d; stdout=%q", code, ExitOK, stdout.String())
+		}
+		return fetcher, stdout.String()
+	}
+
+	first := map[string]gh.IssueData{
+		u1: minimalIssueData(gh.ResourceIssue, "one", u1),
+		u2: minimalIssueData(gh.ResourceIssue, "two", u2),
+	}
+	_, out := run(first, nil)
+	if !strings.Contains(out, "OK total=2 succeeded=2 failed=0 added=2 updated=0 unchanged=0") {
+		t.Fatalf("first sync output = %q, want two added", out)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, defaultSyncStateFile)); err != nil {
+		t.Fatalf("state file missing after first sync: %v", err)
+	}
+
+	second := map[string]gh.IssueData{
+		u1: minimalIssueData(gh.ResourceIssue, "one", u1),
+		u2: minimalIssueData(gh.ResourceIssue, "two, edited", u2),
+	}
+	fetcher, out := run(second, []gh.ResourceRef{refs[u2]})
+	if !strings.Contains(out, "OK total=2 succeeded=2 failed=0 added=0 updated=1 unchanged=1") {
+		t.Fatalf("second sync output = %q, want one updated and one unchanged", out)
+	}
+	if len(fetcher.gotRefs) != 1 || fetcher.gotRefs[0].URL != u2 {
+		t.Fatalf("second sync fetched %#v, want only %s", fetcher.gotRefs, u2)
+	}
+	if !strings.HasPrefix(fetcher.gotQuery, "repo:octo/repo updated:>=") {
+		t.Fatalf("changed-item search query = %q, want repo:octo/repo updated:>=...", fetcher.gotQuery)
+	}
+	got, err := os.ReadFile(filepath.Join(outDir, "octo-repo-issue-2.md"))
+	if err != nil || string(got) != "# two, edited\n" {
+		t.Fatalf("rewritten output = %q (err %v), want edited title", got, err)
+	}
+}
+
+// countingRenderer stamps every document with a call count, like an AI summary that differs
+// between runs.
+type countingRenderer struct {
+	calls int
+}
+
+func (r *countingRenderer) Render(_ context.Context, data gh.IssueData, _ converter.RenderOptions) ([]byte, error) {
+	r.calls++
+	return []byte(fmt.Sprintf("# %s\n\nsummary %d\n", data.Meta.Title, r.calls)), nil
+}
+
+func TestAppRunSyncRendersChangedDataOnly(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	u1 := "https://github.com/octo/repo/issues/1"
+	ref := gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 1, Type: gh.ResourceIssue, URL: u1}
+	renderer := &countingRenderer{}
+
+	run := func(title string) string {
+		t.Helper()
+		stdout := new(bytes.Buffer)
+		app := NewApp(AppDeps{
+			Loader: &fakeLoader{cfg: config.Config{InputFile: "urls.txt", OutputPath: outDir, Sync: true}},
+			Parser: &fakeParser{refByURL: map[string]gh.ResourceRef{u1: ref}, errByURL: map[string]error{}},
+			FetcherFactory: &fakeFetcherFactory{fetcher: &fakeSearchFetcher{
+				fakeFetcher: &fakeFetcher{dataByURL: map[string]gh.IssueData{u1: minimalIssueData(gh.ResourceIssue, title, u1)}, errByURL: map[string]error{}},
+				// The search reports the item as updated on every run, so it is always fetched.
+				refs: []gh.ResourceRef{ref},
+			}},
+			RendererFactory: &fakeRendererFactory{renderer: renderer},
+			Writer:          NewOutputWriter(new(bytes.Buffer)),
+			InputReader:     &fakeInputReader{lines: []string{u1}},
+			Stdout:          stdout,
+			Stderr:          new(bytes.Buffer),
+		})
+		if code := app.Run(context.Background(), nil); code != ExitOK {
+			t.Fatalf("Run exit code = %d, want %d; stdout=%q", code, ExitOK, stdout.String())
+		}
+		return stdout.String()
+	}
+
+	steps := []struct {
+		title     string
+		wantLine  string
+		wantCalls int
+	}{
+		{title: "one", wantLine: "added=1 updated=0 unchanged=0", wantCalls: 1},
+		{title: "one", wantLine: "added=0 updated=0 unchanged=1", wantCalls: 1},
+		{title: "one, edited", wantLine: "added=0 updated=1 unchanged=0", wantCalls: 2},
+	}
+	for idx, step := range steps {
+		if out := run(step.title); !strings.Contains(out, step.wantLine) {
+			t.Fatalf("sync #%d output = %q, want %q", idx+1, out, step.wantLine)
+		}
+		if renderer.calls != step.wantCalls {
+			t.Fatalf("sync #%d render calls = %d, want %d", idx+1, renderer.calls, step.wantCalls)
+		}
+	}
+}
+
+func TestAppRunSyncQueryCountsUnlisted(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	u1 := "https://github.com/octo/repo/issues/1"
+	u2 := "https://github.com/octo/repo/issues/2"
+	refs := map[string]gh.ResourceRef{
+		u1: {Owner: "octo", Repo: "repo", Number: 1, Type: gh.ResourceIssue, URL: u1},
+		u2: {Owner: "octo", Repo: "repo", Number: 2, Type: gh.ResourceIssue, URL: u2},
+	}
+
+	run := func(titles map[string]string, listed []gh.ResourceRef) (*fakeSearchFetcher, string) {
+		t.Helper()
+		data := map[string]gh.IssueData{}
+		for rawURL, title := range titles {
+			data[rawURL] = minimalIssueData(gh.ResourceIssue, title, rawURL)
+		}
+		fetcher := &fakeSearchFetcher{fakeFetcher: &fakeFetcher{dataByURL: data, errByURL: map[string]error{}}, refs: listed}
+		stdout := new(bytes.Buffer)
+		app := NewApp(AppDeps{
+			Loader:          &fakeLoader{cfg: config.Config{Query: "repo:octo/repo", Limit: 100, OutputPath: outDir, Sync: true}},
+			Parser:          &fakeParser{refByURL: refs, errByURL: map[string]error{}},
+			FetcherFactory:  &fakeFetcherFactory{fetcher: fetcher},
+			RendererFactory: &fakeRendererFactory{renderer: titleRenderer{}},
+			Writer:          NewOutputWriter(new(bytes.Buffer)),
+			InputReader:     &fakeInputReader{},
+			Stdout:          stdout,
+			Stderr:          new(bytes.Buffer),
+		})
+		if code := app.Run(context.Background(), nil); code != ExitOK {
+			t.Fatalf("Run exit code = %d, want %d; stdout=%q", code, ExitOK, stdout.String())
+		}
+		return fetcher, stdout.String()
+	}
+
+	_, out := run(map[string]string{u1: "one", u2: "two"}, []gh.ResourceRef{refs[u1], refs[u2]})
+	if !strings.Contains(out, "OK total=2 succeeded=2 failed=0 added=2 updated=0 unchanged=0") {
+		t.Fatalf("first sync output = %q, want two added", out)
+	}
+
+	fetcher, out := run(map[string]string{u2: "two, edited"}, []gh.ResourceRef{refs[u2]})
+	if !strings.Contains(out, "OK total=2 succeeded=2 failed=0 added=0 updated=1 unchanged=1") {
+		t.Fatalf("second sync output = %q, want one updated and one unchanged", out)
+	}
+	if !strings.HasPrefix(fetcher.gotQuery, "repo:octo/repo updated:>=") {
+		t.Fatalf("search query = %q, want repo:octo/repo updated:>=...", fetcher.gotQuery)
+	}
+	if len(fetcher.gotRefs) != 1 || fetcher.gotRefs[0].URL != u2 {
+		t.Fatalf("second sync fetched %#v, want only %s", fetcher.gotRefs, u2)
+	}
+}
+
+func TestAppRunSyncRerendersChangedSettings(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	templateFile := filepath.Join(t.TempDir(), "layout.tmpl")
+	u1 := "https://github.com/octo/repo/issues/1"
+	ref := gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 1, Type: gh.ResourceIssue, URL: u1}
+	renderer := &countingRenderer{}
+
+	run := func(cfg config.Config) string {
+		t.Helper()
+		cfg.InputFile = "urls.txt"
+		cfg.OutputPath = outDir
+		cfg.Sync = true
+		stdout := new(bytes.Buffer)
+		app := NewApp(AppDeps{
+			Loader: &fakeLoader{cfg: cfg},
+			Parser: &fakeParser{refByURL: map[string]gh.ResourceRef{u1: ref}, errByURL: map[string]error{}},
+			FetcherFactory: &fakeFetcherFactory{fetcher: &fakeSearchFetcher{
+				// The search never reports the item as updated.
+				fakeFetcher: &fakeFetcher{dataByURL: map[string]gh.IssueData{u1: minimalIssueData(gh.ResourceIssue, "one", u1)}, errByURL: map[string]error{}},
+			}},
+			RendererFactory: &fakeRendererFactory{renderer: renderer},
+			Writer:          NewOutputWriter(new(bytes.Buffer)),
+			InputReader:     &fakeInputReader{lines: []string{u1}},
+			Stdout:          stdout,
+			Stderr:          new(bytes.Buffer),
+		})
+		if code := app.Run(context.Background(), nil); code != ExitOK {
+			t.Fatalf("Run exit code = %d, want %d; stdout=%q", code, ExitOK, stdout.String())
+		}
+		return stdout.String()
+	}
+
+	steps := []struct {
+		name      string
+		cfg       config.Config
+		template  string
+		wantLine  string
+		wantCalls int
+	}{
+		{name: "first export", cfg: config.Config{CommentStyle: string(converter.CommentStyleList)}, wantLine: "added=1 updated=0 unchanged=0", wantCalls: 1},
+		{name: "same settings", cfg: config.Config{CommentStyle: string(converter.CommentStyleList)}, wantLine: "added=0 updated=0 unchanged=1", wantCalls: 1},
+		{name: "render flag changed", cfg: config.Config{CommentStyle: string(converter.CommentStyleBlock)}, wantLine: "added=0 updated=1 unchanged=0", wantCalls: 2},
+		{name: "template added", cfg: config.Config{CommentStyle: string(converter.CommentStyleBlock), TemplateFile: templateFile}, template: "# {{.Meta.Title}}\n",
+			wantLine: "added=0 updated=1 unchanged=0", wantCalls: 3},
+		{name: "template unchanged", cfg: config.Config{CommentStyle: string(converter.CommentStyleBlock), TemplateFile: templateFile}, template: "# {{.Meta.Title}}\n",
+			wantLine: "added=0 updated=0 unchanged=1", wantCalls: 3},
+		{name: "template edited", cfg: config.Config{CommentStyle: string(converter.CommentStyleBlock), TemplateFile: templateFile}, template: "## {{.Meta.Title}}\n",
+			wantLine: "added=0 updated=1 unchanged=0", wantCalls: 4},
+	}
+	for _, step := range steps {
+		if step.template != "" {
+			if err := os.WriteFile(templateFile, []byte(step.template), 0o600); err != nil {
+				t.Fatalf("write template: %v", err)
+			}
+		}
+		if out := run(step.cfg); !strings.Contains(out, step.wantLine) {
+			t.Fatalf("%s: sync output = %q, want %q", step.name, out, step.wantLine)
+		}
+		if renderer.calls != step.wantCalls {
+			t.Fatalf("%s: render calls = %d, want %d", step.name, renderer.calls, step.wantCalls)
+		}
+	}
+}
@@ -42,7 +42,7 @@ func (f *fakeParser) Parse(rawURL string) (gh.ResourceRef, error) {
 }
 
 type fakeFetcherFactory struct {
-	fetcher *fakeFetcher
+	fetcher gh.Fetcher
 	err     error
 }
 
@@ -75,7 +75,7 @@ func (f *fakeFetcher) Fetch(_ context.Context, ref gh.ResourceRef, opts gh.Fetch
 }
 
 type fakeRendererFactory struct {
-	renderer *fakeRenderer
+	renderer converter.Renderer
 }
 
 func (f *fakeRendererFactory) New(cfg config.Config) converter.Renderer {
@@ -100,17 +100,18 @@ func (f *fakeRenderer) Render(_ context.Context, data gh.IssueData, opts convert
 }
 
 type fakeOutputWriter struct {
-	path     string
-	errByURL map[string]error
-	gotRefs  []gh.ResourceRef
-	gotMode  []Mode
+	path        string
+	errByURL    map[string]error
+	gotRefs     []gh.ResourceRef
+	gotMode     []Mode
+	gotMarkdown []string
 }
 
 func (f *fakeOutputWriter) Write(cfg config.Config, mode Mode, ref gh.ResourceRef, markdown []byte) (string, error) {
 	_ = cfg
-	_ = markdown
 	f.gotRefs = append(f.gotRefs, ref)
 	f.gotMode = append(f.gotMode, mode)
+	f.gotMarkdown = append(f.gotMarkdown, string(markdown))
 	if err := f.errByURL[ref.URL]; err != nil {
 		return "", err
 	}
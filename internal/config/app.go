@@ -0,0 +1,30 @@
+package config
+
+// validateAppAuth checks the GitHub App flags. App auth needs the app ID, its private key and
+// either the installation ID or the owner to discover the installation from, and replaces
+// token auth entirely.
+func validateAppAuth(cfg Config, tokenFlag string) error {
+	if cfg.AppID == 0 {
+		if cfg.AppKeyFile != "" || cfg.AppInstallationID != 0 || cfg.AppOwner != "" {
+			return NewValidationError("app-id", "required with --app-key-file, --app-installation-id or --app-owner")
+		}
+		return nil
+	}
+
+	switch {
+	case cfg.AppID < 0:
+		return NewValidationError("app-id", "must be a positive integer")
+	case tokenFlag != "":
+		return NewConflictError("--token", "--app-id")
+	case cfg.AppKeyFile == "":
+		return NewValidationError("app-key-file", "required with --app-id")
+	case cfg.AppInstallationID < 0:
+		return NewValidationError("app-installation-id", "must be a positive integer")
+	case cfg.AppInstallationID != 0 && cfg.AppOwner != "":
+		return NewConflictError("--app-installation-id", "--app-owner")
+	case cfg.AppInstallationID == 0 && cfg.AppOwner == "":
+		return NewValidationError("app-installation-id", "required with --app-id unless --app-owner is set")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ghConfigDirEnv       = "GH_CONFIG_DIR"
+	webLocalCredsEnv     = "ISSUE2MD_WEB_LOCAL_CREDENTIALS"
+	ghHostsFile          = "hosts.yml"
+	gitCredentialTimeout = 10 * time.Second
+
+	tokenSourceFlag          = "flag"
+	tokenSourceApp           = "github-app"
+	tokenSourceGitCredential = "git-credential"
+	tokenSourceEnvPrefix     = "env:"
+	tokenSourceGHPrefix      = "gh-config:"
+)
+
+// credentialHelper asks an external credential store for the token of host; an empty token
+// with a nil error means the store has none.
+type credentialHelper func(host string) (string, error)
+
+// allowLocalCredentials reports whether the gh login and git credential helpers may supply
+// tokens. The CLI always allows them; the web server only when the operator opts in.
+func (l *flagLoader) allowLocalCredentials() (bool, error) {
+	if !l.server {
+		return l.localCredentials, nil
+	}
+	value := os.Getenv(webLocalCredsEnv)
+	if value == "" {
+		return false, nil
+	}
+	allowed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, NewValidationError(webLocalCredsEnv, "must be true or false")
+	}
+	return allowed, nil
+}
+
+// resolveCredentials settles the token of every host and records where it came from in
+// TokenSources. Hosts without a flag or environment token fall back, when localCredentials
+// allows, to the gh CLI's hosts.yml and then, when enabled, to git's credential helpers.
+func resolveCredentials(cfg *Config, tokenFlag string, gitCredential credentialHelper, localCredentials bool) error {
+	switch {
+	case cfg.AppID != 0:
+		cfg.TokenSources[publicHost] = tokenSourceApp
+	case tokenFlag != "":
+		cfg.Token = tokenFlag
+		setTokenSource(cfg, publicHost, tokenSourceFlag)
+	case os.Getenv("GITHUB_TOKEN") != "":
+		cfg.Token = os.Getenv("GITHUB_TOKEN")
+		setTokenSource(cfg, publicHost, tokenSourceEnvPrefix+"GITHUB_TOKEN")
+	}
+	if !cfg.GitCredential {
+		gitCredential = nil
+	}
+	if !localCredentials {
+		return nil
+	}
+
+	ghPath, ghTokens := loadGHHostTokens()
+	for _, host := range append([]string{publicHost}, cfg.AllowedHosts...) {
+		if _, ok := cfg.TokenSources[host]; ok {
+			continue
+		}
+		token, source := ghTokens[host], tokenSourceGHPrefix+ghPath
+		if token == "" && gitCredential != nil {
+			var err error
+			if token, err = gitCredential(host); err != nil {
+				return NewValidationError("git-credential", fmt.Sprintf("git credential fill for %s failed: %v", host, err))
+			}
+			source = tokenSourceGitCredential
+		}
+		if token == "" {
+			continue
+		}
+		if host == publicHost {
+			cfg.Token = token
+		} else {
+			cfg.HostTokens[host] = token
+		}
+		cfg.TokenSources[host] = source
+	}
+	return nil
+}
+
+// setTokenSource records source unless resolveHosts already did: an ISSUE2MD_HOST_TOKENS entry
+// for github.com takes precedence over --token and GITHUB_TOKEN.
+func setTokenSource(cfg *Config, host, source string) {
+	if _, ok := cfg.TokenSources[host]; !ok {
+		cfg.TokenSources[host] = source
+	}
+}
+
+// loadGHHostTokens reads the gh CLI's hosts.yml. A missing or unreadable file yields no tokens.
+func loadGHHostTokens() (string, map[string]string) {
+	dir := os.Getenv(ghConfigDirEnv)
+	if dir == "" {
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			dir = filepath.Join(xdg, "gh")
+		} else if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".config", "gh")
+		}
+	}
+	if dir == "" {
+		return "", nil
+	}
+	path := filepath.Join(dir, ghHostsFile)
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return path, nil
+	}
+	return path, parseGHHostTokens(data)
+}
+
+// parseGHHostTokens extracts each host's oauth_token from hosts.yml. It understands the subset
+// gh writes: top-level host keys whose scalar fields sit one indentation level deeper. Nested
+// maps such as users: are skipped, and hosts whose token lives in the system keyring have none.
+func parseGHHostTokens(data []byte) map[string]string {
+	tokens := map[string]string{}
+	host, fieldIndent := "", -1
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		if indent == 0 {
+			host, fieldIndent = strings.ToLower(unquoteYAML(key)), -1
+			continue
+		}
+		if fieldIndent < 0 {
+			fieldIndent = indent
+		}
+		if host != "" && indent == fieldIndent && key == "oauth_token" {
+			if token := unquoteYAML(value); token != "" {
+				tokens[host] = token
+			}
+		}
+	}
+	return tokens
+}
+
+func unquoteYAML(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// gitCredentialFill asks git's configured credential helpers for an https credential of host
+// without ever prompting, and returns its password.
+func gitCredentialFill(host string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitCredentialTimeout)
+	defer cancel()
+
+	// #nosec G204 -- fixed git subcommand; the host only travels on stdin.
+	cmd := exec.CommandContext(ctx, "git", "credential", "fill")
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=" + host + "\n\n")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never")
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			// git exits non-zero when no helper has a credential and prompting is disabled.
+			return "", nil
+		}
+		return "", fmt.Errorf("run git credential fill: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if password, ok := strings.CutPrefix(line, "password="); ok {
+			return strings.TrimSpace(password), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testGHHosts = `github.com:
+    users:
+        octocat:
+            oauth_token: gho_nested
+    oauth_token: gho_public
+    git_protocol: https
+    user: octocat
+"GHE.Example.com":
+    oauth_token: 'ghe_token'
+keyring.example.com:
+    user: octocat
+`
+
+func TestParseGHHostTokens(t *testing.T) {
+	t.Parallel()
+
+	got := parseGHHostTokens([]byte(testGHHosts))
+	want := map[string]string{"github.com": "gho_public", "ghe.example.com": "ghe_token"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseGHHostTokens() = %#v, want %#v", got, want)
+	}
+}
+
+func TestLoaderCredentialFallbacks(t *testing.T) {
+	ghDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(ghDir, ghHostsFile), []byte(testGHHosts), 0o600); err != nil {
+		t.Fatalf("write hosts.yml: %v", err)
+	}
+	ghSource := tokenSourceGHPrefix + filepath.Join(ghDir, ghHostsFile)
+
+	tcs := []struct {
+		helperErr   error
+		env         map[string]string
+		wantSources map[string]string
+		name        string
+		wantToken   string
+		wantHelper  []string
+		args        []string
+		wantErr     bool
+	}{
+		{
+			name:        "gh config supplies public and enterprise tokens",
+			args:        []string{"--allowed-hosts", "ghe.example.com"},
+			wantToken:   "gho_public",
+			wantSources: map[string]string{"github.com": ghSource, "ghe.example.com": ghSource},
+		},
+		{
+			name:        "environment wins over gh config",
+			env:         map[string]string{"GITHUB_TOKEN": "env-token"},
+			wantToken:   "env-token",
+			wantSources: map[string]string{"github.com": "env:GITHUB_TOKEN"},
+		},
+		{
+			name:        "flag wins over environment",
+			args:        []string{"--token", "flag-token"},
+			env:         map[string]string{"GITHUB_TOKEN": "env-token"},
+			wantToken:   "flag-token",
+			wantSources: map[string]string{"github.com": "flag"},
+		},
+		{
+			name:        "git credential only when enabled",
+			args:        []string{"--allowed-hosts", "keyring.example.com"},
+			wantToken:   "gho_public",
+			wantSources: map[string]string{"github.com": ghSource},
+		},
+		{
+			name:        "git credential fills hosts gh does not know",
+			args:        []string{"--git-credential", "--allowed-hosts", "keyring.example.com"},
+			wantToken:   "gho_public",
+			wantHelper:  []string{"keyring.example.com"},
+			wantSources: map[string]string{"github.com": ghSource, "keyring.example.com": "git-credential"},
+		},
+		{
+			name:       "git credential failure",
+			args:       []string{"--git-credential", "--allowed-hosts", "keyring.example.com"},
+			helperErr:  errors.New("git not found"),
+			wantHelper: []string{"keyring.example.com"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(ghConfigDirEnv, ghDir)
+			t.Setenv("GITHUB_TOKEN", "")
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+
+			var asked []string
+			loader := &flagLoader{localCredentials: true, gitCredential: func(host string) (string, error) {
+				asked = append(asked, host)
+				return "helper-token", tc.helperErr
+			}}
+			cfg, err := loader.Load(tc.args)
+			if !reflect.DeepEqual(asked, tc.wantHelper) {
+				t.Fatalf("credential helper asked for %v, want %v", asked, tc.wantHelper)
+			}
+			if tc.wantErr {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) || vErr.Field != "git-credential" {
+					t.Fatalf("Load error = %v, want git-credential validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.Token != tc.wantToken {
+				t.Fatalf("Token = %q, want %q", cfg.Token, tc.wantToken)
+			}
+			if !reflect.DeepEqual(cfg.TokenSources, tc.wantSources) {
+				t.Fatalf("TokenSources = %#v, want %#v", cfg.TokenSources, tc.wantSources)
+			}
+		})
+	}
+}
+
+func TestServerLoaderLocalCredentials(t *testing.T) {
+	ghDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(ghDir, ghHostsFile), []byte(testGHHosts), 0o600); err != nil {
+		t.Fatalf("write hosts.yml: %v", err)
+	}
+
+	tcs := []struct {
+		name      string
+		optIn     string
+		envToken  string
+		wantToken string
+		wantErr   bool
+	}{
+		{name: "gh login ignored by default", wantToken: ""},
+		{name: "environment token still used", envToken: "env-token", wantToken: "env-token"},
+		{name: "explicit opt-in", optIn: "true", wantToken: "gho_public"},
+		{name: "explicit opt-out", optIn: "false", wantToken: ""},
+		{name: "invalid opt-in", optIn: "sometimes", wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(ghConfigDirEnv, ghDir)
+			t.Setenv("GITHUB_TOKEN", tc.envToken)
+			t.Setenv(webLocalCredsEnv, tc.optIn)
+
+			cfg, err := NewServerLoader().Load(nil)
+			if tc.wantErr {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) || vErr.Field != webLocalCredsEnv {
+					t.Fatalf("Load error = %v, want %s validation error", err, webLocalCredsEnv)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.Token != tc.wantToken {
+				t.Fatalf("Token = %q, want %q", cfg.Token, tc.wantToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	allowedHostsEnv = "ISSUE2MD_ALLOWED_HOSTS"
+	hostTokensEnv   = "ISSUE2MD_HOST_TOKENS"
+	publicHost      = "github.com"
+)
+
+// resolveHosts fills AllowedHosts and HostTokens from the --allowed-hosts value and the environment.
+// Hosts named in ISSUE2MD_HOST_TOKENS are allowed implicitly; other enterprise hosts fall back to
+// GH_ENTERPRISE_TOKEN so a github.com token is never sent to an enterprise server.
+func resolveHosts(cfg *Config, allowedFlag string) error {
+	raw := allowedFlag
+	if strings.TrimSpace(raw) == "" {
+		raw = os.Getenv(allowedHostsEnv)
+	}
+	hosts, err := parseHostList(raw)
+	if err != nil {
+		return err
+	}
+
+	explicit, err := parseHostTokens(os.Getenv(hostTokensEnv))
+	if err != nil {
+		return err
+	}
+
+	enterpriseToken, enterpriseSource := "", ""
+	for _, name := range []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+		if enterpriseToken = os.Getenv(name); enterpriseToken != "" {
+			enterpriseSource = tokenSourceEnvPrefix + name
+			break
+		}
+	}
+
+	seen := make(map[string]struct{}, len(hosts))
+	for _, host := range hosts {
+		seen[host] = struct{}{}
+	}
+	implicit := make([]string, 0, len(explicit))
+	for host := range explicit {
+		if _, ok := seen[host]; !ok && host != publicHost {
+			implicit = append(implicit, host)
+		}
+	}
+	sort.Strings(implicit)
+	hosts = append(hosts, implicit...)
+
+	tokens := make(map[string]string, len(hosts)+1)
+	sources := make(map[string]string, len(hosts)+1)
+	if token, ok := explicit[publicHost]; ok {
+		tokens[publicHost] = token
+		sources[publicHost] = tokenSourceEnvPrefix + hostTokensEnv
+	}
+	for _, host := range hosts {
+		token, source := explicit[host], tokenSourceEnvPrefix+hostTokensEnv
+		if token == "" {
+			token, source = enterpriseToken, enterpriseSource
+		}
+		tokens[host] = token
+		if token != "" {
+			sources[host] = source
+		}
+	}
+
+	cfg.AllowedHosts = hosts
+	cfg.HostTokens = tokens
+	cfg.TokenSources = sources
+	return nil
+}
+
+func parseHostList(raw string) ([]string, error) {
+	var hosts []string
+	for _, part := range strings.Split(raw, ",") {
+		host := strings.ToLower(strings.TrimSpace(part))
+		if host == "" || host == publicHost || host == "www."+publicHost {
+			continue
+		}
+		if strings.ContainsAny(host, "/:@ ") {
+			return nil, NewValidationError("allowed-hosts", "entries must be bare host names such as ghe.example.com")
+		}
+		if !containsString(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+func parseHostTokens(raw string) (map[string]string, error) {
+	tokens := map[string]string{}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		host, token, ok := strings.Cut(part, "=")
+		host = strings.ToLower(strings.TrimSpace(host))
+		token = strings.TrimSpace(token)
+		if !ok || host == "" || token == "" || strings.ContainsAny(host, "/:@ ") {
+			// Never echo the entry itself: it carries a credential.
+			return nil, NewValidationError(hostTokensEnv, "entries must be host=token pairs separated by commas")
+		}
+		tokens[host] = token
+	}
+	return tokens, nil
+}
+
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoaderEnterpriseHosts(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "public-token")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise-token")
+	t.Setenv(hostTokensEnv, "ghe.corp.com=corp-token, GitHub.com=override-token")
+
+	cfg, err := NewLoader().Load([]string{"--allowed-hosts", "GHE.Example.com,github.com,ghe.example.com"})
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+
+	if want := []string{"ghe.example.com", "ghe.corp.com"}; !reflect.DeepEqual(cfg.AllowedHosts, want) {
+		t.Fatalf("AllowedHosts = %#v, want %#v", cfg.AllowedHosts, want)
+	}
+	wantTokens := map[string]string{
+		"github.com":      "override-token",
+		"ghe.example.com": "enterprise-token",
+		"ghe.corp.com":    "corp-token",
+	}
+	if !reflect.DeepEqual(cfg.HostTokens, wantTokens) {
+		t.Fatalf("HostTokens = %#v, want %#v", cfg.HostTokens, wantTokens)
+	}
+	if cfg.Token != "public-token" {
+		t.Fatalf("Token = %q, want public-token", cfg.Token)
+	}
+}
+
+func TestLoaderAllowedHostsFromEnv(t *testing.T) {
+	t.Setenv(allowedHostsEnv, "ghe.example.com")
+	t.Setenv(ghConfigDirEnv, t.TempDir())
+
+	cfg, err := NewLoader().Load(nil)
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if want := []string{"ghe.example.com"}; !reflect.DeepEqual(cfg.AllowedHosts, want) {
+		t.Fatalf("AllowedHosts = %#v, want %#v", cfg.AllowedHosts, want)
+	}
+	if token := cfg.HostTokens["ghe.example.com"]; token != "" {
+		t.Fatalf("enterprise token = %q, want empty without GH_ENTERPRISE_TOKEN", token)
+	}
+}
+
+func TestLoaderRejectsInvalidHosts(t *testing.T) {
+	tcs := []struct {
+		name      string
+		args      []string
+		tokensEnv string
+		wantField string
+	}{
+		{name: "scheme in allowed host", args: []string{"--allowed-hosts", "https://ghe.example.com"}, wantField: "allowed-hosts"},
+		{name: "token entry without host", tokensEnv: "=secret-value", wantField: hostTokensEnv},
+		{name: "token entry without separator", tokensEnv: "ghe.example.com", wantField: hostTokensEnv},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(hostTokensEnv, tc.tokensEnv)
+
+			_, err := NewLoader().Load(tc.args)
+			var vErr *ValidationError
+			if !errors.As(err, &vErr) || vErr.Field != tc.wantField {
+				t.Fatalf("Load error = %v, want %s validation error", err, tc.wantField)
+			}
+			if strings.Contains(err.Error(), "secret-value") {
+				t.Fatalf("Load error leaks token: %v", err)
+			}
+		})
+	}
+}
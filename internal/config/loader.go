@@ -2,26 +2,85 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"io"
 	"os"
+	"strings"
+	"time"
+
+	"github.com/johnqtcg/issue2md/internal/converter"
+	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
 // Config represents normalized runtime configuration for the CLI.
 type Config struct {
-	OutputPath      string
-	Format          string
-	InputFile       string
-	Token           string
-	SummaryLang     string
-	OpenAIAPIKey    string
-	OpenAIBaseURL   string
-	OpenAIModel     string
-	Positional      []string
-	IncludeComments bool
-	Stdout          bool
-	Force           bool
+	OutputPath    string
+	Format        string
+	InputFile     string
+	Token         string
+	SummaryLang   string
+	OpenAIAPIKey  string
+	OpenAIBaseURL string
+	OpenAIModel   string
+	DefaultRepo   string
+	Query         string
+	StateFile     string
+	CacheDir      string
+	AppKeyFile    string
+	// TemplateFile is the text/template markdown layout given by --template, or empty.
+	TemplateFile string
+	AppOwner     string
+	EditHistory  string
+	// MinimizedComments is how moderator-minimized comments are rendered: collapse, skip or show.
+	MinimizedComments string
+	// CommentStyle lays out comments as list items or quoted blocks: list or block.
+	CommentStyle string
+	Positional   []string
+	AllowedHosts []string
+	HostTokens   map[string]string
+	// TokenSources names where each host's token came from, such as "flag", "env:GITHUB_TOKEN",
+	// "gh-config:<path>" or "git-credential". It never holds the token itself.
+	TokenSources      map[string]string
+	MaxRateLimitWait  time.Duration
+	AppID             int64
+	AppInstallationID int64
+	MaxPatchBytes     int
+	MaxChunkTokens    int
+	TopReacted        int
+	ContextComments   int
+	Limit             int
+	IncludeComments   bool
+	IncludeReactions  bool
+	FocusComment      bool
+	IncludeFiles      bool
+	IncludePatches    bool
+	IncludeCommits    bool
+	IncludeChecks     bool
+	HideResolved      bool
+	Stdout            bool
+	Force             bool
+	DryRun            bool
+	Sync              bool
+	NoCache           bool
+	GitCredential     bool
+	DownloadAssets    bool
 }
 
+const (
+	cacheDirEnv            = "ISSUE2MD_CACHE_DIR"
+	defaultContextComments = 2
+	defaultSearchLimit     = 100
+	// defaultMaxRateLimitWait matches the length of GitHub's primary rate limit window.
+	defaultMaxRateLimitWait = time.Hour
+)
+
+const (
+	// EditHistoryCount annotates edited descriptions and comments with their edit count.
+	EditHistoryCount = "count"
+	// EditHistoryDiff also renders a collapsible diff of every revision.
+	EditHistoryDiff = "diff"
+)
+
 // Loader loads configuration from CLI args and environment variables.
 type Loader interface {
 	Load(args []string) (Config, error)
@@ -29,10 +88,23 @@ type Loader interface {
 
 // NewLoader constructs the default configuration loader.
 func NewLoader() Loader {
-	return &flagLoader{}
+	return &flagLoader{gitCredential: gitCredentialFill, localCredentials: true}
 }
 
-type flagLoader struct{}
+// NewServerLoader constructs the loader of the web server, which serves anonymous callers. It
+// takes tokens only from GITHUB_TOKEN and ISSUE2MD_HOST_TOKENS: the operator's gh login and git
+// credential helpers are consulted only when ISSUE2MD_WEB_LOCAL_CREDENTIALS opts in.
+func NewServerLoader() Loader {
+	return &flagLoader{gitCredential: gitCredentialFill, server: true}
+}
+
+type flagLoader struct {
+	gitCredential credentialHelper
+	// localCredentials allows the gh hosts.yml and git credential fallbacks.
+	localCredentials bool
+	// server reads the localCredentials opt-in from ISSUE2MD_WEB_LOCAL_CREDENTIALS.
+	server bool
+}
 
 func (l *flagLoader) Load(args []string) (Config, error) {
 	cfg := Config{}
@@ -40,31 +112,112 @@ func (l *flagLoader) Load(args []string) (Config, error) {
 	flags.SetOutput(io.Discard)
 
 	flags.StringVar(&cfg.OutputPath, "output", "", "output path")
-	flags.StringVar(&cfg.Format, "format", "markdown", "output format")
+	flags.StringVar(&cfg.Format, "format", string(converter.FormatMarkdown), "output format: markdown, json, html or jsonl")
 	flags.BoolVar(&cfg.IncludeComments, "include-comments", true, "include comments")
+	flags.BoolVar(&cfg.IncludeFiles, "include-files", true, "include pull request changed files")
+	flags.BoolVar(&cfg.IncludePatches, "include-patches", false, "embed pull request file patches as diff blocks")
+	flags.BoolVar(&cfg.IncludeCommits, "include-commits", true, "include pull request commits")
+	flags.BoolVar(&cfg.IncludeChecks, "include-checks", true, "include pull request check results")
+	flags.BoolVar(&cfg.HideResolved, "hide-resolved-threads", false, "omit resolved pull request review threads")
+	flags.IntVar(&cfg.MaxPatchBytes, "max-patch-bytes", converter.DefaultMaxPatchBytes, "maximum bytes embedded per file patch")
+	flags.StringVar(&cfg.TemplateFile, "template", "", "text/template file laying out the markdown document")
+	flags.IntVar(&cfg.MaxChunkTokens, "max-chunk-tokens", converter.DefaultMaxChunkTokens, "approximate maximum tokens per --format jsonl record")
+	flags.BoolVar(&cfg.IncludeReactions, "include-reactions", true, "show reaction counts on the description and comments")
+	flags.IntVar(&cfg.TopReacted, "top-reacted-comments", 0, "highlight the N most-reacted comments (0 disables)")
+	flags.BoolVar(&cfg.FocusComment, "focus-comment", false, "export only the comment named by the URL fragment, with surrounding context")
+	flags.StringVar(&cfg.EditHistory, "edit-history", "", "include edit history of the description and comments: count or diff")
+	flags.StringVar(&cfg.MinimizedComments, "minimized-comments", string(converter.MinimizedCollapse), "how to render comments moderators minimized: collapse, skip or show")
+	flags.StringVar(&cfg.CommentStyle, "comment-style", string(converter.CommentStyleList), "how to lay out comments: list or block")
+	flags.IntVar(&cfg.ContextComments, "context-comments", defaultContextComments, "comments of context shown before and after a focused comment")
 	flags.StringVar(&cfg.InputFile, "input-file", "", "batch input file")
+	flags.StringVar(&cfg.Query, "query", "", "export every issue, pull request and discussion matching a GitHub search query")
+	flags.IntVar(&cfg.Limit, "limit", defaultSearchLimit, "maximum resources exported by --query")
+	flags.BoolVar(&cfg.DryRun, "dry-run", false, "list --query matches without exporting them")
+	flags.BoolVar(&cfg.Sync, "sync", false, "only re-export batch or query items updated since the last sync")
+	flags.StringVar(&cfg.StateFile, "state-file", "", "sync state file (default <output>/.issue2md-sync.json)")
+	flags.StringVar(&cfg.CacheDir, "cache-dir", "", "directory for cached GitHub API responses; the cache is off unless set (env "+cacheDirEnv+")")
+	flags.BoolVar(&cfg.NoCache, "no-cache", false, "disable the on-disk GitHub API cache, overriding "+cacheDirEnv)
+	flags.BoolVar(&cfg.DownloadAssets, "download-assets", false, "download referenced images and attachments into assets/ beside each output file")
+	flags.BoolVar(&cfg.GitCredential, "git-credential", false, "ask git credential helpers for tokens no flag, environment variable or gh login supplies")
+	flags.DurationVar(&cfg.MaxRateLimitWait, "max-rate-limit-wait", defaultMaxRateLimitWait, "longest pause for an exhausted GitHub rate limit before items fail")
 	flags.BoolVar(&cfg.Stdout, "stdout", false, "write markdown to stdout")
 	flags.BoolVar(&cfg.Force, "force", false, "overwrite existing files")
 	flags.StringVar(&cfg.SummaryLang, "lang", "", "summary language")
+	flags.Int64Var(&cfg.AppID, "app-id", 0, "authenticate as this GitHub App instead of with a token")
+	flags.StringVar(&cfg.AppKeyFile, "app-key-file", "", "PEM private key file of the GitHub App")
+	flags.Int64Var(&cfg.AppInstallationID, "app-installation-id", 0, "GitHub App installation to authenticate as")
+	flags.StringVar(&cfg.AppOwner, "app-owner", "", "discover the GitHub App installation of this user or organization")
+	flags.StringVar(&cfg.DefaultRepo, "repo", "", "default [HOST/]OWNER/REPO for short references such as #123")
 
-	var tokenFlag string
+	var tokenFlag, allowedHostsFlag string
 	flags.StringVar(&tokenFlag, "token", "", "GitHub token")
+	flags.StringVar(&allowedHostsFlag, "allowed-hosts", "", "comma-separated GitHub Enterprise Server hosts to accept")
 
 	if err := flags.Parse(args); err != nil {
 		return Config{}, WrapError("parse flags", err)
 	}
 
-	if cfg.Format != "markdown" {
-		return Config{}, WrapError("validate flags", NewValidationError("format", "must be markdown"))
+	if err := validateFormat(cfg); err != nil {
+		return Config{}, WrapError("validate flags", err)
+	}
+	if cfg.MaxPatchBytes <= 0 {
+		return Config{}, WrapError("validate flags", NewValidationError("max-patch-bytes", "must be a positive integer"))
+	}
+	if cfg.MaxChunkTokens <= 0 {
+		return Config{}, WrapError("validate flags", NewValidationError("max-chunk-tokens", "must be a positive integer"))
+	}
+	if cfg.TopReacted < 0 {
+		return Config{}, WrapError("validate flags", NewValidationError("top-reacted-comments", "must not be negative"))
+	}
+	if cfg.ContextComments < 0 {
+		return Config{}, WrapError("validate flags", NewValidationError("context-comments", "must not be negative"))
+	}
+	if cfg.EditHistory != "" && cfg.EditHistory != EditHistoryCount && cfg.EditHistory != EditHistoryDiff {
+		return Config{}, WrapError("validate flags", NewValidationError("edit-history", "must be count or diff"))
+	}
+	if err := validateCommentLayout(cfg); err != nil {
+		return Config{}, WrapError("validate flags", err)
+	}
+	if cfg.Limit <= 0 {
+		return Config{}, WrapError("validate flags", NewValidationError("limit", "must be a positive integer"))
+	}
+	if cfg.Limit > gh.MaxSearchResults {
+		return Config{}, WrapError("validate flags", NewValidationError("limit",
+			fmt.Sprintf("must be at most %d, the GitHub search result cap; split the query by created: ranges", gh.MaxSearchResults)))
+	}
+	if cfg.MaxRateLimitWait <= 0 {
+		return Config{}, WrapError("validate flags", NewValidationError("max-rate-limit-wait", "must be a positive duration"))
 	}
 	if cfg.Stdout && cfg.InputFile != "" {
 		return Config{}, WrapError("validate flags", NewConflictError("--stdout", "--input-file"))
 	}
+	if cfg.Stdout && cfg.DownloadAssets {
+		return Config{}, WrapError("validate flags", NewConflictError("--stdout", "--download-assets"))
+	}
 	cfg.Positional = flags.Args()
+	if err := resolveCacheDir(&cfg); err != nil {
+		return Config{}, WrapError("validate flags", err)
+	}
 
-	cfg.Token = tokenFlag
-	if cfg.Token == "" {
-		cfg.Token = os.Getenv("GITHUB_TOKEN")
+	if err := validateAppAuth(cfg, tokenFlag); err != nil {
+		return Config{}, WrapError("validate flags", err)
+	}
+
+	if err := resolveHosts(&cfg, allowedHostsFlag); err != nil {
+		return Config{}, WrapError("validate flags", err)
+	}
+	localCredentials, err := l.allowLocalCredentials()
+	if err != nil {
+		return Config{}, WrapError("validate flags", err)
+	}
+	if err := resolveCredentials(&cfg, tokenFlag, l.gitCredential, localCredentials); err != nil {
+		return Config{}, WrapError("resolve credentials", err)
+	}
+	if cfg.DefaultRepo == "" {
+		cfg.DefaultRepo = os.Getenv("GH_REPO")
+	}
+	if err := validateDefaultRepo(cfg); err != nil {
+		return Config{}, WrapError("validate flags", err)
 	}
 
 	cfg.OpenAIAPIKey = os.Getenv("OPENAI_API_KEY")
@@ -73,3 +226,90 @@ func (l *flagLoader) Load(args []string) (Config, error) {
 
 	return cfg, nil
 }
+
+// validateCommentLayout checks --minimized-comments and --comment-style.
+func validateCommentLayout(cfg Config) error {
+	switch converter.MinimizedMode(cfg.MinimizedComments) {
+	case converter.MinimizedCollapse, converter.MinimizedSkip, converter.MinimizedShow:
+	default:
+		return NewValidationError("minimized-comments", "must be collapse, skip or show")
+	}
+	switch converter.CommentStyle(cfg.CommentStyle) {
+	case converter.CommentStyleList, converter.CommentStyleBlock:
+		return nil
+	default:
+		return NewValidationError("comment-style", "must be list or block")
+	}
+}
+
+// validateFormat checks --format and the flags only the markdown document supports.
+func validateFormat(cfg Config) error {
+	switch converter.Format(cfg.Format) {
+	case converter.FormatMarkdown:
+		if cfg.TemplateFile != "" && cfg.FocusComment {
+			return NewConflictError("--template", "--focus-comment")
+		}
+		return nil
+	case converter.FormatJSON, converter.FormatHTML, converter.FormatJSONL:
+	default:
+		return NewValidationError("format", "must be markdown, json, html or jsonl")
+	}
+	if cfg.TemplateFile != "" {
+		return NewConflictError("--format "+cfg.Format, "--template")
+	}
+	if cfg.DownloadAssets {
+		return NewConflictError("--format "+cfg.Format, "--download-assets")
+	}
+	if cfg.FocusComment && converter.Format(cfg.Format) != converter.FormatJSON {
+		return NewConflictError("--format "+cfg.Format, "--focus-comment")
+	}
+	return nil
+}
+
+// validateDefaultRepo checks the [HOST/]OWNER/REPO shape of --repo and holds its host to the
+// allowed hosts, since short references and searches are sent there.
+func validateDefaultRepo(cfg Config) error {
+	if cfg.DefaultRepo == "" {
+		return nil
+	}
+	parts := strings.Split(cfg.DefaultRepo, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return NewValidationError("repo", "must be OWNER/REPO or HOST/OWNER/REPO")
+	}
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return NewValidationError("repo", "must be OWNER/REPO or HOST/OWNER/REPO")
+		}
+	}
+	if len(parts) == 3 && !isAllowedHost(cfg, strings.ToLower(parts[0])) {
+		return NewValidationError("repo", fmt.Sprintf("host %q is not github.com or an allowed host", parts[0]))
+	}
+	return nil
+}
+
+func isAllowedHost(cfg Config, host string) bool {
+	if gh.IsDefaultHost(host) {
+		return true
+	}
+	for _, allowed := range cfg.AllowedHosts {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveCacheDir applies --no-cache and falls back to ISSUE2MD_CACHE_DIR. The cache is off
+// unless one of them names a directory.
+func resolveCacheDir(cfg *Config) error {
+	if cfg.NoCache {
+		if cfg.CacheDir != "" {
+			return NewConflictError("--cache-dir", "--no-cache")
+		}
+		return nil
+	}
+	if cfg.CacheDir == "" {
+		cfg.CacheDir = strings.TrimSpace(os.Getenv(cacheDirEnv))
+	}
+	return nil
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"errors"
 	"testing"
+	"time"
+
+	"github.com/johnqtcg/issue2md/internal/converter"
 )
 
 func TestLoaderTokenPriority(t *testing.T) {
@@ -35,7 +38,7 @@ func TestLoaderRejectsInvalidFormat(t *testing.T) {
 	t.Parallel()
 
 	loader := NewLoader()
-	_, err := loader.Load([]string{"--format", "json"})
+	_, err := loader.Load([]string{"--format", "pdf"})
 	if err == nil {
 		t.Fatal("Load error = nil, want error")
 	}
@@ -83,6 +86,232 @@ func TestLoaderRejectsStdoutWithInputFile(t *testing.T) {
 	}
 }
 
+func TestLoaderFormat(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name        string
+		args        []string
+		want        string
+		wantFlagErr string
+	}{
+		{name: "default markdown", args: nil, want: string(converter.FormatMarkdown)},
+		{name: "json", args: []string{"--format", "json"}, want: string(converter.FormatJSON)},
+		{name: "json with assets", args: []string{"--format", "json", "--download-assets"}, wantFlagErr: "--download-assets"},
+		{name: "html", args: []string{"--format=html"}, want: string(converter.FormatHTML)},
+		{name: "html with assets", args: []string{"--format", "html", "--download-assets"}, wantFlagErr: "--download-assets"},
+		{name: "html with focused comment", args: []string{"--format", "html", "--focus-comment"}, wantFlagErr: "--focus-comment"},
+		{name: "jsonl", args: []string{"--format", "jsonl"}, want: string(converter.FormatJSONL)},
+		{name: "jsonl with focused comment", args: []string{"--format", "jsonl", "--focus-comment"}, wantFlagErr: "--focus-comment"},
+		{name: "markdown template", args: []string{"--template", "layout.tmpl"}, want: string(converter.FormatMarkdown)},
+		{name: "html with template", args: []string{"--format", "html", "--template", "layout.tmpl"}, wantFlagErr: "--template"},
+		{name: "template with focused comment", args: []string{"--template", "layout.tmpl", "--focus-comment"}, wantFlagErr: "--focus-comment"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := NewLoader().Load(tc.args)
+			if tc.wantFlagErr != "" {
+				var cErr *ConflictError
+				if !errors.As(err, &cErr) || cErr.Right != tc.wantFlagErr {
+					t.Fatalf("Load error = %v, want conflict with %s", err, tc.wantFlagErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.Format != tc.want {
+				t.Fatalf("Format = %q, want %q", cfg.Format, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoaderMaxChunkTokens(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		args    []string
+		want    int
+		wantErr bool
+	}{
+		{name: "default", args: nil, want: 512},
+		{name: "explicit", args: []string{"--format", "jsonl", "--max-chunk-tokens", "200"}, want: 200},
+		{name: "zero", args: []string{"--max-chunk-tokens", "0"}, wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := NewLoader().Load(tc.args)
+			if tc.wantErr {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) || vErr.Field != "max-chunk-tokens" {
+					t.Fatalf("Load error = %v, want max-chunk-tokens validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.MaxChunkTokens != tc.want {
+				t.Fatalf("MaxChunkTokens = %d, want %d", cfg.MaxChunkTokens, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoaderEditHistory(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "default off", args: nil, want: ""},
+		{name: "count", args: []string{"--edit-history", "count"}, want: EditHistoryCount},
+		{name: "diff", args: []string{"--edit-history=diff"}, want: EditHistoryDiff},
+		{name: "unknown mode", args: []string{"--edit-history", "full"}, wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := NewLoader().Load(tc.args)
+			if tc.wantErr {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) || vErr.Field != "edit-history" {
+					t.Fatalf("Load error = %v, want edit-history validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.EditHistory != tc.want {
+				t.Fatalf("EditHistory = %q, want %q", cfg.EditHistory, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoaderMinimizedComments(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "default collapse", args: nil, want: string(converter.MinimizedCollapse)},
+		{name: "skip", args: []string{"--minimized-comments", "skip"}, want: string(converter.MinimizedSkip)},
+		{name: "show", args: []string{"--minimized-comments=show"}, want: string(converter.MinimizedShow)},
+		{name: "unknown mode", args: []string{"--minimized-comments", "hide"}, wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := NewLoader().Load(tc.args)
+			if tc.wantErr {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) || vErr.Field != "minimized-comments" {
+					t.Fatalf("Load error = %v, want minimized-comments validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.MinimizedComments != tc.want {
+				t.Fatalf("MinimizedComments = %q, want %q", cfg.MinimizedComments, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoaderCommentStyle(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "default list", args: nil, want: string(converter.CommentStyleList)},
+		{name: "block", args: []string{"--comment-style", "block"}, want: string(converter.CommentStyleBlock)},
+		{name: "unknown style", args: []string{"--comment-style=heading"}, wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := NewLoader().Load(tc.args)
+			if tc.wantErr {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) || vErr.Field != "comment-style" {
+					t.Fatalf("Load error = %v, want comment-style validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.CommentStyle != tc.want {
+				t.Fatalf("CommentStyle = %q, want %q", cfg.CommentStyle, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoaderDownloadAssets(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string
+		args     []string
+		want     bool
+		wantConf bool
+	}{
+		{name: "default off", args: nil},
+		{name: "enabled", args: []string{"--download-assets"}, want: true},
+		{name: "stdout", args: []string{"--download-assets", "--stdout"}, wantConf: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := NewLoader().Load(tc.args)
+			if tc.wantConf {
+				var cErr *ConflictError
+				if !errors.As(err, &cErr) {
+					t.Fatalf("Load error = %v, want conflict error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.DownloadAssets != tc.want {
+				t.Fatalf("DownloadAssets = %v, want %v", cfg.DownloadAssets, tc.want)
+			}
+		})
+	}
+}
+
 func TestLoaderStoresPositionalArgs(t *testing.T) {
 	t.Parallel()
 
@@ -98,3 +327,300 @@ func TestLoaderStoresPositionalArgs(t *testing.T) {
 		t.Fatalf("Positional[0] = %q, want issue URL", cfg.Positional[0])
 	}
 }
+
+func TestLoaderChangedFilesOptions(t *testing.T) {
+	t.Parallel()
+
+	loader := NewLoader()
+	cfg, err := loader.Load(nil)
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if !cfg.IncludeFiles || cfg.IncludePatches || cfg.MaxPatchBytes != 16*1024 {
+		t.Fatalf("defaults = files:%t patches:%t max:%d, want true/false/16384", cfg.IncludeFiles, cfg.IncludePatches, cfg.MaxPatchBytes)
+	}
+
+	cfg, err = loader.Load([]string{"--include-files=false", "--include-patches", "--max-patch-bytes", "512"})
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if cfg.IncludeFiles || !cfg.IncludePatches || cfg.MaxPatchBytes != 512 {
+		t.Fatalf("parsed = files:%t patches:%t max:%d, want false/true/512", cfg.IncludeFiles, cfg.IncludePatches, cfg.MaxPatchBytes)
+	}
+
+	_, err = loader.Load([]string{"--max-patch-bytes", "0"})
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Field != "max-patch-bytes" {
+		t.Fatalf("Load error = %v, want max-patch-bytes validation error", err)
+	}
+}
+
+func TestLoaderHideResolvedThreads(t *testing.T) {
+	t.Parallel()
+
+	loader := NewLoader()
+	cfg, err := loader.Load([]string{"--hide-resolved-threads"})
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if !cfg.HideResolved {
+		t.Fatal("HideResolved = false, want true")
+	}
+}
+
+func TestLoaderCommitsAndChecksOptions(t *testing.T) {
+	t.Parallel()
+
+	loader := NewLoader()
+	cfg, err := loader.Load(nil)
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if !cfg.IncludeCommits || !cfg.IncludeChecks {
+		t.Fatalf("defaults = commits:%t checks:%t, want both true", cfg.IncludeCommits, cfg.IncludeChecks)
+	}
+
+	cfg, err = loader.Load([]string{"--include-commits=false", "--include-checks=false"})
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if cfg.IncludeCommits || cfg.IncludeChecks {
+		t.Fatalf("parsed = commits:%t checks:%t, want both false", cfg.IncludeCommits, cfg.IncludeChecks)
+	}
+}
+
+func TestLoaderReactionOptions(t *testing.T) {
+	t.Parallel()
+
+	loader := NewLoader()
+	cfg, err := loader.Load(nil)
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if !cfg.IncludeReactions || cfg.TopReacted != 0 {
+		t.Fatalf("defaults = reactions:%t top:%d, want true/0", cfg.IncludeReactions, cfg.TopReacted)
+	}
+
+	cfg, err = loader.Load([]string{"--include-reactions=false", "--top-reacted-comments", "3"})
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if cfg.IncludeReactions || cfg.TopReacted != 3 {
+		t.Fatalf("parsed = reactions:%t top:%d, want false/3", cfg.IncludeReactions, cfg.TopReacted)
+	}
+
+	_, err = loader.Load([]string{"--top-reacted-comments", "-1"})
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Field != "top-reacted-comments" {
+		t.Fatalf("Load error = %v, want top-reacted-comments validation error", err)
+	}
+}
+
+func TestLoaderFocusOptions(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name      string
+		args      []string
+		wantField string
+		wantFocus bool
+		wantCtx   int
+	}{
+		{name: "defaults", args: nil, wantCtx: 2},
+		{name: "enabled", args: []string{"--focus-comment", "--context-comments", "0"}, wantFocus: true, wantCtx: 0},
+		{name: "negative context", args: []string{"--context-comments", "-1"}, wantField: "context-comments"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := NewLoader().Load(tc.args)
+			if tc.wantField != "" {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) || vErr.Field != tc.wantField {
+					t.Fatalf("Load error = %v, want %s validation error", err, tc.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.FocusComment != tc.wantFocus || cfg.ContextComments != tc.wantCtx {
+				t.Fatalf("focus = (%t, %d), want (%t, %d)", cfg.FocusComment, cfg.ContextComments, tc.wantFocus, tc.wantCtx)
+			}
+		})
+	}
+}
+
+func TestLoaderQueryOptions(t *testing.T) {
+	t.Parallel()
+
+	loader := NewLoader()
+	cfg, err := loader.Load([]string{"--query", "repo:org/x is:issue", "--dry-run"})
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if cfg.Query != "repo:org/x is:issue" || !cfg.DryRun || cfg.Limit != 100 {
+		t.Fatalf("query options = (%q, %t, %d), want query, dry-run and default limit 100", cfg.Query, cfg.DryRun, cfg.Limit)
+	}
+
+	for _, limit := range []string{"0", "1001"} {
+		_, err = loader.Load([]string{"--limit", limit})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.Field != "limit" {
+			t.Fatalf("Load(--limit %s) error = %v, want limit validation error", limit, err)
+		}
+	}
+}
+
+func TestLoaderMaxRateLimitWait(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name      string
+		wantField string
+		args      []string
+		want      time.Duration
+	}{
+		{name: "default", want: time.Hour},
+		{name: "custom", args: []string{"--max-rate-limit-wait", "15m"}, want: 15 * time.Minute},
+		{name: "zero", args: []string{"--max-rate-limit-wait", "0s"}, wantField: "max-rate-limit-wait"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := NewLoader().Load(tc.args)
+			if tc.wantField != "" {
+				var vErr *ValidationError
+				if !errors.As(err, &vErr) || vErr.Field != tc.wantField {
+					t.Fatalf("Load error = %v, want %s validation error", err, tc.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.MaxRateLimitWait != tc.want {
+				t.Fatalf("MaxRateLimitWait = %s, want %s", cfg.MaxRateLimitWait, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoaderCacheOptions(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg-cache")
+
+	tcs := []struct {
+		name     string
+		env      string
+		args     []string
+		wantDir  string
+		wantConf bool
+	}{
+		{name: "off by default", args: nil, wantDir: ""},
+		{name: "explicit dir", args: []string{"--cache-dir", "/var/cache/i2m"}, wantDir: "/var/cache/i2m"},
+		{name: "env dir", env: "/var/cache/env", wantDir: "/var/cache/env"},
+		{name: "flag overrides env", env: "/var/cache/env", args: []string{"--cache-dir", "/var/cache/i2m"}, wantDir: "/var/cache/i2m"},
+		{name: "disabled", args: []string{"--no-cache"}, wantDir: ""},
+		{name: "disabled overrides env", env: "/var/cache/env", args: []string{"--no-cache"}, wantDir: ""},
+		{name: "conflict", args: []string{"--no-cache", "--cache-dir", "/x"}, wantConf: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ISSUE2MD_CACHE_DIR", tc.env)
+			cfg, err := NewLoader().Load(tc.args)
+			if tc.wantConf {
+				var cErr *ConflictError
+				if !errors.As(err, &cErr) {
+					t.Fatalf("Load error = %v, want conflict error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load error = %v, want nil", err)
+			}
+			if cfg.CacheDir != tc.wantDir {
+				t.Fatalf("CacheDir = %q, want %q", cfg.CacheDir, tc.wantDir)
+			}
+		})
+	}
+}
+
+func TestLoaderAppAuth(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "env-token")
+
+	app := []string{"--app-id", "7", "--app-key-file", "app.pem"}
+	tcs := []struct {
+		name      string
+		wantField string
+		args      []string
+		wantConf  bool
+	}{
+		{name: "installation id", args: append([]string{"--app-installation-id", "42"}, app...)},
+		{name: "owner discovery", args: append([]string{"--app-owner", "acme"}, app...)},
+		{name: "key without app id", args: []string{"--app-key-file", "app.pem"}, wantField: "app-id"},
+		{name: "missing key", args: []string{"--app-id", "7", "--app-owner", "acme"}, wantField: "app-key-file"},
+		{name: "missing installation", args: app, wantField: "app-installation-id"},
+		{name: "installation and owner", args: append([]string{"--app-installation-id", "42", "--app-owner", "acme"}, app...), wantConf: true},
+		{name: "token flag", args: append([]string{"--token", "t", "--app-owner", "acme"}, app...), wantConf: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := NewLoader().Load(tc.args)
+			var vErr *ValidationError
+			var cErr *ConflictError
+			switch {
+			case tc.wantField != "":
+				if !errors.As(err, &vErr) || vErr.Field != tc.wantField {
+					t.Fatalf("Load error = %v, want %s validation error", err, tc.wantField)
+				}
+			case tc.wantConf:
+				if !errors.As(err, &cErr) {
+					t.Fatalf("Load error = %v, want conflict error", err)
+				}
+			case err != nil:
+				t.Fatalf("Load error = %v, want nil", err)
+			case cfg.AppID != 7 || cfg.AppKeyFile != "app.pem":
+				t.Fatalf("app = (%d, %q), want (7, app.pem)", cfg.AppID, cfg.AppKeyFile)
+			case cfg.Token != "":
+				t.Fatalf("Token = %q, want GITHUB_TOKEN ignored under App auth", cfg.Token)
+			}
+		})
+	}
+}
+
+func TestLoaderDefaultRepo(t *testing.T) {
+	t.Setenv("GH_REPO", "env-owner/env-repo")
+
+	loader := NewLoader()
+	cfg, err := loader.Load(nil)
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if cfg.DefaultRepo != "env-owner/env-repo" {
+		t.Fatalf("DefaultRepo = %q, want env-owner/env-repo", cfg.DefaultRepo)
+	}
+
+	cfg, err = loader.Load([]string{"--repo", "ghe.example.com/corp/app", "--allowed-hosts", "ghe.example.com"})
+	if err != nil {
+		t.Fatalf("Load error = %v, want nil", err)
+	}
+	if cfg.DefaultRepo != "ghe.example.com/corp/app" {
+		t.Fatalf("DefaultRepo = %q, want flag value", cfg.DefaultRepo)
+	}
+
+	for _, value := range []string{"just-owner", "evil.example/o/r"} {
+		_, err = loader.Load([]string{"--repo", value})
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) || vErr.Field != "repo" {
+			t.Fatalf("Load(--repo %s) error = %v, want repo validation error", value, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// CommentStyle selects how comments are laid out in the markdown document.
+type CommentStyle string
+
+const (
+	// CommentStyleList renders each comment as one "- author (time): body" list item with replies
+	// as nested items. It is the default for an empty style.
+	CommentStyleList CommentStyle = "list"
+	// CommentStyleBlock renders each comment as a blockquote headed by its author, time and
+	// permalink, with replies quoted inside their parent. Multi-line bodies keep their markdown.
+	CommentStyleBlock CommentStyle = "block"
+)
+
+// writeCommentBlock writes comment as a blockquote nested depth levels deep. A code fence the
+// body leaves open is closed inside the quote, so it cannot swallow the rest of the document.
+func writeCommentBlock(b *strings.Builder, comment gh.CommentNode, depth int, opts RenderOptions) {
+	var inner strings.Builder
+	inner.WriteString(commentBlockHeader(comment, opts))
+	inner.WriteString("\n")
+	body := closeOpenFence(strings.TrimRight(comment.Body, "\n"))
+	switch {
+	case collapsesBody(comment, opts):
+		fmt.Fprintf(&inner, "\n<details>\n<summary>Show minimized comment</summary>\n\n%s\n\n</details>\n", body)
+	case strings.TrimSpace(body) != "":
+		fmt.Fprintf(&inner, "\n%s\n", body)
+	}
+	if opts.EditDiffs {
+		inner.WriteString(renderEditDiffs(comment.Edits, ""))
+	}
+	writeQuoteBlock(b, inner.String(), depth)
+}
+
+// writeReviewBlock writes a pull request review as a blockquote headed by its author, state and
+// time; its comments are quoted inside it like replies.
+func writeReviewBlock(b *strings.Builder, review gh.ReviewData, opts RenderOptions) {
+	parts := []string{"**" + review.Author + "**", review.State, review.CreatedAt}
+	if reactions := formatReactions(review.Reactions); opts.IncludeReactions && reactions != "" {
+		parts = append(parts, reactions)
+	}
+	inner := strings.Join(parts, " · ") + "\n"
+	if body := closeOpenFence(strings.TrimRight(review.Body, "\n")); strings.TrimSpace(body) != "" {
+		inner += "\n" + body + "\n"
+	}
+	writeQuoteBlock(b, inner, 0)
+}
+
+// writeQuoteBlock writes text as a blockquote nested depth levels deep.
+func writeQuoteBlock(b *strings.Builder, text string, depth int) {
+	switch {
+	case depth > 0:
+		// A bare marker line keeps the reply inside its parent's quote.
+		b.WriteString(strings.TrimRight(strings.Repeat("> ", depth), " ") + "\n")
+	case b.Len() > 0 && !strings.HasSuffix(b.String(), "\n\n"):
+		b.WriteString("\n")
+	}
+
+	prefix := strings.Repeat("> ", depth+1)
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if line == "" {
+			b.WriteString(strings.TrimRight(prefix, " ") + "\n")
+			continue
+		}
+		b.WriteString(prefix + line + "\n")
+	}
+}
+
+// commentBlockHeader renders the first line of a comment block, such as
+// "**alice** [owner] · 2026-01-01T12:00:00Z · edited 2 times · [permalink](https://…) · 👍 3".
+func commentBlockHeader(comment gh.CommentNode, opts RenderOptions) string {
+	parts := []string{"**" + comment.Author + "**" + authorBadge(comment.AuthorAssociation), comment.CreatedAt}
+	if edited := editAnnotation(comment.Edits); edited != "" {
+		parts = append(parts, edited)
+	}
+	if minimized := minimizedAnnotation(comment); minimized != "" {
+		parts = append(parts, minimized)
+	}
+	if comment.URL != "" {
+		parts = append(parts, "[permalink]("+comment.URL+")")
+	}
+	if reactions := formatReactions(comment.Reactions); opts.IncludeReactions && reactions != "" {
+		parts = append(parts, reactions)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// closeOpenFence appends the closing fence to body when it ends inside a fenced code block.
+func closeOpenFence(body string) string {
+	var open fence
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		current, ok := parseFence(line)
+		switch {
+		case !ok:
+		case !inFence:
+			open, inFence = current, true
+		case current.char == open.char && current.length >= open.length && current.info == "":
+			inFence = false
+		}
+	}
+	if !inFence {
+		return body
+	}
+	return body + "\n" + strings.Repeat(string(open.char), open.length)
+}
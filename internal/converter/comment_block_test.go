@@ -0,0 +1,139 @@
+package converter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func TestRenderCommentBlocks(t *testing.T) {
+	t.Parallel()
+
+	multiline := sampleIssueData()
+	multiline.Thread[0].AuthorAssociation = "MEMBER"
+	multiline.Thread[0].Body = "## Repro\n\n- step one\n- step two\n\n```go\npanic(nil)\n"
+	multiline.Thread[1].IsMinimized = true
+	multiline.Thread[1].MinimizedReason = "outdated"
+
+	flatReviews := samplePRData()
+	flatReviews.Reviews[0].Body = "Looks good.\n\n```go\nif cfg == nil {"
+	for idx := range flatReviews.Reviews {
+		flatReviews.Reviews[idx].Threads = nil
+	}
+
+	tcs := []struct {
+		name    string
+		data    gh.IssueData
+		opts    RenderOptions
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "issue bodies keep their markdown",
+			data: multiline,
+			opts: RenderOptions{IncludeComments: true, IncludeReactions: true},
+			want: []string{
+				"## Discussion Thread\n\n> **bob** [member] · 2026-01-01T12:00:00Z · [permalink](https://github.com/octo/repo/issues/123#issuecomment-1) · 👍 2\n>\n",
+				"> ## Repro\n>\n> - step one\n> - step two\n>\n> ```go\n> panic(nil)\n> ```\n",
+				"> ```\n>\n> > **alice** · 2026-01-01T12:30:00Z\n> >\n> > Thanks, investigating.\n\n",
+				"> **carol** · 2026-01-01T13:00:00Z · minimized as outdated · 🎉 4 🚀 1\n>\n> <details>\n> <summary>Show minimized comment</summary>\n>\n> Fixed in #124?\n>\n> </details>\n",
+			},
+			notWant: []string{"- bob", "- alice"},
+		},
+		{
+			name: "minimized comments skipped",
+			data: multiline,
+			opts: RenderOptions{IncludeComments: true, Minimized: MinimizedSkip},
+			want: []string{"> Thanks, investigating.\n\n1 minimized comment(s) hidden (--minimized-comments=skip).\n"},
+		},
+		{
+			name: "pull request review threads",
+			data: samplePRData(),
+			opts: RenderOptions{IncludeComments: true},
+			want: []string{
+				"+	if cfg == nil {\n```\n\n> **bob** · 2026-01-03T12:10:00Z\n>\n> Please add test.\n>\n> > **alice** · 2026-01-03T12:20:00Z\n",
+				"- status: unresolved, outdated\n\n> **carol** · 2026-01-03T13:05:00Z\n",
+				"## Discussion Thread\n\n> **dave** · 2026-01-03T14:00:00Z\n>\n> Great improvement.\n",
+			},
+		},
+		{
+			name: "pull request reviews",
+			data: samplePRData(),
+			opts: RenderOptions{IncludeComments: true},
+			want: []string{
+				"## Reviews\n\n> **bob** · APPROVED · 2026-01-03T12:00:00Z\n>\n> Looks good.\n\n> **carol** · CHANGES_REQUESTED · 2026-01-03T13:00:00Z\n",
+			},
+			notWant: []string{"- APPROVED by bob"},
+		},
+		{
+			name: "review bodies and comments without threads",
+			data: flatReviews,
+			opts: RenderOptions{IncludeComments: true},
+			want: []string{
+				"> Looks good.\n>\n> ```go\n> if cfg == nil {\n> ```\n>\n> > **bob** · 2026-01-03T12:10:00Z\n> >\n> > Please add test.\n\n> **carol**",
+			},
+		},
+		{
+			name: "discussion answer and replies",
+			data: sampleDiscussionData(),
+			opts: RenderOptions{IncludeComments: true},
+			want: []string{
+				"### Accepted Answer\n\n> **mentor** · 2026-01-05T09:15:00Z\n",
+				"### Replies\n\n> **dora** · 2026-01-05T09:10:00Z\n",
+				"> > **dora** · 2026-01-05T09:20:00Z\n> >\n> > Thanks, this worked.\n",
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			opts := tc.opts
+			opts.CommentStyle = CommentStyleBlock
+			out, err := NewRenderer(nil).Render(context.Background(), tc.data, opts)
+			if err != nil {
+				t.Fatalf("Render error = %v, want nil", err)
+			}
+			for _, piece := range tc.want {
+				if !strings.Contains(string(out), piece) {
+					t.Fatalf("output missing %q\n%s", piece, out)
+				}
+			}
+			for _, piece := range tc.notWant {
+				if strings.Contains(string(out), piece) {
+					t.Fatalf("output must not contain %q\n%s", piece, out)
+				}
+			}
+		})
+	}
+}
+
+func TestCloseOpenFence(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "no fence", body: "plain", want: "plain"},
+		{name: "closed fence", body: "```\ncode\n```", want: "```\ncode\n```"},
+		{name: "open fence", body: "```go\ncode", want: "```go\ncode\n```"},
+		{name: "longer fence", body: "````\n```\nnested", want: "````\n```\nnested\n````"},
+		{name: "tilde fence", body: "~~~\ncode\n```", want: "~~~\ncode\n```\n~~~"},
+		{name: "reopened fence", body: "```\na\n```\n\n```\nb", want: "```\na\n```\n\n```\nb\n```"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := closeOpenFence(tc.body); got != tc.want {
+				t.Fatalf("closeOpenFence(%q) = %q, want %q", tc.body, got, tc.want)
+			}
+		})
+	}
+}
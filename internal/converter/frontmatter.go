@@ -7,7 +7,8 @@ import (
 	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
-func renderFrontMatter(meta gh.Metadata) string {
+func renderFrontMatter(data gh.IssueData) string {
+	meta := data.Meta
 	var b strings.Builder
 
 	b.WriteString("---\n")
@@ -19,16 +20,17 @@ func renderFrontMatter(meta gh.Metadata) string {
 	fmt.Fprintf(&b, "created_at: %s\n", yamlQuote(meta.CreatedAt))
 	fmt.Fprintf(&b, "updated_at: %s\n", yamlQuote(meta.UpdatedAt))
 	fmt.Fprintf(&b, "url: %s\n", yamlQuote(meta.URL))
+	if meta.RequestedURL != "" {
+		fmt.Fprintf(&b, "requested_url: %s\n", yamlQuote(meta.RequestedURL))
+	}
 
 	writeLabelList(&b, meta.Labels)
+	writeLifecycleFrontMatter(&b, meta)
+	fmt.Fprintf(&b, "reactions_total: %d\n", data.Reactions.Total)
 
 	switch meta.Type {
 	case gh.ResourcePullRequest:
-		fmt.Fprintf(&b, "merged: %t\n", meta.Merged)
-		if meta.MergedAt != "" {
-			fmt.Fprintf(&b, "merged_at: %s\n", yamlQuote(meta.MergedAt))
-		}
-		fmt.Fprintf(&b, "review_count: %d\n", meta.ReviewCount)
+		writePRFrontMatter(&b, data)
 	case gh.ResourceDiscussion:
 		if meta.Category != "" {
 			fmt.Fprintf(&b, "category: %s\n", yamlQuote(meta.Category))
@@ -43,6 +45,59 @@ func renderFrontMatter(meta gh.Metadata) string {
 	return b.String()
 }
 
+func writeLifecycleFrontMatter(b *strings.Builder, meta gh.Metadata) {
+	if meta.Type != gh.ResourceDiscussion {
+		writeStringList(b, "assignees", meta.Assignees)
+		if meta.Milestone != "" {
+			fmt.Fprintf(b, "milestone: %s\n", yamlQuote(meta.Milestone))
+		}
+	}
+	if meta.ClosedAt != "" {
+		fmt.Fprintf(b, "closed_at: %s\n", yamlQuote(meta.ClosedAt))
+	}
+	if meta.ClosedBy != "" {
+		fmt.Fprintf(b, "closed_by: %s\n", yamlQuote(meta.ClosedBy))
+	}
+	if meta.StateReason != "" {
+		fmt.Fprintf(b, "state_reason: %s\n", yamlQuote(meta.StateReason))
+	}
+	fmt.Fprintf(b, "locked: %t\n", meta.Locked)
+}
+
+func writePRFrontMatter(b *strings.Builder, data gh.IssueData) {
+	meta := data.Meta
+	fmt.Fprintf(b, "draft: %t\n", meta.Draft)
+	if meta.BaseRef != "" {
+		fmt.Fprintf(b, "base_ref: %s\n", yamlQuote(meta.BaseRef))
+	}
+	if meta.HeadRef != "" {
+		fmt.Fprintf(b, "head_ref: %s\n", yamlQuote(meta.HeadRef))
+	}
+	writeStringList(b, "requested_reviewers", meta.RequestedReviewers)
+	fmt.Fprintf(b, "additions: %d\n", meta.Additions)
+	fmt.Fprintf(b, "deletions: %d\n", meta.Deletions)
+	fmt.Fprintf(b, "merged: %t\n", meta.Merged)
+	if meta.MergedAt != "" {
+		fmt.Fprintf(b, "merged_at: %s\n", yamlQuote(meta.MergedAt))
+	}
+	fmt.Fprintf(b, "review_count: %d\n", meta.ReviewCount)
+	if passed, ok := checksPassed(data.Checks); ok {
+		fmt.Fprintf(b, "checks_passed: %t\n", passed)
+	}
+}
+
+func writeStringList(b *strings.Builder, key string, values []string) {
+	if len(values) == 0 {
+		fmt.Fprintf(b, "%s: []\n", key)
+		return
+	}
+
+	fmt.Fprintf(b, "%s:\n", key)
+	for _, value := range values {
+		fmt.Fprintf(b, "  - %s\n", yamlQuote(value))
+	}
+}
+
 func writeLabelList(b *strings.Builder, labels []gh.Label) {
 	if len(labels) == 0 {
 		b.WriteString("labels: []\n")
@@ -3,12 +3,14 @@ package converter
 import (
 	"strings"
 	"testing"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
 func TestRenderFrontMatterRequiredFields(t *testing.T) {
 	t.Parallel()
 
-	out := renderFrontMatter(sampleIssueData().Meta)
+	out := renderFrontMatter(sampleIssueData())
 	required := []string{
 		"type: 'issue'",
 		"title: 'Issue: Panic on nil config'",
@@ -32,27 +34,61 @@ func TestRenderFrontMatterOptionalFieldsByType(t *testing.T) {
 	t.Parallel()
 
 	tcs := []struct {
-		name     string
-		input    string
-		expected []string
+		name       string
+		input      string
+		expected   []string
+		unexpected []string
 	}{
 		{
 			name:  "pr optional fields",
-			input: renderFrontMatter(samplePRData().Meta),
+			input: renderFrontMatter(samplePRData()),
 			expected: []string{
 				"merged: true",
 				"merged_at: '2026-01-04T09:30:00Z'",
 				"review_count: 2",
+				"checks_passed: true",
+				"draft: false",
+				"base_ref: 'main'",
+				"head_ref: 'fix/nil-config'",
+				"requested_reviewers:\n  - 'carol'\n  - 'team:core'",
+				"additions: 12",
+				"deletions: 3",
+				"closed_by: 'bob'",
+				"assignees: []",
+			},
+		},
+		{
+			name:  "issue lifecycle fields",
+			input: renderFrontMatter(sampleIssueData()),
+			expected: []string{
+				"assignees:\n  - 'maintainer'",
+				"milestone: 'v1.2'",
+				"locked: false",
+				"reactions_total: 4",
+			},
+			unexpected: []string{"requested_url:"},
+		},
+		{
+			name: "redirected resource",
+			input: renderFrontMatter(func() gh.IssueData {
+				data := samplePRData()
+				data.Meta.RequestedURL = "https://github.com/octo/repo/issues/124"
+				return data
+			}()),
+			expected: []string{
+				"url: 'https://github.com/octo/repo/pull/124'\nrequested_url: 'https://github.com/octo/repo/issues/124'\n",
 			},
 		},
 		{
 			name:  "discussion optional fields",
-			input: renderFrontMatter(sampleDiscussionData().Meta),
+			input: renderFrontMatter(sampleDiscussionData()),
 			expected: []string{
 				"category: 'Q&A'",
 				"is_answered: true",
 				"accepted_answer_author: 'mentor'",
+				"locked: true",
 			},
+			unexpected: []string{"assignees:", "milestone:", "draft:"},
 		},
 	}
 
@@ -65,6 +101,11 @@ func TestRenderFrontMatterOptionalFieldsByType(t *testing.T) {
 					t.Fatalf("front matter missing %q\n%s", piece, tc.input)
 				}
 			}
+			for _, piece := range tc.unexpected {
+				if strings.Contains(tc.input, piece) {
+					t.Fatalf("front matter should not contain %q\n%s", piece, tc.input)
+				}
+			}
 		})
 	}
 }
@@ -72,11 +113,11 @@ func TestRenderFrontMatterOptionalFieldsByType(t *testing.T) {
 func TestRenderFrontMatterPreservesDatetimeString(t *testing.T) {
 	t.Parallel()
 
-	meta := sampleIssueData().Meta
-	meta.CreatedAt = "2026-01-01T10:00:00+08:00"
-	meta.UpdatedAt = "2026-01-02T11:00:00-07:00"
+	data := sampleIssueData()
+	data.Meta.CreatedAt = "2026-01-01T10:00:00+08:00"
+	data.Meta.UpdatedAt = "2026-01-02T11:00:00-07:00"
 
-	out := renderFrontMatter(meta)
+	out := renderFrontMatter(data)
 	if !strings.Contains(out, "created_at: '2026-01-01T10:00:00+08:00'") {
 		t.Fatalf("created_at is not preserved:\n%s", out)
 	}
@@ -0,0 +1,378 @@
+package converter
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// htmlStyles is embedded in every HTML document so it renders without network access.
+const htmlStyles = `body{margin:0;background:#f6f8fa;color:#1f2328;font:14px/1.5 -apple-system,BlinkMacSystemFont,"Segoe UI",Helvetica,Arial,sans-serif}
+main{max-width:980px;margin:0 auto;padding:24px}
+h1{font-size:26px;margin:0 0 4px}
+h2{font-size:20px;border-bottom:1px solid #d0d7de;padding-bottom:4px;margin-top:32px}
+a{color:#0969da}
+code,pre{font:12px/1.45 ui-monospace,SFMono-Regular,Menlo,Consolas,monospace}
+pre{background:#f6f8fa;border:1px solid #d0d7de;border-radius:6px;padding:12px;overflow:auto}
+:not(pre)>code{background:rgba(175,184,193,.2);border-radius:4px;padding:.1em .3em}
+table{border-collapse:collapse;margin:8px 0}
+th,td{border:1px solid #d0d7de;padding:4px 10px;text-align:left;vertical-align:top}
+blockquote{margin:0;padding:0 12px;color:#59636e;border-left:4px solid #d0d7de}
+img{max-width:100%}
+.subtitle{color:#59636e;margin:0 0 16px}
+.state{display:inline-block;border-radius:12px;padding:0 10px;color:#fff;background:#59636e;font-weight:600}
+.state-open{background:#1f883d}.state-closed{background:#8250df}.state-merged{background:#8250df}
+.metadata th{background:#f6f8fa;white-space:nowrap}
+.box,.comment,.review{background:#fff;border:1px solid #d0d7de;border-radius:6px;margin:12px 0}
+.box{padding:4px 16px}
+.comment>header,.review>header{background:#f6f8fa;border-bottom:1px solid #d0d7de;border-radius:6px 6px 0 0;padding:6px 12px;color:#59636e}
+.comment>.markdown-body,.review>.markdown-body,.comment>details,.comment>.note{padding:0 12px}
+.author{color:#1f2328;font-weight:600}
+.badge{border:1px solid #d0d7de;border-radius:12px;padding:0 6px;font-size:12px}
+.replies{margin:0 12px 12px 24px;border-left:2px solid #d0d7de;padding-left:12px}
+.review-thread,.patch{background:#fff;border:1px solid #d0d7de;border-radius:6px;margin:12px 0;padding:0 12px}
+.review-thread>summary,.patch>summary{cursor:pointer;padding:8px 0;font-weight:600}
+.review-thread .diff,.patch .diff{margin:0 0 8px}
+.diff .add{background:#dafbe1}.diff .del{background:#ffebe9}.diff .hunk{color:#0550ae}
+.note,.reactions{color:#59636e}
+.task-list-item{list-style:none}
+footer{color:#59636e;margin-top:32px}
+`
+
+// htmlDocument accumulates one HTML page. ids remembers the element ids already written so a
+// comment reachable from two places never repeats its anchor.
+type htmlDocument struct {
+	ids  map[string]bool
+	b    strings.Builder
+	opts RenderOptions
+}
+
+// renderHTMLDocument renders the self-contained --format html page. Comment and description
+// bodies go through markdownToHTML; every other value is escaped.
+func renderHTMLDocument(data gh.IssueData, summary Summary, summaryStatus string, opts RenderOptions) []byte {
+	d := &htmlDocument{ids: map[string]bool{}, opts: opts}
+	title := html.EscapeString(data.Meta.Title)
+	d.b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	d.b.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	d.b.WriteString("<meta name=\"generator\" content=\"issue2md\">\n")
+	fmt.Fprintf(&d.b, "<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n<main>\n", title, htmlStyles)
+
+	d.writeHeader(data.Meta)
+	d.writeMetadata(data.Meta, summaryStatus)
+	if summary.Summary != "" {
+		d.section("summary", markdownToHTML(renderSummarySection(summary)))
+	}
+	d.writeDescription(data)
+	if opts.IncludeComments && opts.TopReactedComments > 0 {
+		d.section("most-reacted", markdownToHTML(renderTopReactedSection(data, opts)))
+	}
+
+	switch data.Meta.Type {
+	case gh.ResourceIssue:
+		d.section("timeline", markdownToHTML(renderTimelineSection(data)))
+		d.writeThread(data)
+	case gh.ResourcePullRequest:
+		d.section("timeline", markdownToHTML(renderTimelineSection(data)))
+		d.writeReviews(data)
+		d.writeThread(data)
+		d.section("commits", markdownToHTML(renderPRCommitsSection(data, opts.IncludeCommits)))
+		d.section("checks", markdownToHTML(renderPRChecksSection(data, opts.IncludeChecks)))
+		d.writeFiles(data)
+	case gh.ResourceDiscussion:
+		d.writeThread(data)
+	}
+
+	url := html.EscapeString(data.Meta.URL)
+	fmt.Fprintf(&d.b, "<footer>\n<h2>References</h2>\n<p>Original URL: <a href=\"%s\">%s</a></p>\n</footer>\n", url, url)
+	d.b.WriteString("</main>\n</body>\n</html>\n")
+	return []byte(d.b.String())
+}
+
+func (d *htmlDocument) section(id, body string) {
+	fmt.Fprintf(&d.b, "<section id=\"%s\">\n%s</section>\n", id, body)
+}
+
+func (d *htmlDocument) writeHeader(meta gh.Metadata) {
+	state := strings.ToLower(meta.State)
+	if meta.Merged {
+		state = "merged"
+	}
+	fmt.Fprintf(&d.b, "<header>\n<h1>%s</h1>\n", html.EscapeString(meta.Title))
+	fmt.Fprintf(&d.b, "<p class=\"subtitle\"><span class=\"state state-%s\">%s</span> %s #%d opened by <span class=\"author\">%s</span></p>\n</header>\n",
+		htmlClassName(state), html.EscapeString(state), html.EscapeString(string(meta.Type)), meta.Number, html.EscapeString(meta.Author))
+}
+
+// writeMetadata turns the markdown metadata list into a two-column table, so both formats
+// always show the same fields.
+func (d *htmlDocument) writeMetadata(meta gh.Metadata, summaryStatus string) {
+	d.b.WriteString("<section id=\"metadata\">\n<h2>Metadata</h2>\n<table class=\"metadata\">\n")
+	for _, line := range strings.Split(renderMetadataSection(meta, summaryStatus), "\n") {
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "- "), ": ")
+		if !ok || !strings.HasPrefix(line, "- ") {
+			continue
+		}
+		cell := html.EscapeString(value)
+		if key == "url" && safeURL(value) {
+			cell = fmt.Sprintf("<a href=\"%s\">%s</a>", cell, cell)
+		}
+		fmt.Fprintf(&d.b, "<tr><th>%s</th><td>%s</td></tr>\n", html.EscapeString(key), cell)
+	}
+	d.b.WriteString("</table>\n</section>\n")
+}
+
+func (d *htmlDocument) writeDescription(data gh.IssueData) {
+	d.b.WriteString("<section id=\"description\">\n<h2>Original Description</h2>\n<div class=\"box\">\n")
+	if strings.TrimSpace(data.Description) == "" {
+		d.b.WriteString("<p class=\"note\">(empty)</p>\n")
+	} else {
+		d.b.WriteString("<div class=\"markdown-body\">\n" + markdownToHTML(data.Description) + "</div>\n")
+	}
+	d.b.WriteString(markdownToHTML(renderDescriptionEdits(data.DescriptionEdits, d.opts)))
+	if reactions := formatReactions(data.Reactions); d.opts.IncludeReactions && reactions != "" {
+		fmt.Fprintf(&d.b, "<p class=\"reactions\">Reactions: %s</p>\n", html.EscapeString(reactions))
+	}
+	d.b.WriteString("</div>\n</section>\n")
+}
+
+func (d *htmlDocument) note(format string, args ...any) {
+	fmt.Fprintf(&d.b, "<p class=\"note\">%s</p>\n", html.EscapeString(fmt.Sprintf(format, args...)))
+}
+
+func (d *htmlDocument) writeThread(data gh.IssueData) {
+	d.b.WriteString("<section id=\"thread\">\n<h2>Discussion Thread</h2>\n")
+	defer d.b.WriteString("</section>\n")
+	switch {
+	case !d.opts.IncludeComments:
+		d.note("Comments omitted (--include-comments=false).")
+		return
+	case len(data.Thread) == 0:
+		d.note("none")
+		return
+	}
+
+	if data.Meta.Type == gh.ResourceDiscussion {
+		d.writeAcceptedAnswer(data)
+		d.b.WriteString("<h3>Replies</h3>\n")
+	}
+	d.writeSkippedNote(d.writeComments(data.Thread, true))
+}
+
+// writeAcceptedAnswer repeats the accepted answer above the replies, without its replies and
+// without an anchor: the id stays on the copy in the thread.
+func (d *htmlDocument) writeAcceptedAnswer(data gh.IssueData) {
+	if !data.Meta.IsAnswered {
+		return
+	}
+	accepted, ok := resolveAcceptedAnswer(data.Thread, data.Meta.AcceptedAnswerID, data.Meta.AcceptedAnswerAuthor)
+	if !ok {
+		return
+	}
+	accepted.Replies = nil
+	d.b.WriteString("<h3>Accepted Answer</h3>\n")
+	d.writeComments([]gh.CommentNode{accepted}, false)
+}
+
+func (d *htmlDocument) writeSkippedNote(skipped int) {
+	if skipped > 0 {
+		d.note("%d minimized comment(s) hidden (--minimized-comments=skip).", skipped)
+	}
+}
+
+// writeComments writes comments and their nested replies and returns how many minimized
+// comments were skipped. Anchored comments get their URL fragment as element id.
+func (d *htmlDocument) writeComments(comments []gh.CommentNode, anchored bool) int {
+	skipped := 0
+	for _, comment := range comments {
+		if comment.IsMinimized && d.opts.Minimized == MinimizedSkip {
+			skipped++
+			continue
+		}
+		d.b.WriteString("<article class=\"comment\"")
+		if id := commentElementID(comment); anchored && id != "" && !d.ids[id] {
+			d.ids[id] = true
+			fmt.Fprintf(&d.b, " id=\"%s\"", id)
+		}
+		d.b.WriteString(">\n")
+		d.writeCommentHeader(comment)
+		body := "<div class=\"markdown-body\">\n" + markdownToHTML(comment.Body) + "</div>\n"
+		if collapsesBody(comment, d.opts) {
+			body = "<details class=\"minimized\">\n<summary>Show minimized comment</summary>\n" + body + "</details>\n"
+		}
+		d.b.WriteString(body)
+		if d.opts.EditDiffs {
+			d.b.WriteString(markdownToHTML(renderEditDiffs(comment.Edits, "")))
+		}
+		if len(comment.Replies) > 0 {
+			d.b.WriteString("<div class=\"replies\">\n")
+			skipped += d.writeComments(comment.Replies, anchored)
+			d.b.WriteString("</div>\n")
+		}
+		d.b.WriteString("</article>\n")
+	}
+	return skipped
+}
+
+func (d *htmlDocument) writeCommentHeader(comment gh.CommentNode) {
+	d.b.WriteString("<header><span class=\"author\">" + html.EscapeString(comment.Author) + "</span>")
+	if badge := strings.Trim(authorBadge(comment.AuthorAssociation), " []"); badge != "" {
+		d.b.WriteString(" <span class=\"badge\">" + badge + "</span>")
+	}
+	when := html.EscapeString(comment.CreatedAt)
+	if comment.URL != "" && safeURL(comment.URL) {
+		when = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(comment.URL), when)
+	}
+	d.b.WriteString(" commented " + when)
+	for _, annotation := range []string{editAnnotation(comment.Edits), minimizedAnnotation(comment)} {
+		if annotation != "" {
+			d.b.WriteString(" · " + html.EscapeString(annotation))
+		}
+	}
+	if reactions := formatReactions(comment.Reactions); d.opts.IncludeReactions && reactions != "" {
+		d.b.WriteString(" <span class=\"reactions\">" + html.EscapeString(reactions) + "</span>")
+	}
+	d.b.WriteString("</header>\n")
+}
+
+// commentElementID returns the comment's URL fragment, such as "issuecomment-123", or an id
+// built from its REST ID. Anything outside [A-Za-z0-9_-] is dropped.
+func commentElementID(comment gh.CommentNode) string {
+	id := ""
+	if _, fragment, ok := strings.Cut(comment.URL, "#"); ok {
+		id = fragment
+	} else if comment.ID != "" {
+		id = "comment-" + comment.ID
+	}
+	return htmlClassName(id)
+}
+
+func htmlClassName(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x80 && (isAlphanumeric(byte(r)) || r == '-' || r == '_') {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+func (d *htmlDocument) writeReviews(data gh.IssueData) {
+	d.b.WriteString("<section id=\"reviews\">\n<h2>Reviews</h2>\n")
+	defer d.b.WriteString("</section>\n")
+	switch {
+	case !d.opts.IncludeComments:
+		d.note("Reviews omitted (--include-comments=false).")
+		return
+	case len(data.Reviews) == 0:
+		d.note("none")
+		return
+	}
+
+	skipped := 0
+	for _, review := range data.Reviews {
+		fmt.Fprintf(&d.b, "<article class=\"review\">\n<header><span class=\"author\">%s</span> %s at %s</header>\n",
+			html.EscapeString(review.Author), html.EscapeString(strings.ToLower(review.State)), html.EscapeString(review.CreatedAt))
+		if strings.TrimSpace(review.Body) != "" {
+			d.b.WriteString("<div class=\"markdown-body\">\n" + markdownToHTML(review.Body) + "</div>\n")
+		}
+		if len(review.Threads) == 0 {
+			skipped += d.writeComments(review.Comments, true)
+		}
+		d.b.WriteString("</article>\n")
+	}
+	d.writeSkippedNote(skipped)
+	d.writeReviewThreads(data.Reviews)
+}
+
+// writeReviewThreads renders each thread as a collapsible block that starts expanded while the
+// thread is unresolved.
+func (d *htmlDocument) writeReviewThreads(reviews []gh.ReviewData) {
+	hidden, skipped, total := 0, 0, 0
+	for _, review := range reviews {
+		for _, thread := range review.Threads {
+			if total == 0 {
+				d.b.WriteString("<h3>Review Threads</h3>\n")
+			}
+			total++
+			if d.opts.HideResolvedThreads && thread.IsResolved {
+				hidden++
+				continue
+			}
+			open := ""
+			if !thread.IsResolved {
+				open = " open"
+			}
+			fmt.Fprintf(&d.b, "<details class=\"review-thread\"%s>\n<summary><code>%s</code> (%s) · %s</summary>\n", open,
+				html.EscapeString(thread.Path), html.EscapeString(describeThreadAnchor(thread)), html.EscapeString(describeThreadStatus(thread)))
+			fmt.Fprintf(&d.b, "<p class=\"note\">review: %s by %s</p>\n", html.EscapeString(review.State), html.EscapeString(review.Author))
+			if thread.DiffHunk != "" {
+				d.b.WriteString(diffBlockHTML(thread.DiffHunk))
+			}
+			skipped += d.writeComments(thread.Comments, true)
+			d.b.WriteString("</details>\n")
+		}
+	}
+	if hidden > 0 {
+		d.note("%d resolved thread(s) hidden (--hide-resolved-threads).", hidden)
+	}
+	d.writeSkippedNote(skipped)
+}
+
+// diffBlockHTML renders a unified diff with added, removed and hunk header lines marked.
+func diffBlockHTML(diff string) string {
+	var b strings.Builder
+	b.WriteString("<pre class=\"diff\"><code>")
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		class := ""
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			class = "hunk"
+		case strings.HasPrefix(line, "+"):
+			class = "add"
+		case strings.HasPrefix(line, "-"):
+			class = "del"
+		}
+		if class != "" {
+			fmt.Fprintf(&b, "<span class=\"%s\">%s</span>\n", class, html.EscapeString(line))
+		} else {
+			b.WriteString(html.EscapeString(line) + "\n")
+		}
+	}
+	b.WriteString("</code></pre>\n")
+	return b.String()
+}
+
+// writeFiles lists changed files and puts each embedded patch in a collapsed block, within the
+// same size limits as the markdown document.
+func (d *htmlDocument) writeFiles(data gh.IssueData) {
+	if !d.opts.IncludeFiles || !d.opts.IncludePatches || len(data.Files) == 0 {
+		d.section("files", markdownToHTML(renderPRFilesSection(data, d.opts)))
+		return
+	}
+	listing := d.opts
+	listing.IncludePatches = false
+	d.b.WriteString("<section id=\"files\">\n" + markdownToHTML(renderPRFilesSection(data, listing)))
+	maxPatchBytes := d.opts.MaxPatchBytes
+	if maxPatchBytes <= 0 {
+		maxPatchBytes = DefaultMaxPatchBytes
+	}
+	budget := maxTotalPatchBytes
+	for _, file := range data.Files {
+		fmt.Fprintf(&d.b, "<details class=\"patch\">\n<summary><code>%s</code></summary>\n", html.EscapeString(file.Filename))
+		switch {
+		case file.Patch == "":
+			d.note("Patch unavailable (binary or too large).")
+		case budget <= 0:
+			d.note("Patch omitted (total patch size limit reached).")
+		default:
+			patch, truncated := truncatePatch(file.Patch, min(maxPatchBytes, budget))
+			budget -= len(patch)
+			d.b.WriteString(diffBlockHTML(patch))
+			if truncated {
+				d.note("Patch truncated at %d bytes.", len(patch))
+			}
+		}
+		d.b.WriteString("</details>\n")
+	}
+	d.b.WriteString("</section>\n")
+}
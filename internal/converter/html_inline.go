@@ -0,0 +1,459 @@
+package converter
+
+import (
+	"html"
+	"strings"
+)
+
+// inline converts the inline markdown of one block to HTML. Raw tags opened inside text are
+// closed before it returns, so inline HTML never leaks into the surrounding block.
+func (c *mdConverter) inline(text string) string {
+	base := len(c.open)
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		i = c.inlineAt(&b, text, i, base)
+	}
+	c.closeOpen(&b, base)
+	return b.String()
+}
+
+type inlineParser func(b *strings.Builder, text string, i int) (next int, ok bool)
+
+func (c *mdConverter) inlineAt(b *strings.Builder, text string, i, base int) int {
+	var parse inlineParser
+	switch text[i] {
+	case '\\':
+		parse = writeEscape
+	case '`':
+		parse = writeCodeSpan
+	case '!', '[':
+		parse = c.link
+	case '<':
+		parse = func(b *strings.Builder, text string, i int) (int, bool) { return c.angle(b, text, i, base) }
+	case '*', '_', '~':
+		parse = c.emphasis
+	case '&':
+		b.WriteString(entityOrAmp(text[i:]))
+		return i + max(entityLength(text[i:]), 1)
+	case '\n':
+		b.WriteString("<br>\n")
+		return i + 1
+	case 'h', 'H', 'w', 'W':
+		parse = c.bareURL
+	}
+	if parse != nil {
+		if next, ok := parse(b, text, i); ok {
+			return next
+		}
+	}
+	writeTextByte(b, text[i])
+	return i + 1
+}
+
+func writeTextByte(b *strings.Builder, ch byte) {
+	switch ch {
+	case '<':
+		b.WriteString("&lt;")
+	case '>':
+		b.WriteString("&gt;")
+	case '&':
+		b.WriteString("&amp;")
+	case '"':
+		b.WriteString("&#34;")
+	default:
+		b.WriteByte(ch)
+	}
+}
+
+func isASCIIPunct(ch byte) bool {
+	return ch < 0x80 && strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", ch) >= 0
+}
+
+func isAlphanumeric(ch byte) bool {
+	return isASCIILetter(ch) || isASCIIDigit(ch)
+}
+
+func isInlineSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n'
+}
+
+func writeEscape(b *strings.Builder, text string, i int) (int, bool) {
+	if i+1 >= len(text) {
+		return i, false
+	}
+	switch next := text[i+1]; {
+	case next == '\n':
+		b.WriteString("<br>\n")
+	case isASCIIPunct(next):
+		writeTextByte(b, next)
+	default:
+		return i, false
+	}
+	return i + 2, true
+}
+
+func runLength(text string, i int) int {
+	n := 0
+	for i+n < len(text) && text[i+n] == text[i] {
+		n++
+	}
+	return n
+}
+
+// codeSpanEnd returns the index of the backtick run closing the code span opened by the run of
+// length n at i, or -1.
+func codeSpanEnd(text string, i, n int) int {
+	for k := i + n; k < len(text); {
+		if text[k] != '`' {
+			k++
+			continue
+		}
+		run := runLength(text, k)
+		if run == n {
+			return k
+		}
+		k += run
+	}
+	return -1
+}
+
+func writeCodeSpan(b *strings.Builder, text string, i int) (int, bool) {
+	n := runLength(text, i)
+	end := codeSpanEnd(text, i, n)
+	if end < 0 {
+		b.WriteString(text[i : i+n])
+		return i + n, true
+	}
+	code := strings.ReplaceAll(text[i+n:end], "\n", " ")
+	if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+		code = code[1 : len(code)-1]
+	}
+	b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+	return end + n, true
+}
+
+// unescapeMarkdown drops backslash escapes and decodes entities in a link destination or title.
+func unescapeMarkdown(s string) string {
+	var b strings.Builder
+	for k := 0; k < len(s); k++ {
+		if s[k] == '\\' && k+1 < len(s) && isASCIIPunct(s[k+1]) {
+			k++
+		}
+		b.WriteByte(s[k])
+	}
+	return html.UnescapeString(b.String())
+}
+
+type inlineLink struct {
+	label string
+	dest  string
+	title string
+	end   int
+}
+
+// labelEnd returns the index of the bracket closing the link label opened at i, or -1.
+func labelEnd(text string, i int) int {
+	depth := 0
+	for k := i; k < len(text); k++ {
+		switch text[k] {
+		case '\\':
+			k++
+		case '`':
+			n := runLength(text, k)
+			if end := codeSpanEnd(text, k, n); end >= 0 {
+				k = end + n - 1
+			} else {
+				k += n - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return k
+			}
+		}
+	}
+	return -1
+}
+
+func parseInlineLink(text string, i int) (inlineLink, bool) {
+	end := labelEnd(text, i)
+	if end < 0 || end+1 >= len(text) || text[end+1] != '(' {
+		return inlineLink{}, false
+	}
+	link := inlineLink{label: text[i+1 : end]}
+	k := skipHTMLSpace(text, end+2)
+	dest, k, ok := parseLinkDestination(text, k)
+	if !ok {
+		return inlineLink{}, false
+	}
+	link.dest = unescapeMarkdown(dest)
+	if title, next, ok := parseLinkTitle(text, k); ok {
+		link.title, k = title, next
+	} else if next < 0 {
+		return inlineLink{}, false
+	}
+	k = skipHTMLSpace(text, k)
+	if k >= len(text) || text[k] != ')' {
+		return inlineLink{}, false
+	}
+	link.end = k + 1
+	return link, true
+}
+
+// parseLinkTitle parses a quoted or parenthesized title after white space at k. next is -1 when
+// a title opens but never closes.
+func parseLinkTitle(text string, k int) (title string, next int, ok bool) {
+	spaced := skipHTMLSpace(text, k)
+	if spaced == k || spaced >= len(text) || strings.IndexByte("\"'(", text[spaced]) < 0 {
+		return "", k, false
+	}
+	closer := text[spaced]
+	if closer == '(' {
+		closer = ')'
+	}
+	stop := strings.IndexByte(text[spaced+1:], closer)
+	if stop < 0 {
+		return "", -1, false
+	}
+	return unescapeMarkdown(text[spaced+1 : spaced+1+stop]), spaced + stop + 2, true
+}
+
+// referenceLink resolves a full "[text][label]", collapsed "[text][]" or shortcut "[text]"
+// reference against the body's link reference definitions.
+func (c *mdConverter) referenceLink(text string, i int) (inlineLink, bool) {
+	end := labelEnd(text, i)
+	if end < 0 {
+		return inlineLink{}, false
+	}
+	link := inlineLink{label: text[i+1 : end], end: end + 1}
+	key := link.label
+	if end+1 < len(text) && text[end+1] == '[' {
+		if refEnd := labelEnd(text, end+1); refEnd >= 0 {
+			if ref := text[end+2 : refEnd]; strings.TrimSpace(ref) != "" {
+				key = ref
+			}
+			link.end = refEnd + 1
+		}
+	}
+	def, ok := c.refs[normalizeLinkLabel(key)]
+	if !ok {
+		return inlineLink{}, false
+	}
+	link.dest, link.title = def.dest, def.title
+	return link, true
+}
+
+func parseLinkDestination(text string, k int) (string, int, bool) {
+	if k < len(text) && text[k] == '<' {
+		stop := strings.IndexAny(text[k+1:], "<>\n")
+		if stop < 0 || text[k+1+stop] != '>' {
+			return "", k, false
+		}
+		return text[k+1 : k+1+stop], k + stop + 2, true
+	}
+	start, depth := k, 0
+	for ; k < len(text); k++ {
+		switch ch := text[k]; {
+		case ch == '\\' && k+1 < len(text):
+			k++
+		case ch == '(':
+			depth++
+		case ch == ')' && depth == 0:
+			return text[start:k], k, true
+		case ch == ')':
+			depth--
+		case ch <= ' ':
+			return text[start:k], k, depth == 0
+		}
+	}
+	return text[start:k], k, depth == 0
+}
+
+// link writes an inline or reference link or image. Destinations that fail safeURL are dropped
+// and only the label text is kept.
+func (c *mdConverter) link(b *strings.Builder, text string, i int) (int, bool) {
+	image := text[i] == '!'
+	if image && (i+1 >= len(text) || text[i+1] != '[') {
+		return i, false
+	}
+	start := i
+	if image {
+		start++
+	}
+	link, ok := parseInlineLink(text, start)
+	if !ok {
+		link, ok = c.referenceLink(text, start)
+	}
+	if !ok {
+		return i, false
+	}
+	dest := strings.ReplaceAll(link.dest, " ", "%20")
+	title := ""
+	if link.title != "" {
+		title = ` title="` + html.EscapeString(link.title) + `"`
+	}
+	switch {
+	case image && safeURL(dest):
+		alt := strings.ReplaceAll(unescapeMarkdown(link.label), "`", "")
+		b.WriteString(`<img src="` + html.EscapeString(dest) + `" alt="` + html.EscapeString(alt) + `"` + title + ` loading="lazy">`)
+	case image:
+		b.WriteString(html.EscapeString(unescapeMarkdown(link.label)))
+	case safeURL(dest) && !c.inLink:
+		c.inLink = true
+		label := c.inline(link.label)
+		c.inLink = false
+		b.WriteString(`<a href="` + html.EscapeString(dest) + `"` + title + ` rel="nofollow noopener noreferrer">` + label + "</a>")
+	default:
+		b.WriteString(c.inline(link.label))
+	}
+	return link.end, true
+}
+
+// angle handles an autolink, an HTML comment or a raw inline tag starting at i.
+func (c *mdConverter) angle(b *strings.Builder, text string, i, base int) (int, bool) {
+	rest := text[i:]
+	if strings.HasPrefix(rest, "<!--") {
+		if end := strings.Index(rest[4:], "-->"); end >= 0 {
+			return i + 4 + end + 3, true
+		}
+		return i, false
+	}
+	if end := strings.IndexAny(rest[1:], "<> \t\n"); end > 0 && rest[1+end] == '>' {
+		target := rest[1 : 1+end]
+		href := target
+		if !strings.Contains(target, ":") && strings.Contains(target, "@") {
+			href = "mailto:" + target
+		}
+		if strings.Contains(href, ":") && safeURL(href) && !c.inLink {
+			b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer">` + html.EscapeString(target) + "</a>")
+			return i + end + 2, true
+		}
+	}
+	tag, end, ok := parseHTMLTag(text, i)
+	if !ok {
+		return i, false
+	}
+	c.writeSanitizedTag(b, tag, text[i:end], base)
+	return end, true
+}
+
+// emphasis writes *em*, **strong** and ~~strikethrough~~ spans. A delimiter run without a
+// matching closer is written as text.
+func (c *mdConverter) emphasis(b *strings.Builder, text string, i int) (int, bool) {
+	ch := text[i]
+	run := runLength(text, i)
+	if i+run >= len(text) || isInlineSpace(text[i+run]) || (ch == '_' && i > 0 && isAlphanumeric(text[i-1])) {
+		b.WriteString(text[i : i+run])
+		return i + run, true
+	}
+	widths := []int{2, 1}
+	if ch == '~' {
+		widths = []int{run}
+		if run > 2 {
+			widths = nil
+		}
+	}
+	for _, n := range widths {
+		if n > run {
+			continue
+		}
+		closer := emphasisCloser(text, i+n, ch, n)
+		if closer < 0 {
+			continue
+		}
+		tag := "em"
+		switch {
+		case ch == '~':
+			tag = "del"
+		case n == 2:
+			tag = "strong"
+		}
+		b.WriteString("<" + tag + ">" + c.inline(text[i+n:closer]) + "</" + tag + ">")
+		return closer + n, true
+	}
+	b.WriteString(text[i : i+run])
+	return i + run, true
+}
+
+// emphasisCloser finds the closing delimiter of width n for an opener ending at start, skipping
+// escapes and code spans. It returns the index of the closer or -1.
+func emphasisCloser(text string, start int, ch byte, n int) int {
+	for k := start; k < len(text); {
+		switch text[k] {
+		case '\\':
+			k += 2
+			continue
+		case '`':
+			run := runLength(text, k)
+			if end := codeSpanEnd(text, k, run); end >= 0 {
+				k = end + run
+			} else {
+				k += run
+			}
+			continue
+		case ch:
+		default:
+			k++
+			continue
+		}
+		run := runLength(text, k)
+		end := k + run
+		pos := end - n
+		matches := run >= n && (ch != '~' || run == n)
+		if matches && pos > start && !isInlineSpace(text[pos-1]) && (ch != '_' || end >= len(text) || !isAlphanumeric(text[end])) {
+			return pos
+		}
+		k = end
+	}
+	return -1
+}
+
+// bareURL autolinks http(s):// and www. URLs in text the way GitHub does, leaving trailing
+// punctuation outside the link.
+func (c *mdConverter) bareURL(b *strings.Builder, text string, i int) (int, bool) {
+	if c.inLink || (i > 0 && isAlphanumeric(text[i-1])) {
+		return i, false
+	}
+	lower := strings.ToLower(text[i:min(len(text), i+8)])
+	prefix := ""
+	for _, candidate := range []string{"https://", "http://", "www."} {
+		if strings.HasPrefix(lower, candidate) {
+			prefix = candidate
+			break
+		}
+	}
+	if prefix == "" {
+		return i, false
+	}
+	end := i
+	for end < len(text) && !isInlineSpace(text[end]) && text[end] != '<' {
+		end++
+	}
+	url := trimURLPunctuation(text[i:end])
+	if len(url) <= len(prefix) {
+		return i, false
+	}
+	href := url
+	if prefix == "www." {
+		href = "http://" + url
+	}
+	b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer">` + html.EscapeString(url) + "</a>")
+	return i + len(url), true
+}
+
+func trimURLPunctuation(url string) string {
+	for url != "" {
+		last := url[len(url)-1]
+		switch {
+		case strings.IndexByte("?!.,:;*_~'\"", last) >= 0:
+			url = url[:len(url)-1]
+		case last == ')' && strings.Count(url, ")") > strings.Count(url, "("):
+			url = url[:len(url)-1]
+		default:
+			return url
+		}
+	}
+	return url
+}
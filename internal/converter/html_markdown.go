@@ -0,0 +1,687 @@
+package converter
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// markdownToHTML converts a GitHub-flavored markdown body to HTML. Text is always escaped and
+// raw HTML in the body only passes through the allowlist in html_sanitize.go, so the result is
+// safe to embed in a page. Every element the body opens is closed within it.
+func markdownToHTML(src string) string {
+	lines := splitMarkdownLines(src)
+	c := &mdConverter{refs: collectLinkReferences(lines)}
+	var b strings.Builder
+	c.blocks(&b, lines)
+	return b.String()
+}
+
+// mdConverter holds the state of one body conversion. open lists the raw HTML elements the body
+// opened and has not closed yet; each container closes the ones it opened before it ends. refs
+// holds the link reference definitions of the body by normalized label.
+type mdConverter struct {
+	refs   map[string]inlineLink
+	open   []string
+	scope  int
+	tight  bool
+	inLink bool
+}
+
+// collectLinkReferences gathers the "[label]: destination "title"" definitions of a body and
+// blanks their lines, so they do not render as paragraphs. Definitions inside fenced code and
+// lines continuing a paragraph are left alone; the first definition of a label wins.
+func collectLinkReferences(lines []string) map[string]inlineLink {
+	refs := map[string]inlineLink{}
+	var open fence
+	inFence, paragraph := false, false
+	for i, line := range lines {
+		if current, ok := parseFence(line); ok {
+			switch {
+			case !inFence:
+				open, inFence = current, true
+			case current.char == open.char && current.length >= open.length && current.info == "":
+				inFence = false
+			}
+			paragraph = false
+			continue
+		}
+		if inFence {
+			continue
+		}
+		label, def, ok := parseLinkReference(line)
+		if !ok || paragraph {
+			paragraph = !isBlankLine(line)
+			continue
+		}
+		if _, seen := refs[label]; !seen {
+			refs[label] = def
+		}
+		lines[i] = ""
+	}
+	return refs
+}
+
+// parseLinkReference parses a single-line link reference definition and returns its
+// normalized label.
+func parseLinkReference(line string) (string, inlineLink, bool) {
+	if indentOf(line) > 3 {
+		return "", inlineLink{}, false
+	}
+	text := strings.TrimSpace(line)
+	end := labelEnd(text, 0)
+	if !strings.HasPrefix(text, "[") || end < 0 || end+1 >= len(text) || text[end+1] != ':' {
+		return "", inlineLink{}, false
+	}
+	label := normalizeLinkLabel(text[1:end])
+	k := skipHTMLSpace(text, end+2)
+	dest, k, ok := parseLinkDestination(text, k)
+	if !ok || label == "" || dest == "" {
+		return "", inlineLink{}, false
+	}
+	def := inlineLink{dest: unescapeMarkdown(dest)}
+	if title, next, ok := parseLinkTitle(text, k); ok {
+		def.title, k = title, next
+	}
+	if strings.TrimSpace(text[k:]) != "" {
+		return "", inlineLink{}, false
+	}
+	return label, def, true
+}
+
+// normalizeLinkLabel matches reference labels case-insensitively with collapsed white space.
+func normalizeLinkLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+type blockParser func(b *strings.Builder, lines []string, i int) (next int, ok bool)
+
+func splitMarkdownLines(src string) []string {
+	src = strings.ReplaceAll(strings.ReplaceAll(src, "\r\n", "\n"), "\r", "\n")
+	lines := strings.Split(strings.TrimRight(src, "\n"), "\n")
+	for i, line := range lines {
+		rest := strings.TrimLeft(line, " \t")
+		indent := line[:len(line)-len(rest)]
+		if strings.Contains(indent, "\t") {
+			lines[i] = strings.ReplaceAll(indent, "\t", "    ") + rest
+		}
+	}
+	return lines
+}
+
+func (c *mdConverter) blocks(b *strings.Builder, lines []string) {
+	outer := c.scope
+	c.scope = len(c.open)
+	parsers := []blockParser{c.fencedCode, c.atxHeading, c.thematicBreak, c.blockquote, c.list, c.table, c.htmlBlock, c.indentedCode}
+	for i := 0; i < len(lines); {
+		if isBlankLine(lines[i]) {
+			i++
+			continue
+		}
+		matched := false
+		for _, parse := range parsers {
+			if next, ok := parse(b, lines, i); ok {
+				i, matched = next, true
+				break
+			}
+		}
+		if !matched {
+			i = c.paragraph(b, lines, i)
+		}
+	}
+	c.closeOpen(b, c.scope)
+	c.scope = outer
+}
+
+func isBlankLine(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// interruptsParagraph reports whether line starts a block that ends an open paragraph.
+func interruptsParagraph(line string) bool {
+	if _, ok := parseFence(line); ok {
+		return true
+	}
+	if _, _, ok := parseATXHeading(line); ok {
+		return true
+	}
+	if isThematicBreak(line) || isBlockquoteLine(line) || isHTMLBlockStart(line, true) {
+		return true
+	}
+	marker, ok := parseListMarker(line)
+	return ok && !marker.empty && (!marker.ordered || marker.start == 1)
+}
+
+type fence struct {
+	info   string
+	indent int
+	length int
+	char   byte
+}
+
+func parseFence(line string) (fence, bool) {
+	indent := indentOf(line)
+	if indent > 3 {
+		return fence{}, false
+	}
+	rest := line[indent:]
+	if rest == "" || (rest[0] != '`' && rest[0] != '~') {
+		return fence{}, false
+	}
+	length := 0
+	for length < len(rest) && rest[length] == rest[0] {
+		length++
+	}
+	info := strings.TrimSpace(rest[length:])
+	if length < 3 || (rest[0] == '`' && strings.Contains(info, "`")) {
+		return fence{}, false
+	}
+	return fence{info: info, indent: indent, length: length, char: rest[0]}, true
+}
+
+func (c *mdConverter) fencedCode(b *strings.Builder, lines []string, i int) (int, bool) {
+	open, ok := parseFence(lines[i])
+	if !ok {
+		return i, false
+	}
+	var code []string
+	j := i + 1
+	for ; j < len(lines); j++ {
+		if closing, ok := parseFence(lines[j]); ok && closing.char == open.char && closing.length >= open.length && closing.info == "" {
+			j++
+			break
+		}
+		line := lines[j]
+		line = line[min(indentOf(line), open.indent):]
+		code = append(code, line)
+	}
+	writeCodeBlock(b, code, open.info)
+	return j, true
+}
+
+func writeCodeBlock(b *strings.Builder, code []string, info string) {
+	lang, _, _ := strings.Cut(info, " ")
+	lang = strings.Map(func(r rune) rune {
+		if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("+-_#.", r)) {
+			return r
+		}
+		return -1
+	}, lang)
+	if lang != "" {
+		fmt.Fprintf(b, "<pre><code class=\"language-%s\">", lang)
+	} else {
+		b.WriteString("<pre><code>")
+	}
+	for _, line := range code {
+		b.WriteString(html.EscapeString(line))
+		b.WriteString("\n")
+	}
+	b.WriteString("</code></pre>\n")
+}
+
+func parseATXHeading(line string) (int, string, bool) {
+	indent := indentOf(line)
+	if indent > 3 {
+		return 0, "", false
+	}
+	rest := line[indent:]
+	level := 0
+	for level < len(rest) && rest[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(rest) && rest[level] != ' ') {
+		return 0, "", false
+	}
+	text := strings.TrimSpace(rest[level:])
+	if trimmed := strings.TrimRight(text, "#"); trimmed == "" || strings.HasSuffix(trimmed, " ") {
+		text = strings.TrimSpace(trimmed)
+	}
+	return level, text, true
+}
+
+func (c *mdConverter) atxHeading(b *strings.Builder, lines []string, i int) (int, bool) {
+	level, text, ok := parseATXHeading(lines[i])
+	if !ok {
+		return i, false
+	}
+	fmt.Fprintf(b, "<h%d>%s</h%d>\n", level, c.inline(text), level)
+	return i + 1, true
+}
+
+func isThematicBreak(line string) bool {
+	if indentOf(line) > 3 {
+		return false
+	}
+	var char rune
+	count := 0
+	for _, r := range line {
+		switch {
+		case r == ' ':
+		case (r == '*' || r == '-' || r == '_') && (char == 0 || r == char):
+			char = r
+			count++
+		default:
+			return false
+		}
+	}
+	return count >= 3
+}
+
+func (c *mdConverter) thematicBreak(b *strings.Builder, lines []string, i int) (int, bool) {
+	if !isThematicBreak(lines[i]) {
+		return i, false
+	}
+	b.WriteString("<hr>\n")
+	return i + 1, true
+}
+
+func isBlockquoteLine(line string) bool {
+	indent := indentOf(line)
+	return indent <= 3 && strings.HasPrefix(line[indent:], ">")
+}
+
+func (c *mdConverter) blockquote(b *strings.Builder, lines []string, i int) (int, bool) {
+	if !isBlockquoteLine(lines[i]) {
+		return i, false
+	}
+	var inner []string
+	j := i
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		switch {
+		case isBlockquoteLine(line):
+			rest := strings.TrimPrefix(line[indentOf(line):], ">")
+			inner = append(inner, strings.TrimPrefix(rest, " "))
+		case !isBlankLine(line) && !isBlankLine(inner[len(inner)-1]) && !interruptsParagraph(line):
+			// A lazy continuation line extends the quoted paragraph.
+			inner = append(inner, line)
+		default:
+			return c.writeBlockquote(b, inner, j), true
+		}
+	}
+	return c.writeBlockquote(b, inner, j), true
+}
+
+func (c *mdConverter) writeBlockquote(b *strings.Builder, inner []string, next int) int {
+	tight := c.tight
+	c.tight = false
+	b.WriteString("<blockquote>\n")
+	c.blocks(b, inner)
+	b.WriteString("</blockquote>\n")
+	c.tight = tight
+	return next
+}
+
+type listMarker struct {
+	bullet  byte
+	indent  int
+	content int
+	start   int
+	ordered bool
+	empty   bool
+}
+
+func parseListMarker(line string) (listMarker, bool) {
+	indent := indentOf(line)
+	if indent > 3 {
+		return listMarker{}, false
+	}
+	rest := line[indent:]
+	marker := listMarker{indent: indent}
+	width := 0
+	switch {
+	case rest != "" && (rest[0] == '-' || rest[0] == '*' || rest[0] == '+'):
+		marker.bullet = rest[0]
+		width = 1
+	default:
+		for width < len(rest) && width < 9 && rest[width] >= '0' && rest[width] <= '9' {
+			width++
+		}
+		if width == 0 || width >= len(rest) || (rest[width] != '.' && rest[width] != ')') {
+			return listMarker{}, false
+		}
+		marker.start, _ = strconv.Atoi(rest[:width])
+		marker.ordered = true
+		marker.bullet = rest[width]
+		width++
+	}
+	after := rest[width:]
+	if after == "" || isBlankLine(after) {
+		marker.empty = true
+		marker.content = indent + width + 1
+		return marker, true
+	}
+	if after[0] != ' ' {
+		return listMarker{}, false
+	}
+	spaces := indentOf(after)
+	if spaces > 4 {
+		spaces = 1
+	}
+	marker.content = indent + width + spaces
+	return marker, true
+}
+
+func (m listMarker) sameList(other listMarker) bool {
+	return m.ordered == other.ordered && m.bullet == other.bullet
+}
+
+func (c *mdConverter) list(b *strings.Builder, lines []string, i int) (int, bool) {
+	first, ok := parseListMarker(lines[i])
+	if !ok {
+		return i, false
+	}
+	items := [][]string{{listItemText(lines[i], first)}}
+	content := first.content
+	loose := false
+	j := i + 1
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		current := items[len(items)-1]
+		if marker, ok := parseListMarker(line); ok && marker.sameList(first) && marker.indent < content {
+			items = append(items, []string{listItemText(line, marker)})
+			content = marker.content
+			continue
+		}
+		switch {
+		case isBlankLine(line):
+			next := nextNonBlank(lines, j)
+			if next < 0 || !continuesList(lines[next], first, content) {
+				c.writeList(b, first, items, loose)
+				return j, true
+			}
+			loose = loose || !blankInsideFence(current)
+			items[len(items)-1] = append(current, "")
+		case indentOf(line) >= content:
+			items[len(items)-1] = append(current, line[content:])
+		case !isBlankLine(current[len(current)-1]) && !interruptsParagraph(line):
+			// A lazy continuation line extends the item's paragraph.
+			items[len(items)-1] = append(current, strings.TrimLeft(line, " "))
+		default:
+			c.writeList(b, first, items, loose)
+			return j, true
+		}
+	}
+	c.writeList(b, first, items, loose)
+	return j, true
+}
+
+func listItemText(line string, marker listMarker) string {
+	return strings.TrimRight(line[min(marker.content, len(line)):], " ")
+}
+
+func continuesList(line string, first listMarker, content int) bool {
+	if indentOf(line) >= content {
+		return true
+	}
+	marker, ok := parseListMarker(line)
+	return ok && marker.sameList(first)
+}
+
+// blankInsideFence reports whether a trailing blank line belongs to an open code fence, where
+// it does not make the list loose.
+func blankInsideFence(lines []string) bool {
+	open := false
+	for _, line := range lines {
+		if _, ok := parseFence(line); ok {
+			open = !open
+		}
+	}
+	return open
+}
+
+func nextNonBlank(lines []string, from int) int {
+	for k := from; k < len(lines); k++ {
+		if !isBlankLine(lines[k]) {
+			return k
+		}
+	}
+	return -1
+}
+
+func (c *mdConverter) writeList(b *strings.Builder, first listMarker, items [][]string, loose bool) {
+	tag := "ul"
+	switch {
+	case first.ordered && first.start != 1:
+		tag = "ol"
+		fmt.Fprintf(b, "<ol start=\"%d\">\n", first.start)
+	case first.ordered:
+		tag = "ol"
+		b.WriteString("<ol>\n")
+	default:
+		b.WriteString("<ul>\n")
+	}
+	tight := c.tight
+	c.tight = !loose
+	for _, item := range items {
+		if checked, rest, ok := taskItem(item[0]); ok {
+			b.WriteString("<li class=\"task-list-item\"><input type=\"checkbox\" disabled")
+			if checked {
+				b.WriteString(" checked")
+			}
+			b.WriteString("> ")
+			item = append([]string{rest}, item[1:]...)
+		} else {
+			b.WriteString("<li>")
+		}
+		c.blocks(b, item)
+		b.WriteString("</li>\n")
+	}
+	c.tight = tight
+	fmt.Fprintf(b, "</%s>\n", tag)
+}
+
+func taskItem(first string) (checked bool, rest string, ok bool) {
+	if len(first) < 3 || first[0] != '[' || first[2] != ']' || (len(first) > 3 && first[3] != ' ') {
+		return false, "", false
+	}
+	switch first[1] {
+	case ' ':
+		return false, strings.TrimPrefix(first[3:], " "), true
+	case 'x', 'X':
+		return true, strings.TrimPrefix(first[3:], " "), true
+	default:
+		return false, "", false
+	}
+}
+
+func (c *mdConverter) table(b *strings.Builder, lines []string, i int) (int, bool) {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") {
+		return i, false
+	}
+	header := splitTableRow(lines[i])
+	aligns, ok := parseTableDelimiter(lines[i+1])
+	if !ok || len(aligns) != len(header) {
+		return i, false
+	}
+
+	b.WriteString("<table>\n<thead>\n")
+	c.writeTableRow(b, "th", header, aligns)
+	b.WriteString("</thead>\n")
+	j := i + 2
+	if j < len(lines) && !isBlankLine(lines[j]) {
+		b.WriteString("<tbody>\n")
+		for ; j < len(lines) && !isBlankLine(lines[j]) && !interruptsParagraph(lines[j]); j++ {
+			c.writeTableRow(b, "td", splitTableRow(lines[j]), aligns)
+		}
+		b.WriteString("</tbody>\n")
+	}
+	b.WriteString("</table>\n")
+	return j, true
+}
+
+func (c *mdConverter) writeTableRow(b *strings.Builder, tag string, cells, aligns []string) {
+	b.WriteString("<tr>")
+	for k, align := range aligns {
+		cell := ""
+		if k < len(cells) {
+			cell = cells[k]
+		}
+		if align != "" {
+			fmt.Fprintf(b, "<%s align=\"%s\">%s</%s>", tag, align, c.inline(cell), tag)
+		} else {
+			fmt.Fprintf(b, "<%s>%s</%s>", tag, c.inline(cell), tag)
+		}
+	}
+	b.WriteString("</tr>\n")
+}
+
+// splitTableRow splits a pipe table row on unescaped pipes outside code spans.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	inCode := false
+	for k := 0; k < len(line); k++ {
+		switch {
+		case line[k] == '\\' && k+1 < len(line) && line[k+1] == '|':
+			cell.WriteByte('|')
+			k++
+		case line[k] == '`':
+			inCode = !inCode
+			cell.WriteByte('`')
+		case line[k] == '|' && !inCode:
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[k])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func parseTableDelimiter(line string) ([]string, bool) {
+	if !strings.Contains(line, "-") {
+		return nil, false
+	}
+	cells := splitTableRow(line)
+	aligns := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		trimmed := strings.Trim(cell, ":")
+		if trimmed == "" || strings.Trim(trimmed, "-") != "" {
+			return nil, false
+		}
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			aligns = append(aligns, "center")
+		case right:
+			aligns = append(aligns, "right")
+		case left:
+			aligns = append(aligns, "left")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+	return aligns, true
+}
+
+// isHTMLBlockStart reports whether line opens a raw HTML block: a comment, a block-level tag,
+// or (when not interrupting a paragraph) any tag alone on its line.
+func isHTMLBlockStart(line string, interrupting bool) bool {
+	indent := indentOf(line)
+	if indent > 3 || !strings.HasPrefix(line[indent:], "<") {
+		return false
+	}
+	rest := line[indent:]
+	if strings.HasPrefix(rest, "<!--") {
+		return true
+	}
+	tag, end, ok := parseHTMLTag(rest, 0)
+	if !ok {
+		return false
+	}
+	if isBlockHTMLTag(tag.name) {
+		return true
+	}
+	return !interrupting && isBlankLine(rest[end:])
+}
+
+func (c *mdConverter) htmlBlock(b *strings.Builder, lines []string, i int) (int, bool) {
+	if !isHTMLBlockStart(lines[i], false) {
+		return i, false
+	}
+	j := i
+	for j < len(lines) && !isBlankLine(lines[j]) {
+		j++
+	}
+	c.rawHTML(b, strings.Join(lines[i:j], "\n"), c.scope)
+	b.WriteString("\n")
+	return j, true
+}
+
+func (c *mdConverter) indentedCode(b *strings.Builder, lines []string, i int) (int, bool) {
+	if indentOf(lines[i]) < 4 {
+		return i, false
+	}
+	var code []string
+	j := i
+	for ; j < len(lines) && (isBlankLine(lines[j]) || indentOf(lines[j]) >= 4); j++ {
+		if isBlankLine(lines[j]) {
+			code = append(code, "")
+			continue
+		}
+		code = append(code, lines[j][4:])
+	}
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+	writeCodeBlock(b, code, "")
+	return j, true
+}
+
+func (c *mdConverter) paragraph(b *strings.Builder, lines []string, i int) int {
+	j := i + 1
+	for ; j < len(lines) && !isBlankLine(lines[j]); j++ {
+		if level := setextLevel(lines[j]); level > 0 {
+			text := strings.TrimSpace(strings.Join(trimLines(lines[i:j]), "\n"))
+			fmt.Fprintf(b, "<h%d>%s</h%d>\n", level, c.inline(text), level)
+			return j + 1
+		}
+		if interruptsParagraph(lines[j]) {
+			break
+		}
+	}
+	text := strings.Join(trimLines(lines[i:j]), "\n")
+	if c.tight {
+		b.WriteString(c.inline(text))
+		b.WriteString("\n")
+	} else {
+		fmt.Fprintf(b, "<p>%s</p>\n", c.inline(text))
+	}
+	return j
+}
+
+func setextLevel(line string) int {
+	if indentOf(line) > 3 {
+		return 0
+	}
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed != "" && strings.Trim(trimmed, "=") == "":
+		return 1
+	case trimmed != "" && strings.Trim(trimmed, "-") == "":
+		return 2
+	default:
+		return 0
+	}
+}
+
+func trimLines(lines []string) []string {
+	out := make([]string, len(lines))
+	for k, line := range lines {
+		out[k] = strings.TrimLeft(line, " ")
+	}
+	return out
+}
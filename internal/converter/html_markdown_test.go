@@ -0,0 +1,184 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "heading and inline styles",
+			src:  "## Steps\n\nRun **go** with *care*, ~~not~~ `a<b`.",
+			want: "<h2>Steps</h2>\n<p>Run <strong>go</strong> with <em>care</em>, <del>not</del> <code>a&lt;b</code>.</p>\n",
+		},
+		{
+			name: "soft line break",
+			src:  "first\nsecond",
+			want: "<p>first<br>\nsecond</p>\n",
+		},
+		{
+			name: "fenced code keeps markup as text",
+			src:  "```go\nfmt.Println(\"<b>\")\n```",
+			want: "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;b&gt;&#34;)\n</code></pre>\n",
+		},
+		{
+			name: "tight list with task items",
+			src:  "- [x] done\n- [ ] todo\n  - nested",
+			want: "<ul>\n<li class=\"task-list-item\"><input type=\"checkbox\" disabled checked> done\n</li>\n" +
+				"<li class=\"task-list-item\"><input type=\"checkbox\" disabled> todo\n<ul>\n<li>nested\n</li>\n</ul>\n</li>\n</ul>\n",
+		},
+		{
+			name: "ordered list start",
+			src:  "3. three\n4. four",
+			want: "<ol start=\"3\">\n<li>three\n</li>\n<li>four\n</li>\n</ol>\n",
+		},
+		{
+			name: "blockquote with lazy line",
+			src:  "> quoted\nlazy",
+			want: "<blockquote>\n<p>quoted<br>\nlazy</p>\n</blockquote>\n",
+		},
+		{
+			name: "table with alignment",
+			src:  "| a | b |\n|:--|--:|\n| `x\\|y` | 2 |",
+			want: "<table>\n<thead>\n<tr><th align=\"left\">a</th><th align=\"right\">b</th></tr>\n</thead>\n" +
+				"<tbody>\n<tr><td align=\"left\"><code>x|y</code></td><td align=\"right\">2</td></tr>\n</tbody>\n</table>\n",
+		},
+		{
+			name: "full reference links",
+			src:  "See [the docs][Docs] and ![logo][1].\n\n[docs]: https://example.com/a \"Docs\"\n[1]: <https://example.com/logo.png>",
+			want: "<p>See <a href=\"https://example.com/a\" title=\"Docs\" rel=\"nofollow noopener noreferrer\">the docs</a> and " +
+				"<img src=\"https://example.com/logo.png\" alt=\"logo\" loading=\"lazy\">.</p>\n",
+		},
+		{
+			name: "collapsed and shortcut reference links",
+			src:  "[Guide][] and [guide] but not [missing].\n\n[guide]: https://example.com/guide",
+			want: "<p><a href=\"https://example.com/guide\" rel=\"nofollow noopener noreferrer\">Guide</a> and " +
+				"<a href=\"https://example.com/guide\" rel=\"nofollow noopener noreferrer\">guide</a> but not [missing].</p>\n",
+		},
+		{
+			name: "reference definitions in code and unsafe destinations",
+			src:  "[x] and [y]\n\n[x]: javascript:alert(1)\n\n```\n[y]: https://example.com/y\n```",
+			want: "<p>x and [y]</p>\n<pre><code>[y]: https://example.com/y\n</code></pre>\n",
+		},
+		{
+			name: "links and autolinks",
+			src:  "[docs](https://example.com/a \"Docs\") and https://example.com/b. <me@example.com>",
+			want: "<p><a href=\"https://example.com/a\" title=\"Docs\" rel=\"nofollow noopener noreferrer\">docs</a> and " +
+				"<a href=\"https://example.com/b\" rel=\"nofollow noopener noreferrer\">https://example.com/b</a>. " +
+				"<a href=\"mailto:me@example.com\" rel=\"nofollow noopener noreferrer\">me@example.com</a></p>\n",
+		},
+		{
+			name: "image",
+			src:  "![shot](https://example.com/s.png)",
+			want: "<p><img src=\"https://example.com/s.png\" alt=\"shot\" loading=\"lazy\"></p>\n",
+		},
+		{
+			name: "details block keeps markdown inside",
+			src:  "<details><summary>Logs</summary>\n\n**panic**\n\n</details>",
+			want: "<details><summary>Logs</summary>\n<p><strong>panic</strong></p>\n</details>\n",
+		},
+		{
+			name: "intraword underscores and entities",
+			src:  "snake_case_name & &copy; <!-- hidden -->",
+			want: "<p>snake_case_name &amp; &copy; </p>\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := markdownToHTML(tc.src); got != tc.want {
+				t.Fatalf("markdownToHTML(%q)\ngot:  %q\nwant: %q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownToHTMLSanitizes(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		src     string
+		want    string
+		notWant []string
+	}{
+		{
+			name:    "script block",
+			src:     "<script>alert(1)</script>",
+			want:    "&lt;script&gt;alert(1)&lt;/script&gt;",
+			notWant: []string{"<script"},
+		},
+		{
+			name:    "event handler attribute",
+			src:     "<img src=\"https://example.com/a.png\" onerror=\"alert(1)\">",
+			want:    "<img src=\"https://example.com/a.png\">",
+			notWant: []string{"onerror"},
+		},
+		{
+			name:    "javascript link",
+			src:     "[click](javascript:alert(1))",
+			want:    "<p>click</p>",
+			notWant: []string{"href"},
+		},
+		{
+			name:    "entity encoded scheme",
+			src:     "<a href=\"jav&#x61;script:alert(1)\">x</a>",
+			want:    "<a rel=\"nofollow noopener noreferrer\">x</a>",
+			notWant: []string{"href", "script:"},
+		},
+		{
+			name:    "data image",
+			src:     "![x](data:text/html;base64,PHNjcmlwdD4=)",
+			want:    "<p>x</p>",
+			notWant: []string{"<img"},
+		},
+		{
+			name:    "style and iframe",
+			src:     "text <iframe src=\"https://evil.example\"></iframe> <span style=\"position:fixed\">s</span>",
+			want:    "&lt;iframe src=&#34;https://evil.example&#34;&gt;&lt;/iframe&gt; <span>s</span>",
+			notWant: []string{"<iframe", "style="},
+		},
+		{
+			name: "unclosed tags are closed",
+			src:  "<div><b>open",
+			want: "<div><b>open\n</b></div>",
+		},
+		{
+			name:    "stray closing tags are dropped",
+			src:     "text</p></div>",
+			want:    "<p>text</p>\n",
+			notWant: []string{"</div>"},
+		},
+		{
+			name:    "attribute quoting",
+			src:     "<a href=\"https://example.com/?q=&quot;x&quot;\" title='a\"b'>q</a>",
+			want:    "<a href=\"https://example.com/?q=&#34;x&#34;\" title=\"a&#34;b\" rel=\"nofollow noopener noreferrer\">q</a>",
+			notWant: []string{"title='"},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := markdownToHTML(tc.src)
+			if !strings.Contains(got, tc.want) {
+				t.Fatalf("markdownToHTML(%q) = %q, want it to contain %q", tc.src, got, tc.want)
+			}
+			for _, piece := range tc.notWant {
+				if strings.Contains(got, piece) {
+					t.Fatalf("markdownToHTML(%q) = %q, must not contain %q", tc.src, got, piece)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package converter
+
+import (
+	"html"
+	"strings"
+)
+
+// htmlTag is one parsed raw HTML tag. Attribute values are unescaped.
+type htmlTag struct {
+	name        string
+	attrs       []htmlAttr
+	closing     bool
+	selfClosing bool
+}
+
+type htmlAttr struct {
+	name  string
+	value string
+}
+
+// parseHTMLTag parses the open or closing tag starting at src[i] == '<' and returns the index
+// just past it.
+func parseHTMLTag(src string, i int) (htmlTag, int, bool) {
+	var tag htmlTag
+	k := i + 1
+	if k < len(src) && src[k] == '/' {
+		tag.closing = true
+		k++
+	}
+	start := k
+	for k < len(src) && (isASCIILetter(src[k]) || (k > start && (isASCIIDigit(src[k]) || src[k] == '-'))) {
+		k++
+	}
+	if k == start {
+		return htmlTag{}, i, false
+	}
+	tag.name = strings.ToLower(src[start:k])
+	for {
+		spaced := k < len(src) && isHTMLSpace(src[k])
+		k = skipHTMLSpace(src, k)
+		switch {
+		case k >= len(src):
+			return htmlTag{}, i, false
+		case src[k] == '>':
+			return tag, k + 1, true
+		case src[k] == '/' && k+1 < len(src) && src[k+1] == '>' && !tag.closing:
+			tag.selfClosing = true
+			return tag, k + 2, true
+		case !spaced || tag.closing:
+			return htmlTag{}, i, false
+		}
+		attr, next, ok := parseHTMLAttr(src, k)
+		if !ok {
+			return htmlTag{}, i, false
+		}
+		tag.attrs = append(tag.attrs, attr)
+		k = next
+	}
+}
+
+func parseHTMLAttr(src string, k int) (htmlAttr, int, bool) {
+	start := k
+	for k < len(src) && (isASCIILetter(src[k]) || isASCIIDigit(src[k]) || strings.IndexByte("_:.-", src[k]) >= 0) {
+		k++
+	}
+	if k == start {
+		return htmlAttr{}, k, false
+	}
+	attr := htmlAttr{name: strings.ToLower(src[start:k])}
+	next := skipHTMLSpace(src, k)
+	if next >= len(src) || src[next] != '=' {
+		return attr, k, true
+	}
+	k = skipHTMLSpace(src, next+1)
+	if k >= len(src) {
+		return htmlAttr{}, k, false
+	}
+	switch quote := src[k]; quote {
+	case '"', '\'':
+		end := strings.IndexByte(src[k+1:], quote)
+		if end < 0 {
+			return htmlAttr{}, k, false
+		}
+		attr.value = html.UnescapeString(src[k+1 : k+1+end])
+		return attr, k + end + 2, true
+	default:
+		start = k
+		for k < len(src) && !isHTMLSpace(src[k]) && strings.IndexByte("\"'=<>`", src[k]) < 0 {
+			k++
+		}
+		if k == start {
+			return htmlAttr{}, k, false
+		}
+		attr.value = html.UnescapeString(src[start:k])
+		return attr, k, true
+	}
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func skipHTMLSpace(src string, k int) int {
+	for k < len(src) && isHTMLSpace(src[k]) {
+		k++
+	}
+	return k
+}
+
+// isBlockHTMLTag reports whether a raw tag starts an HTML block even inside a paragraph.
+func isBlockHTMLTag(name string) bool {
+	switch name {
+	case "address", "article", "aside", "blockquote", "details", "dialog", "dd", "div", "dl", "dt",
+		"fieldset", "figcaption", "figure", "footer", "form", "h1", "h2", "h3", "h4", "h5", "h6",
+		"header", "hr", "iframe", "li", "main", "nav", "ol", "p", "picture", "pre", "script", "section",
+		"style", "summary", "table", "tbody", "td", "textarea", "tfoot", "th", "thead", "tr", "ul":
+		return true
+	default:
+		return false
+	}
+}
+
+// allowedHTMLTag is the allowlist of raw HTML elements kept from bodies, mirroring the subset
+// GitHub itself renders. Anything else, including script, style, iframe, form and svg, is shown
+// as escaped text.
+func allowedHTMLTag(name string) bool {
+	switch name {
+	case "a", "abbr", "b", "blockquote", "br", "code", "dd", "del", "details", "div", "dl", "dt", "em",
+		"h1", "h2", "h3", "h4", "h5", "h6", "hr", "i", "img", "ins", "kbd", "li", "mark", "ol", "p",
+		"picture", "pre", "q", "s", "samp", "source", "span", "strike", "strong", "sub", "summary", "sup",
+		"table", "tbody", "td", "tfoot", "th", "thead", "tr", "tt", "u", "ul", "var":
+		return true
+	default:
+		return false
+	}
+}
+
+// allowedHTMLAttr is the per-element attribute allowlist; event handlers and style never pass.
+func allowedHTMLAttr(tag, attr string) bool {
+	switch attr {
+	case "title", "align", "lang", "dir":
+		return true
+	}
+	switch tag {
+	case "a":
+		return attr == "href"
+	case "img":
+		return attr == "src" || attr == "alt" || attr == "width" || attr == "height"
+	case "source":
+		return attr == "srcset" || attr == "media" || attr == "type"
+	case "td", "th":
+		return attr == "colspan" || attr == "rowspan"
+	case "ol":
+		return attr == "start"
+	case "details":
+		return attr == "open"
+	default:
+		return false
+	}
+}
+
+func isVoidHTMLTag(name string) bool {
+	return name == "br" || name == "hr" || name == "img" || name == "source"
+}
+
+// safeURL accepts http, https and mailto URLs and relative references. Entity-encoded and
+// whitespace-obfuscated schemes such as "jav&#x61;script:" are decoded before the check.
+func safeURL(raw string) bool {
+	value := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(raw))
+	end := strings.IndexAny(value, ":/?#")
+	if end < 0 || value[end] != ':' {
+		return true
+	}
+	switch strings.ToLower(value[:end]) {
+	case "http", "https", "mailto":
+		return true
+	default:
+		return false
+	}
+}
+
+// safeSrcset checks every candidate URL of a srcset attribute.
+func safeSrcset(value string) bool {
+	for _, candidate := range strings.Split(value, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) > 0 && !safeURL(fields[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeSanitizedTag writes an allowlisted tag rebuilt from its parsed form, or the raw source
+// escaped as text. Closing tags only close elements opened since base; unmatched ones are dropped.
+func (c *mdConverter) writeSanitizedTag(b *strings.Builder, tag htmlTag, source string, base int) {
+	if !allowedHTMLTag(tag.name) {
+		b.WriteString(html.EscapeString(source))
+		return
+	}
+	if tag.closing {
+		for k := len(c.open) - 1; k >= base; k-- {
+			if c.open[k] == tag.name {
+				c.closeOpen(b, k)
+				return
+			}
+		}
+		return
+	}
+
+	b.WriteString("<" + tag.name)
+	for _, attr := range tag.attrs {
+		if !allowedHTMLAttr(tag.name, attr.name) {
+			continue
+		}
+		switch {
+		case (attr.name == "href" || attr.name == "src") && !safeURL(attr.value):
+			continue
+		case attr.name == "srcset" && !safeSrcset(attr.value):
+			continue
+		}
+		b.WriteString(" " + attr.name + `="` + html.EscapeString(attr.value) + `"`)
+	}
+	if tag.name == "a" {
+		b.WriteString(` rel="nofollow noopener noreferrer"`)
+	}
+	b.WriteString(">")
+	if !isVoidHTMLTag(tag.name) {
+		c.open = append(c.open, tag.name)
+	}
+}
+
+// closeOpen closes the raw elements opened at or after index from, innermost first.
+func (c *mdConverter) closeOpen(b *strings.Builder, from int) {
+	for k := len(c.open) - 1; k >= from; k-- {
+		b.WriteString("</" + c.open[k] + ">")
+	}
+	c.open = c.open[:from]
+}
+
+// rawHTML writes an HTML fragment from a body: allowlisted tags are rebuilt, comments dropped,
+// entities kept and every other character that could start markup escaped.
+func (c *mdConverter) rawHTML(b *strings.Builder, src string, base int) {
+	for i := 0; i < len(src); {
+		switch src[i] {
+		case '<':
+			if strings.HasPrefix(src[i:], "<!--") {
+				end := strings.Index(src[i+4:], "-->")
+				if end < 0 {
+					return
+				}
+				i += 4 + end + 3
+				continue
+			}
+			if tag, end, ok := parseHTMLTag(src, i); ok {
+				c.writeSanitizedTag(b, tag, src[i:end], base)
+				i = end
+				continue
+			}
+			b.WriteString("&lt;")
+		case '&':
+			b.WriteString(entityOrAmp(src[i:]))
+			if n := entityLength(src[i:]); n > 0 {
+				i += n
+				continue
+			}
+		case '>':
+			b.WriteString("&gt;")
+		case '"':
+			b.WriteString("&#34;")
+		default:
+			b.WriteByte(src[i])
+		}
+		i++
+	}
+}
+
+// entityLength returns the length of the character reference at the start of s, or 0.
+func entityLength(s string) int {
+	k := 1
+	switch {
+	case strings.HasPrefix(s, "&#x") || strings.HasPrefix(s, "&#X"):
+		k = 3
+		for k < len(s) && k < 9 && strings.IndexByte("0123456789abcdefABCDEF", s[k]) >= 0 {
+			k++
+		}
+		if k == 3 {
+			return 0
+		}
+	case strings.HasPrefix(s, "&#"):
+		k = 2
+		for k < len(s) && k < 9 && isASCIIDigit(s[k]) {
+			k++
+		}
+		if k == 2 {
+			return 0
+		}
+	default:
+		for k < len(s) && k < 33 && (isASCIILetter(s[k]) || (k > 1 && isASCIIDigit(s[k]))) {
+			k++
+		}
+		if k == 1 {
+			return 0
+		}
+	}
+	if k < len(s) && s[k] == ';' {
+		return k + 1
+	}
+	return 0
+}
+
+func entityOrAmp(s string) string {
+	if n := entityLength(s); n > 0 {
+		return s[:n]
+	}
+	return "&amp;"
+}
@@ -0,0 +1,133 @@
+package converter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func TestRenderHTMLDocument(t *testing.T) {
+	t.Parallel()
+
+	minimized := sampleIssueData()
+	minimized.Thread[1].IsMinimized = true
+	minimized.Thread[1].MinimizedReason = "spam"
+	minimized.Thread[0].AuthorAssociation = "OWNER"
+	minimized.Thread[0].Body = "<script>steal()</script> see <a href=\"javascript:x\" onclick=\"y\">this</a>"
+
+	resolved := samplePRData()
+	resolved.Reviews[1].Threads[0].IsResolved = true
+
+	full := RenderOptions{IncludeComments: true, IncludeSummary: true, IncludeFiles: true, IncludePatches: true, IncludeCommits: true, IncludeChecks: true, IncludeReactions: true}
+
+	tcs := []struct {
+		name    string
+		data    gh.IssueData
+		opts    RenderOptions
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "issue page",
+			data: minimized,
+			opts: full,
+			want: []string{
+				"<!DOCTYPE html>\n",
+				"<title>Issue: Panic on nil config</title>",
+				"<style>\n",
+				"<span class=\"state state-open\">open</span> issue #123 opened by <span class=\"author\">alice</span>",
+				"<tr><th>labels</th><td>bug, help wanted</td></tr>",
+				"<tr><th>url</th><td><a href=\"https://github.com/octo/repo/issues/123\">",
+				"<h2>AI Summary</h2>",
+				"<img src=\"https://example.com/a.png\" alt=\"image\" loading=\"lazy\">",
+				"<p class=\"reactions\">Reactions: 👍 3 ❤️ 1</p>",
+				"<article class=\"comment\" id=\"issuecomment-1\">",
+				"<span class=\"badge\">owner</span> commented <a href=\"https://github.com/octo/repo/issues/123#issuecomment-1\">2026-01-01T12:00:00Z</a>",
+				"&lt;script&gt;steal()&lt;/script&gt;",
+				"<div class=\"replies\">\n<article class=\"comment\" id=\"comment-c1-r1\">",
+				"minimized as spam",
+				"<details class=\"minimized\">\n<summary>Show minimized comment</summary>",
+			},
+			notWant: []string{"<script", "onclick", "javascript:"},
+		},
+		{
+			name:    "minimized comments skipped",
+			data:    minimized,
+			opts:    RenderOptions{IncludeComments: true, Minimized: MinimizedSkip},
+			want:    []string{"1 minimized comment(s) hidden (--minimized-comments=skip)."},
+			notWant: []string{"Fixed in #124?"},
+		},
+		{
+			name: "pull request threads",
+			data: resolved,
+			opts: full,
+			want: []string{
+				"<span class=\"state state-merged\">merged</span>",
+				"<details class=\"review-thread\">\n<summary><code>internal/config/loader.go</code> (lines 11-13, RIGHT) · resolved by alice</summary>",
+				"<details class=\"review-thread\">\n<summary><code>README.md</code>",
+				"<span class=\"add\">+	if cfg == nil {</span>\n",
+				"<details class=\"patch\">\n<summary><code>internal/config/loader.go</code></summary>",
+				"<h2>Checks</h2>",
+			},
+			notWant: []string{"<details class=\"review-thread\" open>"},
+		},
+		{
+			name:    "resolved threads hidden",
+			data:    resolved,
+			opts:    RenderOptions{IncludeComments: true, HideResolvedThreads: true},
+			want:    []string{"2 resolved thread(s) hidden (--hide-resolved-threads)."},
+			notWant: []string{"class=\"review-thread\""},
+		},
+		{
+			name: "discussion anchors the answer once",
+			data: sampleDiscussionData(),
+			opts: RenderOptions{IncludeComments: true},
+			want: []string{
+				"<h3>Accepted Answer</h3>\n<article class=\"comment\">",
+				"<h3>Replies</h3>",
+				"<article class=\"comment\" id=\"comment-d2\">",
+			},
+		},
+		{
+			name:    "comments omitted",
+			data:    sampleIssueData(),
+			opts:    RenderOptions{},
+			want:    []string{"<p class=\"note\">Comments omitted (--include-comments=false).</p>"},
+			notWant: []string{"<article"},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			opts := tc.opts
+			opts.Format = FormatHTML
+			out, err := NewRenderer(&stubSummarizer{summary: fixedSummary()}).Render(context.Background(), tc.data, opts)
+			if err != nil {
+				t.Fatalf("Render error = %v, want nil", err)
+			}
+			for _, piece := range tc.want {
+				if !strings.Contains(string(out), piece) {
+					t.Fatalf("output missing %q\n%s", piece, out)
+				}
+			}
+			for _, piece := range tc.notWant {
+				if strings.Contains(string(out), piece) {
+					t.Fatalf("output must not contain %q\n%s", piece, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderHTMLRejectsFocusedComment(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRenderer(nil).Render(context.Background(), sampleIssueData(), RenderOptions{Format: FormatHTML, FocusComment: "issuecomment-1"})
+	if err == nil || !strings.Contains(err.Error(), "render html") {
+		t.Fatalf("Render error = %v, want render html error", err)
+	}
+}
@@ -0,0 +1,412 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// DefaultMaxChunkTokens caps the approximate size of one --format jsonl record.
+const DefaultMaxChunkTokens = 512
+
+// charsPerToken is the rough rune-to-token ratio behind Chunk.Tokens. It matches common BPE
+// tokenizers on English prose; code and CJK text count lower than they tokenize.
+const charsPerToken = 4
+
+// Chunk kinds identify what part of a resource a record holds.
+const (
+	ChunkDescription  = "description"
+	ChunkSummary      = "summary"
+	ChunkComment      = "comment"
+	ChunkReview       = "review"
+	ChunkReviewThread = "review_thread"
+)
+
+// Chunk is one --format jsonl record: a description, summary, comment, review or review thread,
+// or one part of it when its text exceeds the chunk size.
+//
+// ID is derived from the resource URL and the element's GitHub anchor, so it is stable across
+// exports; split elements append "#p<Part>". ParentID names the element the chunk replies to:
+// the description for top-level comments, reviews and the summary, the parent comment for
+// replies and the review for review threads. It is always the ID of a record in the same
+// export: the first part ("#p1") when the parent was split. Tokens is an approximation of
+// Text's size.
+type Chunk struct {
+	ID        string        `json:"id"`
+	ParentID  string        `json:"parent_id,omitempty"`
+	Kind      string        `json:"kind"`
+	Author    string        `json:"author,omitempty"`
+	CreatedAt string        `json:"created_at,omitempty"`
+	UpdatedAt string        `json:"updated_at,omitempty"`
+	URL       string        `json:"url"`
+	Anchor    string        `json:"anchor,omitempty"`
+	Minimized string        `json:"minimized,omitempty"`
+	Text      string        `json:"text"`
+	Resource  ChunkResource `json:"resource"`
+	Part      int           `json:"part"`
+	Parts     int           `json:"parts"`
+	Tokens    int           `json:"tokens"`
+}
+
+// ChunkResource repeats the resource metadata on every record so each one is self-contained.
+type ChunkResource struct {
+	Type   gh.ResourceType `json:"type"`
+	Repo   string          `json:"repo,omitempty"`
+	Title  string          `json:"title"`
+	State  string          `json:"state,omitempty"`
+	Author string          `json:"author,omitempty"`
+	URL    string          `json:"url"`
+	Labels []string        `json:"labels,omitempty"`
+	Number int             `json:"number"`
+}
+
+// chunkElement is one logical element before it is split into records.
+type chunkElement struct {
+	key       string
+	parentKey string
+	kind      string
+	author    string
+	createdAt string
+	updatedAt string
+	url       string
+	anchor    string
+	minimized string
+	text      string
+}
+
+// renderChunks renders data as JSON Lines, one Chunk per line in document order.
+func renderChunks(data gh.IssueData, summary Summary, opts RenderOptions) ([]byte, error) {
+	maxTokens := opts.MaxChunkTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxChunkTokens
+	}
+	resource := chunkResource(data)
+	base := chunkBaseID(data.Meta)
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	// firstIDs maps element keys to their first record; parents precede their children.
+	firstIDs := map[string]string{}
+	for _, element := range collectChunkElements(data, summary, opts) {
+		parts := splitChunkText(element.text, maxTokens)
+		for idx, text := range parts {
+			chunk := Chunk{
+				ID:        base + "#" + element.key,
+				Kind:      element.kind,
+				Author:    element.author,
+				CreatedAt: element.createdAt,
+				UpdatedAt: element.updatedAt,
+				URL:       element.url,
+				Anchor:    element.anchor,
+				Minimized: element.minimized,
+				Text:      text,
+				Resource:  resource,
+				Part:      idx + 1,
+				Parts:     len(parts),
+				Tokens:    approximateTokens(text),
+			}
+			if len(parts) > 1 {
+				chunk.ID += fmt.Sprintf("#p%d", idx+1)
+			}
+			if idx == 0 {
+				firstIDs[element.key] = chunk.ID
+			}
+			if element.parentKey != "" {
+				chunk.ParentID = firstIDs[element.parentKey]
+			}
+			if err := encoder.Encode(chunk); err != nil {
+				return nil, fmt.Errorf("render jsonl: %w", err)
+			}
+		}
+	}
+	return out.Bytes(), nil
+}
+
+func chunkResource(data gh.IssueData) ChunkResource {
+	labels := make([]string, 0, len(data.Meta.Labels))
+	for _, label := range data.Meta.Labels {
+		labels = append(labels, label.Name)
+	}
+	repo := ""
+	if data.Resolved.Owner != "" {
+		repo = data.Resolved.Owner + "/" + data.Resolved.Repo
+	}
+	return ChunkResource{
+		Type:   data.Meta.Type,
+		Repo:   repo,
+		Title:  data.Meta.Title,
+		State:  data.Meta.State,
+		Author: data.Meta.Author,
+		URL:    data.Meta.URL,
+		Labels: labels,
+		Number: data.Meta.Number,
+	}
+}
+
+// chunkBaseID is the resource URL without its scheme, such as "github.com/octo/repo/issues/1".
+func chunkBaseID(meta gh.Metadata) string {
+	if meta.URL == "" {
+		return fmt.Sprintf("%s/%d", meta.Type, meta.Number)
+	}
+	_, rest, ok := strings.Cut(meta.URL, "://")
+	if !ok {
+		rest = meta.URL
+	}
+	return strings.TrimSuffix(rest, "/")
+}
+
+func collectChunkElements(data gh.IssueData, summary Summary, opts RenderOptions) []chunkElement {
+	elements := []chunkElement{{
+		key:       ChunkDescription,
+		kind:      ChunkDescription,
+		author:    data.Meta.Author,
+		createdAt: data.Meta.CreatedAt,
+		updatedAt: data.Meta.UpdatedAt,
+		url:       data.Meta.URL,
+		text:      "# " + data.Meta.Title + "\n\n" + strings.TrimSpace(data.Description),
+	}}
+	if summary.Summary != "" {
+		elements = append(elements, chunkElement{
+			key:       ChunkSummary,
+			parentKey: ChunkDescription,
+			kind:      ChunkSummary,
+			url:       data.Meta.URL,
+			text:      strings.TrimSpace(renderSummarySection(summary)),
+		})
+	}
+	if !opts.IncludeComments {
+		return elements
+	}
+	for idx, review := range data.Reviews {
+		elements = append(elements, reviewChunkElements(data.Meta, idx, review, opts)...)
+	}
+	return appendCommentElements(elements, data.Meta.URL, data.Thread, ChunkDescription, opts)
+}
+
+// appendCommentElements adds comments and their replies in thread order. Minimized comments
+// follow --minimized-comments: skipped ones drop their replies too.
+func appendCommentElements(elements []chunkElement, resourceURL string, comments []gh.CommentNode, parentKey string, opts RenderOptions) []chunkElement {
+	for idx, comment := range comments {
+		if comment.IsMinimized && opts.Minimized == MinimizedSkip {
+			continue
+		}
+		element := commentChunkElement(resourceURL, comment, parentKey)
+		if element.key == "" {
+			// Comments without URL or ID are keyed by position under their parent.
+			element.key = fmt.Sprintf("%s-comment-%d", parentKey, idx+1)
+		}
+		elements = append(elements, element)
+		elements = appendCommentElements(elements, resourceURL, comment.Replies, element.key, opts)
+	}
+	return elements
+}
+
+func commentChunkElement(resourceURL string, comment gh.CommentNode, parentKey string) chunkElement {
+	url := comment.URL
+	if url == "" {
+		url = resourceURL
+	}
+	return chunkElement{
+		key:       commentElementID(comment),
+		parentKey: parentKey,
+		kind:      ChunkComment,
+		author:    comment.Author,
+		createdAt: comment.CreatedAt,
+		updatedAt: comment.UpdatedAt,
+		url:       url,
+		anchor:    urlFragment(comment.URL),
+		minimized: minimizedAnnotation(comment),
+		text:      strings.TrimSpace(comment.Body),
+	}
+}
+
+// reviewChunkElements returns the review with its state and body, then one element per review
+// thread holding the diff hunk and every comment. Reviews without threads list their comments
+// individually.
+func reviewChunkElements(meta gh.Metadata, idx int, review gh.ReviewData, opts RenderOptions) []chunkElement {
+	anchor, url := "", meta.URL
+	key := fmt.Sprintf("review-%d", idx+1)
+	if review.ID != "" {
+		anchor = anchorReview + review.ID
+		key, url = anchor, meta.URL+"#"+anchor
+	}
+	reviewElement := chunkElement{
+		key:       key,
+		parentKey: ChunkDescription,
+		kind:      ChunkReview,
+		author:    review.Author,
+		createdAt: review.CreatedAt,
+		url:       url,
+		anchor:    anchor,
+		text:      strings.TrimSpace(fmt.Sprintf("Review (%s)\n\n%s", strings.ToLower(review.State), review.Body)),
+	}
+	elements := []chunkElement{reviewElement}
+	if len(review.Threads) == 0 {
+		return appendCommentElements(elements, meta.URL, review.Comments, reviewElement.key, opts)
+	}
+	for idx, thread := range review.Threads {
+		if opts.HideResolvedThreads && thread.IsResolved {
+			continue
+		}
+		if element, ok := threadChunkElement(meta, reviewElement.key, idx, thread, opts); ok {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+func threadChunkElement(meta gh.Metadata, reviewKey string, idx int, thread gh.ReviewThread, opts RenderOptions) (chunkElement, bool) {
+	var comments []gh.CommentNode
+	for _, comment := range thread.Comments {
+		if !comment.IsMinimized || opts.Minimized != MinimizedSkip {
+			comments = append(comments, comment)
+		}
+	}
+	if len(comments) == 0 {
+		return chunkElement{}, false
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s, %s)\n", thread.Path, describeThreadAnchor(thread), describeThreadStatus(thread))
+	if thread.DiffHunk != "" {
+		fence := codeFence(thread.DiffHunk)
+		fmt.Fprintf(&b, "\n%sdiff\n%s\n%s\n", fence, strings.TrimRight(thread.DiffHunk, "\n"), fence)
+	}
+	for _, comment := range comments {
+		fmt.Fprintf(&b, "\n%s: %s\n", comment.Author, strings.TrimSpace(comment.Body))
+	}
+
+	first, last := comments[0], comments[len(comments)-1]
+	key := "thread-" + thread.ID
+	if thread.ID == "" {
+		key = fmt.Sprintf("%s-thread-%d", reviewKey, idx+1)
+	}
+	url := first.URL
+	if url == "" {
+		url = meta.URL
+	}
+	return chunkElement{
+		key:       key,
+		parentKey: reviewKey,
+		kind:      ChunkReviewThread,
+		author:    first.Author,
+		createdAt: first.CreatedAt,
+		updatedAt: last.CreatedAt,
+		url:       url,
+		anchor:    urlFragment(first.URL),
+		text:      strings.TrimRight(b.String(), "\n"),
+	}, true
+}
+
+// urlFragment returns the part of url after "#", such as "issuecomment-123", or "".
+func urlFragment(url string) string {
+	_, fragment, _ := strings.Cut(url, "#")
+	return fragment
+}
+
+// approximateTokens estimates the token count of text from its rune count.
+func approximateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+}
+
+// splitChunkText splits text into parts of at most maxTokens, breaking on paragraph boundaries
+// and keeping fenced code blocks whole where they fit. Oversized paragraphs fall back to line
+// breaks, and oversized lines to a hard cut.
+func splitChunkText(text string, maxTokens int) []string {
+	if approximateTokens(text) <= maxTokens {
+		return []string{text}
+	}
+	var parts []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+	}
+	for _, unit := range chunkUnits(text, maxTokens) {
+		joined := unit
+		if current.Len() > 0 {
+			joined = current.String() + "\n\n" + unit
+		}
+		if approximateTokens(joined) > maxTokens {
+			flush()
+			joined = unit
+		}
+		current.Reset()
+		current.WriteString(joined)
+	}
+	flush()
+	return parts
+}
+
+// chunkUnits splits text into paragraphs that each fit maxTokens. Blank lines inside a code
+// fence do not end a paragraph.
+func chunkUnits(text string, maxTokens int) []string {
+	var units, paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			units = append(units, fitChunkUnit(paragraph, maxTokens)...)
+			paragraph = nil
+		}
+	}
+	inFence := false
+	for _, line := range strings.Split(text, "\n") {
+		if _, ok := parseFence(line); ok {
+			inFence = !inFence
+		}
+		if isBlankLine(line) && !inFence {
+			flush()
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+	flush()
+	return units
+}
+
+// fitChunkUnit returns one paragraph as-is when it fits, otherwise its lines grouped to fit.
+func fitChunkUnit(lines []string, maxTokens int) []string {
+	paragraph := strings.Join(lines, "\n")
+	if approximateTokens(paragraph) <= maxTokens {
+		return []string{paragraph}
+	}
+	var units []string
+	current := ""
+	for _, line := range lines {
+		for _, piece := range hardSplit(line, maxTokens*charsPerToken) {
+			switch {
+			case current == "":
+				current = piece
+			case approximateTokens(current+"\n"+piece) <= maxTokens:
+				current += "\n" + piece
+			default:
+				units = append(units, current)
+				current = piece
+			}
+		}
+	}
+	if current != "" {
+		units = append(units, current)
+	}
+	return units
+}
+
+// hardSplit cuts line into pieces of at most limit runes.
+func hardSplit(line string, limit int) []string {
+	runes := []rune(line)
+	if len(runes) <= limit {
+		return []string{line}
+	}
+	pieces := make([]string, 0, len(runes)/limit+1)
+	for len(runes) > limit {
+		pieces = append(pieces, string(runes[:limit]))
+		runes = runes[limit:]
+	}
+	return append(pieces, string(runes))
+}
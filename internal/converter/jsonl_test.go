@@ -0,0 +1,229 @@
+package converter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func decodeChunks(t *testing.T, raw []byte) []Chunk {
+	t.Helper()
+
+	var chunks []Chunk
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var chunk Chunk
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			t.Fatalf("decode line %q: %v", scanner.Text(), err)
+		}
+		chunks = append(chunks, chunk)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scan jsonl: %v", err)
+	}
+	return chunks
+}
+
+func TestRenderChunks(t *testing.T) {
+	t.Parallel()
+
+	issue := sampleIssueData()
+	issue.Resolved = gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 123, Type: gh.ResourceIssue}
+	issue.Thread[1].IsMinimized = true
+	issue.Thread[1].MinimizedReason = "outdated"
+
+	tcs := []struct {
+		name       string
+		data       gh.IssueData
+		opts       RenderOptions
+		wantIDs    []string
+		wantParent map[string]string
+	}{
+		{
+			name: "issue thread",
+			data: issue,
+			opts: RenderOptions{IncludeComments: true, IncludeSummary: true},
+			wantIDs: []string{
+				"github.com/octo/repo/issues/123#description",
+				"github.com/octo/repo/issues/123#summary",
+				"github.com/octo/repo/issues/123#issuecomment-1",
+				"github.com/octo/repo/issues/123#comment-c1-r1",
+				"github.com/octo/repo/issues/123#comment-c2",
+			},
+			wantParent: map[string]string{
+				"github.com/octo/repo/issues/123#issuecomment-1": "github.com/octo/repo/issues/123#description",
+				"github.com/octo/repo/issues/123#comment-c1-r1":  "github.com/octo/repo/issues/123#issuecomment-1",
+			},
+		},
+		{
+			name: "minimized skipped without comments summary",
+			data: issue,
+			opts: RenderOptions{IncludeComments: true, Minimized: MinimizedSkip},
+			wantIDs: []string{
+				"github.com/octo/repo/issues/123#description",
+				"github.com/octo/repo/issues/123#issuecomment-1",
+				"github.com/octo/repo/issues/123#comment-c1-r1",
+			},
+		},
+		{
+			name: "pull request reviews",
+			data: samplePRData(),
+			opts: RenderOptions{IncludeComments: true, HideResolvedThreads: true},
+			wantIDs: []string{
+				"github.com/octo/repo/pull/124#description",
+				"github.com/octo/repo/pull/124#pullrequestreview-r1",
+				"github.com/octo/repo/pull/124#pullrequestreview-r2",
+				"github.com/octo/repo/pull/124#thread-t2",
+				"github.com/octo/repo/pull/124#comment-pr-thread-1",
+			},
+			wantParent: map[string]string{
+				"github.com/octo/repo/pull/124#thread-t2": "github.com/octo/repo/pull/124#pullrequestreview-r2",
+			},
+		},
+		{
+			name:    "comments omitted",
+			data:    sampleDiscussionData(),
+			opts:    RenderOptions{},
+			wantIDs: []string{"github.com/octo/repo/discussions/88#description"},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			opts := tc.opts
+			opts.Format = FormatJSONL
+			raw, err := NewRenderer(&stubSummarizer{summary: fixedSummary()}).Render(context.Background(), tc.data, opts)
+			if err != nil {
+				t.Fatalf("Render error = %v, want nil", err)
+			}
+			chunks := decodeChunks(t, raw)
+			ids := make([]string, 0, len(chunks))
+			for _, chunk := range chunks {
+				ids = append(ids, chunk.ID)
+				if chunk.Resource.Number != tc.data.Meta.Number || chunk.Resource.URL != tc.data.Meta.URL {
+					t.Fatalf("chunk %s resource = %+v, want resource metadata", chunk.ID, chunk.Resource)
+				}
+				if chunk.Tokens != approximateTokens(chunk.Text) || chunk.Part != 1 || chunk.Parts != 1 {
+					t.Fatalf("chunk %s = tokens %d part %d/%d, want unsplit with token count", chunk.ID, chunk.Tokens, chunk.Part, chunk.Parts)
+				}
+				if want, ok := tc.wantParent[chunk.ID]; ok && chunk.ParentID != want {
+					t.Fatalf("chunk %s parent = %q, want %q", chunk.ID, chunk.ParentID, want)
+				}
+			}
+			if !reflect.DeepEqual(ids, tc.wantIDs) {
+				t.Fatalf("chunk ids = %q, want %q", ids, tc.wantIDs)
+			}
+		})
+	}
+}
+
+func TestRenderChunksMetadata(t *testing.T) {
+	t.Parallel()
+
+	data := sampleIssueData()
+	data.Resolved = gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 123, Type: gh.ResourceIssue}
+	data.Thread[0].AuthorAssociation = "MEMBER"
+	data.Thread[1].IsMinimized = true
+	data.Thread[1].MinimizedReason = "off-topic"
+
+	raw, err := NewRenderer(nil).Render(context.Background(), data, RenderOptions{Format: FormatJSONL, IncludeComments: true})
+	if err != nil {
+		t.Fatalf("Render error = %v, want nil", err)
+	}
+	chunks := decodeChunks(t, raw)
+	if len(chunks) != 4 {
+		t.Fatalf("chunks = %d, want 4", len(chunks))
+	}
+
+	description := chunks[0]
+	if description.Kind != ChunkDescription || description.ParentID != "" || description.Author != "alice" ||
+		!strings.HasPrefix(description.Text, "# Issue: Panic on nil config\n\n") {
+		t.Fatalf("description chunk = %+v", description)
+	}
+	wantResource := ChunkResource{
+		Type: gh.ResourceIssue, Repo: "octo/repo", Title: data.Meta.Title, State: "open", Author: "alice",
+		URL: data.Meta.URL, Labels: []string{"bug", "help wanted"}, Number: 123,
+	}
+	if !reflect.DeepEqual(description.Resource, wantResource) {
+		t.Fatalf("resource = %+v, want %+v", description.Resource, wantResource)
+	}
+
+	comment := chunks[1]
+	if comment.Kind != ChunkComment || comment.Anchor != "issuecomment-1" || comment.URL != data.Thread[0].URL ||
+		comment.CreatedAt != "2026-01-01T12:00:00Z" || comment.Text != "I can reproduce this." {
+		t.Fatalf("comment chunk = %+v", comment)
+	}
+	if reply := chunks[2]; reply.Anchor != "" || reply.URL != data.Meta.URL {
+		t.Fatalf("reply without permalink = %+v, want resource URL and no anchor", reply)
+	}
+	if minimized := chunks[3]; minimized.Minimized != "minimized as off-topic" {
+		t.Fatalf("minimized chunk = %+v, want minimized note", minimized)
+	}
+}
+
+func TestRenderChunksSplitsLongBodies(t *testing.T) {
+	t.Parallel()
+
+	data := sampleIssueData()
+	data.Description = strings.Repeat("a", 60) + "\n\n" + strings.Repeat("b", 60) + "\n\n" + strings.Repeat("c", 8)
+
+	raw, err := NewRenderer(nil).Render(context.Background(), data, RenderOptions{Format: FormatJSONL, MaxChunkTokens: 20, IncludeComments: true})
+	if err != nil {
+		t.Fatalf("Render error = %v, want nil", err)
+	}
+	chunks := decodeChunks(t, raw)
+	if len(chunks) != 6 {
+		t.Fatalf("chunks = %d, want 3 description parts and 3 comments: %+v", len(chunks), chunks)
+	}
+	if comment := chunks[3]; comment.ParentID != chunks[0].ID {
+		t.Fatalf("comment parent = %q, want first description part %q", comment.ParentID, chunks[0].ID)
+	}
+	for idx, chunk := range chunks[:3] {
+		wantID := "github.com/octo/repo/issues/123#description#p" + string(rune('1'+idx))
+		if chunk.ID != wantID || chunk.Part != idx+1 || chunk.Parts != 3 || chunk.Tokens > 20 {
+			t.Fatalf("chunk %d = %+v, want %s part %d/3 within 20 tokens", idx, chunk, wantID, idx+1)
+		}
+	}
+	if want := strings.Repeat("b", 60) + "\n\n" + strings.Repeat("c", 8); chunks[2].Text != want {
+		t.Fatalf("last chunk = %q, want %q", chunks[2].Text, want)
+	}
+}
+
+func TestSplitChunkText(t *testing.T) {
+	t.Parallel()
+
+	fence := "```\nline one\n\nline two\n```"
+	tcs := []struct {
+		name      string
+		text      string
+		want      []string
+		maxTokens int
+	}{
+		{name: "fits", text: "short\n\ntext", maxTokens: 10, want: []string{"short\n\ntext"}},
+		{name: "paragraphs", text: "aaaa aaaa\n\nbbbb bbbb\n\ncc", maxTokens: 3, want: []string{"aaaa aaaa", "bbbb bbbb", "cc"}},
+		{name: "fence kept whole", text: "intro\n\n" + fence, maxTokens: 8, want: []string{"intro", fence}},
+		{name: "long paragraph on lines", text: "aaaaaaaa\nbbbb\ncc", maxTokens: 3, want: []string{"aaaaaaaa", "bbbb\ncc"}},
+		{name: "long line cut", text: strings.Repeat("x", 10), maxTokens: 1, want: []string{"xxxx", "xxxx", "xx"}},
+		{name: "runes not bytes", text: strings.Repeat("é", 6), maxTokens: 1, want: []string{"éééé", "éé"}},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := splitChunkText(tc.text, tc.maxTokens)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("splitChunkText(%q, %d) = %q, want %q", tc.text, tc.maxTokens, got, tc.want)
+			}
+		})
+	}
+}
@@ -4,98 +4,122 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
+// DefaultMaxPatchBytes caps one file patch embedded in the Changed Files section.
+const DefaultMaxPatchBytes = 16 * 1024
+
 // RenderOptions controls markdown rendering behavior.
+// MaxPatchBytes caps each embedded patch; zero means DefaultMaxPatchBytes.
+// TopReactedComments > 0 adds a Most-reacted Comments section with that many entries.
+// A non-empty FocusComment (a URL fragment such as "issuecomment-123") replaces the body
+// with that comment and FocusContext neighbouring comments on each side.
+// Edited bodies are always annotated; EditDiffs also renders a diff of each revision.
+// Minimized selects how moderator-minimized comments appear; empty means MinimizedCollapse.
+// CommentStyle lays out comments as list items or quoted blocks; empty means CommentStyleList.
+// Format selects the document kind; empty means FormatMarkdown.
+// MaxChunkTokens caps each FormatJSONL record; zero means DefaultMaxChunkTokens.
+// Template lays out the markdown document; nil means DefaultTemplate.
 type RenderOptions struct {
-	Lang            string
-	IncludeComments bool
-	IncludeSummary  bool
+	Template            *Template
+	Format              Format
+	Lang                string
+	FocusComment        string
+	Minimized           MinimizedMode
+	CommentStyle        CommentStyle
+	MaxPatchBytes       int
+	MaxChunkTokens      int
+	TopReactedComments  int
+	FocusContext        int
+	IncludeComments     bool
+	IncludeReactions    bool
+	IncludeSummary      bool
+	IncludeFiles        bool
+	IncludePatches      bool
+	IncludeCommits      bool
+	IncludeChecks       bool
+	HideResolvedThreads bool
+	EditDiffs           bool
 }
 
-// Renderer converts normalized GitHub data into markdown output.
+// Renderer converts normalized GitHub data into a markdown document, or the document selected
+// by RenderOptions.Format.
 type Renderer interface {
 	Render(ctx context.Context, data gh.IssueData, opts RenderOptions) ([]byte, error)
 }
 
 type renderer struct {
 	summarizer Summarizer
+	// defaultTemplate parses DefaultTemplate on first use and reuses it for every render without
+	// RenderOptions.Template.
+	defaultTemplate func() (*Template, error)
 }
 
 // NewRenderer creates a markdown renderer instance.
 func NewRenderer(summarizer Summarizer) Renderer {
-	return &renderer{summarizer: summarizer}
+	return &renderer{
+		summarizer: summarizer,
+		defaultTemplate: sync.OnceValues(func() (*Template, error) {
+			return ParseTemplate("default", DefaultTemplate)
+		}),
+	}
 }
 
 func (r *renderer) Render(ctx context.Context, data gh.IssueData, opts RenderOptions) ([]byte, error) {
 	if data.Meta.Type == "" {
 		return nil, fmt.Errorf("render markdown: missing resource type")
 	}
-
-	var (
-		summary       Summary
-		summaryStatus string
-	)
-
-	if opts.IncludeSummary && r.summarizer != nil {
-		targetLang := resolveSummaryLanguage(opts.Lang, data)
-		got, err := r.summarizer.Summarize(ctx, data, targetLang)
-		switch {
-		case err != nil:
-			summaryStatus = fmt.Sprintf("skipped (%s)", err.Error())
-		case got.Status == "skipped":
-			reason := got.Reason
-			if reason == "" {
-				reason = "summary unavailable"
-			}
-			summaryStatus = fmt.Sprintf("skipped (%s)", reason)
-		default:
-			summary = got
+	switch opts.Format {
+	case "", FormatMarkdown:
+	case FormatJSON:
+		var summary *Summary
+		if got, ok := r.summarize(ctx, data, opts); ok {
+			summary = &got
 		}
+		return renderSnapshot(data, summary)
+	case FormatHTML, FormatJSONL:
+		if opts.FocusComment != "" {
+			return nil, fmt.Errorf("render %s: focused comments are only rendered as markdown", opts.Format)
+		}
+		if !isRenderableType(data.Meta.Type) {
+			return nil, fmt.Errorf("render %s: unsupported resource type %q", opts.Format, data.Meta.Type)
+		}
+		summary, summaryStatus := r.documentSummary(ctx, data, opts)
+		if opts.Format == FormatJSONL {
+			return renderChunks(data, summary, opts)
+		}
+		return renderHTMLDocument(data, summary, summaryStatus, opts), nil
+	default:
+		return nil, fmt.Errorf("render: unsupported format %q", opts.Format)
 	}
-
-	var b strings.Builder
-	b.WriteString(renderFrontMatter(data.Meta))
-	fmt.Fprintf(&b, "# %s\n\n", data.Meta.Title)
-	b.WriteString(renderMetadataSection(data.Meta, summaryStatus))
-
-	if summary.Summary != "" {
-		b.WriteString("\n")
-		b.WriteString(renderSummarySection(summary))
-	}
-
-	b.WriteString("\n## Original Description\n\n")
-	if strings.TrimSpace(data.Description) == "" {
-		b.WriteString("(empty)\n")
-	} else {
-		b.WriteString(data.Description)
-		b.WriteString("\n")
+	if opts.FocusComment != "" {
+		focused, err := renderFocusedDocument(data, opts)
+		if err != nil {
+			return nil, fmt.Errorf("render markdown: %w", err)
+		}
+		return []byte(focused), nil
 	}
 
-	switch data.Meta.Type {
-	case gh.ResourceIssue:
-		b.WriteString("\n")
-		b.WriteString(renderIssueTimelineSection(data))
-		b.WriteString("\n")
-		b.WriteString(renderIssueThreadSection(data, opts.IncludeComments))
-	case gh.ResourcePullRequest:
-		b.WriteString("\n")
-		b.WriteString(renderPRReviewsSection(data, opts.IncludeComments))
-		b.WriteString("\n")
-		b.WriteString(renderPRThreadSection(data, opts.IncludeComments))
-	case gh.ResourceDiscussion:
-		b.WriteString("\n")
-		b.WriteString(renderDiscussionThreadSection(data, opts.IncludeComments))
-	default:
+	if !isRenderableType(data.Meta.Type) {
 		return nil, fmt.Errorf("render markdown: unsupported resource type %q", data.Meta.Type)
 	}
+	tmpl := opts.Template
+	if tmpl == nil {
+		var err error
+		if tmpl, err = r.defaultTemplate(); err != nil {
+			return nil, fmt.Errorf("render markdown: %w", err)
+		}
+	}
 
-	b.WriteString("\n## References\n")
-	fmt.Fprintf(&b, "- Original URL: %s\n", data.Meta.URL)
-
-	return []byte(b.String()), nil
+	summary, summaryStatus := r.documentSummary(ctx, data, opts)
+	out, err := tmpl.execute(TemplateData{IssueData: data, Summary: summary, SummaryStatus: summaryStatus, Options: opts})
+	if err != nil {
+		return nil, fmt.Errorf("render markdown: %w", err)
+	}
+	return out, nil
 }
 
 func renderMetadataSection(meta gh.Metadata, summaryStatus string) string {
@@ -110,15 +134,12 @@ func renderMetadataSection(meta gh.Metadata, summaryStatus string) string {
 	fmt.Fprintf(&b, "- updated_at: %s\n", meta.UpdatedAt)
 	fmt.Fprintf(&b, "- url: %s\n", meta.URL)
 	fmt.Fprintf(&b, "- labels: %s\n", joinLabels(meta.Labels))
+	writeLifecycleMetadata(&b, meta)
 
-	if meta.Type == gh.ResourcePullRequest {
-		fmt.Fprintf(&b, "- merged: %t\n", meta.Merged)
-		if meta.MergedAt != "" {
-			fmt.Fprintf(&b, "- merged_at: %s\n", meta.MergedAt)
-		}
-		fmt.Fprintf(&b, "- review_count: %d\n", meta.ReviewCount)
-	}
-	if meta.Type == gh.ResourceDiscussion {
+	switch meta.Type {
+	case gh.ResourcePullRequest:
+		writePRMetadata(&b, meta)
+	case gh.ResourceDiscussion:
 		if meta.Category != "" {
 			fmt.Fprintf(&b, "- category: %s\n", meta.Category)
 		}
@@ -134,6 +155,45 @@ func renderMetadataSection(meta gh.Metadata, summaryStatus string) string {
 	return b.String()
 }
 
+// writeLifecycleMetadata emits ownership and close details; discussions have no assignees or milestones.
+func writeLifecycleMetadata(b *strings.Builder, meta gh.Metadata) {
+	if meta.Type != gh.ResourceDiscussion {
+		fmt.Fprintf(b, "- assignees: %s\n", joinNames(meta.Assignees))
+		if meta.Milestone != "" {
+			fmt.Fprintf(b, "- milestone: %s\n", meta.Milestone)
+		}
+	}
+	if meta.ClosedAt != "" {
+		fmt.Fprintf(b, "- closed_at: %s\n", meta.ClosedAt)
+	}
+	if meta.ClosedBy != "" {
+		fmt.Fprintf(b, "- closed_by: %s\n", meta.ClosedBy)
+	}
+	if meta.StateReason != "" {
+		fmt.Fprintf(b, "- state_reason: %s\n", meta.StateReason)
+	}
+	fmt.Fprintf(b, "- locked: %t\n", meta.Locked)
+}
+
+func writePRMetadata(b *strings.Builder, meta gh.Metadata) {
+	fmt.Fprintf(b, "- draft: %t\n", meta.Draft)
+	if meta.BaseRef != "" || meta.HeadRef != "" {
+		fmt.Fprintf(b, "- branches: %s\n", describeBranches(meta))
+	}
+	fmt.Fprintf(b, "- requested_reviewers: %s\n", joinNames(meta.RequestedReviewers))
+	fmt.Fprintf(b, "- changes: +%d -%d\n", meta.Additions, meta.Deletions)
+	fmt.Fprintf(b, "- merged: %t\n", meta.Merged)
+	if meta.MergedAt != "" {
+		fmt.Fprintf(b, "- merged_at: %s\n", meta.MergedAt)
+	}
+	fmt.Fprintf(b, "- review_count: %d\n", meta.ReviewCount)
+}
+
+// describeBranches renders the merge direction as "head → base".
+func describeBranches(meta gh.Metadata) string {
+	return meta.HeadRef + " → " + meta.BaseRef
+}
+
 func renderSummarySection(summary Summary) string {
 	var b strings.Builder
 
@@ -174,3 +234,69 @@ func joinLabels(labels []gh.Label) string {
 	}
 	return strings.Join(parts, ", ")
 }
+
+func joinNames(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// codeFence returns a backtick fence longer than any backtick run inside body,
+// so embedded content can never terminate the block early.
+func codeFence(body string) string {
+	longest, current := 0, 0
+	for _, r := range body {
+		if r != '`' {
+			current = 0
+			continue
+		}
+		current++
+		if current > longest {
+			longest = current
+		}
+	}
+	if longest < 3 {
+		return "```"
+	}
+	return strings.Repeat("`", longest+1)
+}
+
+// summarize runs the summarizer when one is configured and requested. A failed summary is
+// returned with Status "skipped" and the failure as Reason; ok is false when none was attempted.
+func (r *renderer) summarize(ctx context.Context, data gh.IssueData, opts RenderOptions) (Summary, bool) {
+	if !opts.IncludeSummary || r.summarizer == nil {
+		return Summary{}, false
+	}
+	got, err := r.summarizer.Summarize(ctx, data, resolveSummaryLanguage(opts.Lang, data))
+	switch {
+	case err != nil:
+		return Summary{Status: "skipped", Reason: err.Error()}, true
+	case got.Status == "skipped" && got.Reason == "":
+		got.Reason = "summary unavailable"
+	}
+	return got, true
+}
+
+// documentSummary returns the summary to render and, when summarization was skipped, the
+// metadata summary_status describing why.
+func (r *renderer) documentSummary(ctx context.Context, data gh.IssueData, opts RenderOptions) (Summary, string) {
+	got, ok := r.summarize(ctx, data, opts)
+	switch {
+	case !ok:
+		return Summary{}, ""
+	case got.Status == "skipped":
+		return Summary{}, fmt.Sprintf("skipped (%s)", got.Reason)
+	default:
+		return got, ""
+	}
+}
+
+func isRenderableType(resourceType gh.ResourceType) bool {
+	switch resourceType {
+	case gh.ResourceIssue, gh.ResourcePullRequest, gh.ResourceDiscussion:
+		return true
+	default:
+		return false
+	}
+}
@@ -80,7 +80,12 @@ func TestRendererGoldenIssuePRDiscussion(t *testing.T) {
 		{
 			name: "issue",
 			data: func() string {
-				out, err := NewRenderer(&stubSummarizer{summary: fixedSummary()}).Render(context.Background(), sampleIssueData(), RenderOptions{IncludeComments: true, IncludeSummary: true})
+				out, err := NewRenderer(&stubSummarizer{summary: fixedSummary()}).Render(context.Background(), sampleIssueData(), RenderOptions{
+					IncludeComments:    true,
+					IncludeSummary:     true,
+					IncludeReactions:   true,
+					TopReactedComments: 2,
+				})
 				if err != nil {
 					t.Fatalf("Render issue error = %v", err)
 				}
@@ -91,7 +96,14 @@ func TestRendererGoldenIssuePRDiscussion(t *testing.T) {
 		{
 			name: "pr",
 			data: func() string {
-				out, err := NewRenderer(&stubSummarizer{summary: fixedSummary()}).Render(context.Background(), samplePRData(), RenderOptions{IncludeComments: true, IncludeSummary: true})
+				out, err := NewRenderer(&stubSummarizer{summary: fixedSummary()}).Render(context.Background(), samplePRData(), RenderOptions{
+					IncludeComments: true,
+					IncludeSummary:  true,
+					IncludeFiles:    true,
+					IncludePatches:  true,
+					IncludeCommits:  true,
+					IncludeChecks:   true,
+				})
 				if err != nil {
 					t.Fatalf("Render pr error = %v", err)
 				}
@@ -1,17 +1,16 @@
 package converter
 
 import (
-	"fmt"
 	"strings"
 
 	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
-func renderDiscussionThreadSection(data gh.IssueData, includeComments bool) string {
+func renderDiscussionThreadSection(data gh.IssueData, opts RenderOptions) string {
 	var b strings.Builder
 
 	b.WriteString("## Discussion Thread\n")
-	if !includeComments {
+	if !opts.IncludeComments {
 		b.WriteString("Comments omitted (--include-comments=false).\n")
 		return b.String()
 	}
@@ -24,12 +23,13 @@ func renderDiscussionThreadSection(data gh.IssueData, includeComments bool) stri
 		accepted, ok := resolveAcceptedAnswer(data.Thread, data.Meta.AcceptedAnswerID, data.Meta.AcceptedAnswerAuthor)
 		if ok {
 			b.WriteString("\n### Accepted Answer\n")
-			fmt.Fprintf(&b, "- %s (%s): %s\n", accepted.Author, accepted.CreatedAt, accepted.Body)
+			writeCommentItem(&b, accepted, 0, opts)
 		}
 	}
 
 	b.WriteString("\n### Replies\n")
-	writeCommentList(&b, data.Thread, 0)
+	skipped := writeCommentList(&b, data.Thread, 0, opts)
+	b.WriteString(skippedMinimizedNote(skipped))
 	return b.String()
 }
 
@@ -69,12 +69,18 @@ func findAcceptedAnswerByAuthor(nodes []gh.CommentNode, author string) (gh.Comme
 	return gh.CommentNode{}, false
 }
 
-func writeCommentList(b *strings.Builder, comments []gh.CommentNode, depth int) {
-	prefix := strings.Repeat("  ", depth)
+// writeCommentList writes comments and their replies nested under them and returns how many
+// minimized comments were skipped. Replies to a skipped comment are skipped with it.
+func writeCommentList(b *strings.Builder, comments []gh.CommentNode, depth int, opts RenderOptions) int {
+	skipped := 0
 	for _, comment := range comments {
-		fmt.Fprintf(b, "%s- %s (%s): %s\n", prefix, comment.Author, comment.CreatedAt, comment.Body)
+		if !writeCommentItem(b, comment, depth, opts) {
+			skipped++
+			continue
+		}
 		if len(comment.Replies) > 0 {
-			writeCommentList(b, comment.Replies, depth+1)
+			skipped += writeCommentList(b, comment.Replies, depth+1, opts)
 		}
 	}
+	return skipped
 }
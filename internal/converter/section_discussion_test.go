@@ -10,7 +10,7 @@ import (
 func TestRenderDiscussionThreadSection(t *testing.T) {
 	t.Parallel()
 
-	out := renderDiscussionThreadSection(sampleDiscussionData(), true)
+	out := renderDiscussionThreadSection(sampleDiscussionData(), RenderOptions{IncludeComments: true})
 	expected := []string{
 		"## Discussion Thread",
 		"### Accepted Answer",
@@ -28,7 +28,7 @@ func TestRenderDiscussionThreadSection(t *testing.T) {
 func TestRenderDiscussionThreadIncludeCommentsOption(t *testing.T) {
 	t.Parallel()
 
-	out := renderDiscussionThreadSection(sampleDiscussionData(), false)
+	out := renderDiscussionThreadSection(sampleDiscussionData(), RenderOptions{})
 	if !strings.Contains(out, "Comments omitted (--include-comments=false).") {
 		t.Fatalf("discussion thread should include omitted note:\n%s", out)
 	}
@@ -48,7 +48,7 @@ func TestRenderDiscussionThreadSectionUsesAcceptedAnswerID(t *testing.T) {
 		{ID: "d3", Author: "mentor", Body: "accepted answer", CreatedAt: "2026-01-05T09:16:00Z"},
 	}
 
-	out := renderDiscussionThreadSection(data, true)
+	out := renderDiscussionThreadSection(data, RenderOptions{IncludeComments: true})
 	if !strings.Contains(out, "accepted answer") {
 		t.Fatalf("discussion should include accepted answer by id:\n%s", out)
 	}
@@ -0,0 +1,136 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// maxEditDiffCells bounds the line-diff table; larger revisions are shown as a full replacement.
+const maxEditDiffCells = 1 << 20
+
+// editAnnotation returns "edited N time(s)", or "" for bodies never edited or not inspected.
+func editAnnotation(history gh.EditHistory) string {
+	switch history.Count {
+	case 0:
+		return ""
+	case 1:
+		return "edited 1 time"
+	default:
+		return fmt.Sprintf("edited %d times", history.Count)
+	}
+}
+
+// renderDescriptionEdits renders the edit note under the original description, followed by the
+// revision diffs when requested.
+func renderDescriptionEdits(history gh.EditHistory, opts RenderOptions) string {
+	annotation := editAnnotation(history)
+	if annotation == "" {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n_%s", strings.ToUpper(annotation[:1])+annotation[1:])
+	if history.LastEditor != "" {
+		fmt.Fprintf(&b, ", last by %s", history.LastEditor)
+	}
+	if history.LastEditedAt != "" {
+		fmt.Fprintf(&b, " at %s", history.LastEditedAt)
+	}
+	b.WriteString("._\n")
+	if opts.EditDiffs {
+		b.WriteString(renderEditDiffs(history, ""))
+	}
+	return b.String()
+}
+
+// writeCommentEdits writes the revision diffs of the comment list item at indent, nested under it.
+func writeCommentEdits(b *strings.Builder, history gh.EditHistory, indent string, opts RenderOptions) {
+	if opts.EditDiffs {
+		b.WriteString(renderEditDiffs(history, indent+"  "))
+	}
+}
+
+// renderEditDiffs renders a collapsible block with one diff per revision, oldest first.
+// Nothing is rendered without at least two revision texts to compare.
+func renderEditDiffs(history gh.EditHistory, indent string) string {
+	if len(history.Revisions) < 2 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n%s<details>\n%s<summary>Edit history (%s)</summary>\n", indent, indent, editAnnotation(history))
+	for i := 1; i < len(history.Revisions); i++ {
+		revision := history.Revisions[i]
+		editor := revision.Editor
+		if editor == "" {
+			editor = "unknown"
+		}
+		diff := lineDiff(history.Revisions[i-1].Body, revision.Body)
+		fence := codeFence(diff)
+		fmt.Fprintf(&b, "\n%sRevision %d by %s at %s\n\n", indent, i, editor, revision.EditedAt)
+		fmt.Fprintf(&b, "%s%sdiff\n", indent, fence)
+		for _, line := range strings.Split(diff, "\n") {
+			fmt.Fprintf(&b, "%s%s\n", indent, line)
+		}
+		fmt.Fprintf(&b, "%s%s\n", indent, fence)
+	}
+	fmt.Fprintf(&b, "\n%s</details>\n\n", indent)
+	return b.String()
+}
+
+// lineDiff compares two texts line by line and prefixes every line with " ", "-" or "+".
+func lineDiff(before, after string) string {
+	oldLines := splitDiffLines(before)
+	newLines := splitDiffLines(after)
+
+	out := make([]string, 0, len(oldLines)+len(newLines))
+	if len(oldLines)*len(newLines) > maxEditDiffCells {
+		for _, line := range oldLines {
+			out = append(out, "-"+line)
+		}
+		for _, line := range newLines {
+			out = append(out, "+"+line)
+		}
+		return strings.Join(out, "\n")
+	}
+
+	// common[i][j] is the longest common subsequence of oldLines[i:] and newLines[j:].
+	common := make([][]int, len(oldLines)+1)
+	for i := range common {
+		common[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			out = append(out, " "+oldLines[i])
+			i++
+			j++
+		case i < len(oldLines) && (j == len(newLines) || common[i+1][j] >= common[i][j+1]):
+			out = append(out, "-"+oldLines[i])
+			i++
+		default:
+			out = append(out, "+"+newLines[j])
+			j++
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func splitDiffLines(text string) []string {
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,103 @@
+package converter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func TestLineDiff(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{name: "unchanged", before: "a\nb", after: "a\nb\n", want: " a\n b"},
+		{name: "changed middle line", before: "a\nb\nc", after: "a\nB\nc", want: " a\n-b\n+B\n c"},
+		{name: "appended", before: "a", after: "a\nb", want: " a\n+b"},
+		{name: "crlf bodies", before: "a\r\nb\r\n", after: "a\nc", want: " a\n-b\n+c"},
+		{name: "from empty", before: "", after: "x", want: "+x"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := lineDiff(tc.before, tc.after); got != tc.want {
+				t.Fatalf("lineDiff() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderEditHistory(t *testing.T) {
+	t.Parallel()
+
+	edits := gh.EditHistory{
+		LastEditor:   "bob",
+		LastEditedAt: "2026-01-03T00:00:00Z",
+		Count:        2,
+		Revisions: []gh.ContentEdit{
+			{Editor: "bob", EditedAt: "2026-01-01T12:00:00Z", Body: "I can reproduce this"},
+			{Editor: "bob", EditedAt: "2026-01-02T00:00:00Z", Body: "I can reproduce this on 1.2"},
+			{Editor: "carol", EditedAt: "2026-01-03T00:00:00Z", Body: "I can reproduce this."},
+		},
+	}
+	data := sampleIssueData()
+	data.Thread[0].Edits = edits
+	data.DescriptionEdits = gh.EditHistory{LastEditor: "alice", LastEditedAt: "2026-01-01T11:00:00Z", Count: 1}
+
+	tcs := []struct {
+		name      string
+		want      []string
+		notWant   []string
+		editDiffs bool
+	}{
+		{
+			name: "annotations only",
+			want: []string{
+				"_Edited 1 time, last by alice at 2026-01-01T11:00:00Z._\n",
+				"- bob (2026-01-01T12:00:00Z, edited 2 times): I can reproduce this.",
+				"  - alice (2026-01-01T12:30:00Z): Thanks, investigating.\n",
+			},
+			notWant: []string{"<details>"},
+		},
+		{
+			name:      "revision diffs",
+			editDiffs: true,
+			want: []string{
+				"- bob (2026-01-01T12:00:00Z, edited 2 times): I can reproduce this.",
+				"\n  <details>\n  <summary>Edit history (edited 2 times)</summary>\n",
+				"\n  Revision 1 by bob at 2026-01-02T00:00:00Z\n\n  ```diff\n  -I can reproduce this\n  +I can reproduce this on 1.2\n  ```\n",
+				"\n  Revision 2 by carol at 2026-01-03T00:00:00Z\n",
+				"\n  </details>\n",
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := NewRenderer(nil).Render(context.Background(), data, RenderOptions{IncludeComments: true, EditDiffs: tc.editDiffs})
+			if err != nil {
+				t.Fatalf("Render error = %v, want nil", err)
+			}
+			for _, piece := range tc.want {
+				if !strings.Contains(string(out), piece) {
+					t.Fatalf("output missing %q\n%s", piece, out)
+				}
+			}
+			for _, piece := range tc.notWant {
+				if strings.Contains(string(out), piece) {
+					t.Fatalf("output contains %q\n%s", piece, out)
+				}
+			}
+		})
+	}
+}
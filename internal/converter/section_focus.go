@@ -0,0 +1,209 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// focusTarget locates one comment among the siblings it is shown with. When reply is set
+// the target is a discussion reply and siblings[index] is its top-level parent.
+type focusTarget struct {
+	thread   *gh.ReviewThread
+	reply    *gh.CommentNode
+	siblings []gh.CommentNode
+	index    int
+}
+
+func (t focusTarget) comment() gh.CommentNode {
+	if t.reply != nil {
+		return *t.reply
+	}
+	return t.siblings[t.index]
+}
+
+// renderFocusedDocument renders the header plus one targeted comment with surrounding context
+// instead of the full thread.
+func renderFocusedDocument(data gh.IssueData, opts RenderOptions) (string, error) {
+	target, ok := locateFocusComment(data, opts.FocusComment)
+	if !ok {
+		return "", fmt.Errorf("render focused comment: comment %q not found", opts.FocusComment)
+	}
+
+	var b strings.Builder
+	b.WriteString(renderFrontMatter(data))
+	fmt.Fprintf(&b, "# %s\n\n", data.Meta.Title)
+	b.WriteString(renderMetadataSection(data.Meta, ""))
+	b.WriteString("\n")
+	b.WriteString(renderFocusSection(target, opts))
+	b.WriteString("\n## References\n")
+	fmt.Fprintf(&b, "- Original URL: %s\n", data.Meta.URL)
+	if url := target.comment().URL; url != "" {
+		fmt.Fprintf(&b, "- Comment URL: %s\n", url)
+	}
+	return b.String(), nil
+}
+
+func renderFocusSection(target focusTarget, opts RenderOptions) string {
+	var b strings.Builder
+	b.WriteString("## Focused Comment\n")
+	fmt.Fprintf(&b, "- anchor: %s\n", opts.FocusComment)
+	if target.thread != nil {
+		fmt.Fprintf(&b, "- file: %s (%s)\n", target.thread.Path, describeThreadAnchor(*target.thread))
+		if hunk := target.thread.DiffHunk; hunk != "" {
+			fence := codeFence(hunk)
+			fmt.Fprintf(&b, "\n%sdiff\n%s\n%s\n", fence, strings.TrimRight(hunk, "\n"), fence)
+		}
+	}
+
+	start := max(target.index-opts.FocusContext, 0)
+	end := min(target.index+opts.FocusContext+1, len(target.siblings))
+	if before := target.siblings[start:target.index]; len(before) > 0 {
+		b.WriteString("\n### Context Before\n")
+		writeCommentList(&b, withoutReplies(before), 0, opts)
+	}
+
+	focused := target.comment()
+	b.WriteString("\n### Target Comment\n")
+	when := focused.CreatedAt
+	if edited := editAnnotation(focused.Edits); edited != "" {
+		when += " (" + edited + ")"
+	}
+	fmt.Fprintf(&b, "**%s**%s commented at %s%s\n\n", focused.Author, authorBadge(focused.AuthorAssociation), when,
+		reactionSuffix(focused.Reactions, opts.IncludeReactions))
+	if minimized := minimizedAnnotation(focused); minimized != "" {
+		// The target is always shown in full; the note keeps the moderation visible.
+		fmt.Fprintf(&b, "_%s%s._\n\n", strings.ToUpper(minimized[:1]), minimized[1:])
+	}
+	b.WriteString(blockquote(focused.Body))
+	if opts.EditDiffs {
+		b.WriteString(renderEditDiffs(focused.Edits, ""))
+	}
+
+	switch {
+	case target.reply != nil:
+		b.WriteString("\n### Reply Thread\n")
+		writeCommentList(&b, target.siblings[target.index:target.index+1], 0, opts)
+	case len(focused.Replies) > 0:
+		b.WriteString("\n### Replies\n")
+		writeCommentList(&b, focused.Replies, 0, opts)
+	}
+
+	if after := target.siblings[target.index+1 : end]; len(after) > 0 {
+		b.WriteString("\n### Context After\n")
+		writeCommentList(&b, withoutReplies(after), 0, opts)
+	}
+	return b.String()
+}
+
+// Anchor prefixes of the comment kinds a focused export can target.
+const (
+	anchorIssueComment      = "issuecomment-"
+	anchorReviewComment     = "discussion_r"
+	anchorDiscussionComment = "discussioncomment-"
+	anchorReview            = "pullrequestreview-"
+)
+
+// locateFocusComment resolves a review anchor to the review itself. Comment anchors are searched
+// in review threads first so review comments keep their code anchor, then in flat review
+// comments, then in the conversation thread and its replies.
+func locateFocusComment(data gh.IssueData, anchor string) (focusTarget, bool) {
+	if id, ok := strings.CutPrefix(anchor, anchorReview); ok {
+		return locateFocusReview(data, id)
+	}
+	for i := range data.Reviews {
+		for j := range data.Reviews[i].Threads {
+			thread := &data.Reviews[i].Threads[j]
+			if idx := indexOfAnchor(thread.Comments, anchor, anchorReviewComment); idx >= 0 {
+				return focusTarget{thread: thread, siblings: thread.Comments, index: idx}, true
+			}
+		}
+	}
+	for _, review := range data.Reviews {
+		if idx := indexOfAnchor(review.Comments, anchor, anchorReviewComment); idx >= 0 {
+			return focusTarget{siblings: review.Comments, index: idx}, true
+		}
+	}
+	kind := anchorIssueComment
+	if data.Meta.Type == gh.ResourceDiscussion {
+		kind = anchorDiscussionComment
+	}
+	if idx := indexOfAnchor(data.Thread, anchor, kind); idx >= 0 {
+		return focusTarget{siblings: data.Thread, index: idx}, true
+	}
+	for i := range data.Thread {
+		if idx := indexOfAnchor(data.Thread[i].Replies, anchor, kind); idx >= 0 {
+			return focusTarget{reply: &data.Thread[i].Replies[idx], siblings: data.Thread, index: i}, true
+		}
+	}
+	return focusTarget{}, false
+}
+
+// locateFocusReview targets a pull request review. The other reviews are its context and the
+// review's own comments its replies.
+func locateFocusReview(data gh.IssueData, id string) (focusTarget, bool) {
+	target := focusTarget{index: -1}
+	for _, review := range data.Reviews {
+		if review.ID == id {
+			target.index = len(target.siblings)
+		}
+		target.siblings = append(target.siblings, gh.CommentNode{
+			ID:        review.ID,
+			Author:    review.Author,
+			Body:      review.Body,
+			CreatedAt: review.CreatedAt,
+			URL:       data.Meta.URL + "#" + anchorReview + review.ID,
+			Reactions: review.Reactions,
+			Replies:   review.Comments,
+		})
+	}
+	return target, target.index >= 0 && id != ""
+}
+
+func indexOfAnchor(comments []gh.CommentNode, anchor, kind string) int {
+	for i, comment := range comments {
+		if matchesAnchor(comment, anchor, kind) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchesAnchor compares the comment URL fragment, falling back to the numeric REST ID only for
+// comments without a URL and only when the anchor names the comment's kind.
+func matchesAnchor(comment gh.CommentNode, anchor, kind string) bool {
+	if anchor == "" {
+		return false
+	}
+	if comment.URL != "" {
+		return strings.HasSuffix(comment.URL, "#"+anchor)
+	}
+	id, ok := strings.CutPrefix(anchor, kind)
+	return ok && id != "" && comment.ID == id
+}
+
+func withoutReplies(comments []gh.CommentNode) []gh.CommentNode {
+	out := make([]gh.CommentNode, len(comments))
+	for i, comment := range comments {
+		comment.Replies = nil
+		out[i] = comment
+	}
+	return out
+}
+
+func blockquote(body string) string {
+	if strings.TrimSpace(body) == "" {
+		return "> (empty)\n"
+	}
+	lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+	var b strings.Builder
+	for _, line := range lines {
+		if line == "" {
+			b.WriteString(">\n")
+			continue
+		}
+		fmt.Fprintf(&b, "> %s\n", line)
+	}
+	return b.String()
+}
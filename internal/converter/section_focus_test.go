@@ -0,0 +1,138 @@
+package converter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func TestRenderFocusedComment(t *testing.T) {
+	t.Parallel()
+
+	issue := sampleIssueData()
+	issue.Thread = []gh.CommentNode{
+		{ID: "11", Author: "a1", Body: "first", CreatedAt: "2026-01-01T00:00:00Z", URL: "https://github.com/octo/repo/issues/123#issuecomment-11"},
+		{ID: "12", Author: "a2", Body: "second", CreatedAt: "2026-01-01T01:00:00Z", URL: "https://github.com/octo/repo/issues/123#issuecomment-12"},
+		{ID: "13", Author: "a3", Body: "target line\n\nmore", CreatedAt: "2026-01-01T02:00:00Z", URL: "https://github.com/octo/repo/issues/123#issuecomment-13"},
+		{ID: "14", Author: "a4", Body: "fourth", CreatedAt: "2026-01-01T03:00:00Z", URL: "https://github.com/octo/repo/issues/123#issuecomment-14"},
+	}
+
+	discussion := sampleDiscussionData()
+	discussion.Thread[1].Replies[0].URL = "https://github.com/octo/repo/discussions/88#discussioncomment-501"
+
+	pr := samplePRData()
+	pr.Reviews[0].Threads[0].Comments[1].URL = "https://github.com/octo/repo/pull/124#discussion_r777"
+
+	tcs := []struct {
+		name    string
+		data    gh.IssueData
+		anchor  string
+		context int
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "issue comment with context",
+			data:    issue,
+			anchor:  "issuecomment-13",
+			context: 1,
+			want: []string{
+				"## Focused Comment\n- anchor: issuecomment-13\n",
+				"### Context Before\n- a2 (2026-01-01T01:00:00Z): second\n",
+				"### Target Comment\n**a3** commented at 2026-01-01T02:00:00Z\n\n> target line\n>\n> more\n",
+				"### Context After\n- a4 (2026-01-01T03:00:00Z): fourth\n",
+				"- Comment URL: https://github.com/octo/repo/issues/123#issuecomment-13\n",
+			},
+			notWant: []string{"a1 (", "## Original Description", "## Comments"},
+		},
+		{
+			name:   "discussion reply keeps its subtree",
+			data:   discussion,
+			anchor: "discussioncomment-501",
+			want: []string{
+				"### Target Comment\n**dora** commented at 2026-01-05T09:20:00Z\n\n> Thanks, this worked.\n",
+				"### Reply Thread\n- mentor (2026-01-05T09:15:00Z): Set GITHUB_TOKEN and OPENAI_API_KEY in your shell.\n  - dora (2026-01-05T09:20:00Z): Thanks, this worked.\n",
+			},
+			notWant: []string{"### Context Before"},
+		},
+		{
+			name:    "review with its comments",
+			data:    pr,
+			anchor:  "pullrequestreview-r2",
+			context: 1,
+			want: []string{
+				"### Context Before\n- bob (2026-01-03T12:00:00Z): Looks good.\n",
+				"### Target Comment\n**carol** commented at 2026-01-03T13:00:00Z\n\n> Need edge case coverage.\n",
+				"### Replies\n- carol (2026-01-03T13:05:00Z): Typo in heading.\n",
+				"- Comment URL: https://github.com/octo/repo/pull/124#pullrequestreview-r2\n",
+			},
+		},
+		{
+			name:    "review thread comment keeps code anchor",
+			data:    pr,
+			anchor:  "discussion_r777",
+			context: 2,
+			want: []string{
+				"- file: internal/config/loader.go (",
+				"```diff\n@@ -10,3 +10,6 @@ func Load() {",
+				"### Context Before\n- bob (2026-01-03T12:10:00Z): Please add test.\n",
+				"### Target Comment\n**alice** commented at 2026-01-03T12:20:00Z\n\n> Added in the next commit.\n",
+			},
+			notWant: []string{"## Review Threads", "### Context After"},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw, err := NewRenderer(nil).Render(context.Background(), tc.data, RenderOptions{FocusComment: tc.anchor, FocusContext: tc.context})
+			if err != nil {
+				t.Fatalf("Render() error = %v, want nil", err)
+			}
+			out := string(raw)
+			for _, want := range tc.want {
+				if !strings.Contains(out, want) {
+					t.Fatalf("Render() missing %q in:\n%s", want, out)
+				}
+			}
+			for _, notWant := range tc.notWant {
+				if strings.Contains(out, notWant) {
+					t.Fatalf("Render() unexpectedly contains %q in:\n%s", notWant, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderFocusedCommentNotFound(t *testing.T) {
+	t.Parallel()
+
+	withoutURL := sampleIssueData()
+	withoutURL.Thread[0].ID = "42"
+	withoutURL.Thread[0].URL = ""
+
+	tcs := []struct {
+		name   string
+		data   gh.IssueData
+		anchor string
+	}{
+		{name: "unknown comment", data: sampleIssueData(), anchor: "issuecomment-999"},
+		{name: "ID fallback checks the anchor kind", data: withoutURL, anchor: "discussion_r42"},
+		{name: "unknown review", data: samplePRData(), anchor: "pullrequestreview-999"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewRenderer(nil).Render(context.Background(), tc.data, RenderOptions{FocusComment: tc.anchor})
+			if err == nil || !strings.Contains(err.Error(), `comment "`+tc.anchor+`" not found`) {
+				t.Fatalf("Render() error = %v, want not found", err)
+			}
+		})
+	}
+}
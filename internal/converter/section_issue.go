@@ -1,33 +1,16 @@
 package converter
 
 import (
-	"fmt"
 	"strings"
 
 	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
-func renderIssueTimelineSection(data gh.IssueData) string {
-	var b strings.Builder
-
-	b.WriteString("## Timeline\n")
-	if len(data.Timeline) == 0 {
-		b.WriteString("- none\n")
-		return b.String()
-	}
-
-	for _, event := range data.Timeline {
-		fmt.Fprintf(&b, "- %s | %s | %s | %s\n", event.CreatedAt, event.EventType, event.Actor, event.Details)
-	}
-
-	return b.String()
-}
-
-func renderIssueThreadSection(data gh.IssueData, includeComments bool) string {
+func renderIssueThreadSection(data gh.IssueData, opts RenderOptions) string {
 	var b strings.Builder
 
 	b.WriteString("## Discussion Thread\n")
-	if !includeComments {
+	if !opts.IncludeComments {
 		b.WriteString("Comments omitted (--include-comments=false).\n")
 		return b.String()
 	}
@@ -36,6 +19,7 @@ func renderIssueThreadSection(data gh.IssueData, includeComments bool) string {
 		return b.String()
 	}
 
-	writeCommentList(&b, data.Thread, 0)
+	skipped := writeCommentList(&b, data.Thread, 0, opts)
+	b.WriteString(skippedMinimizedNote(skipped))
 	return b.String()
 }
@@ -0,0 +1,81 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// MinimizedMode selects how comments that moderators minimized are rendered.
+type MinimizedMode string
+
+const (
+	// MinimizedCollapse keeps minimized comments in place with their body in a collapsed block.
+	// It is the default for an empty mode.
+	MinimizedCollapse MinimizedMode = "collapse"
+	// MinimizedSkip leaves minimized comments, and their replies, out of the document.
+	MinimizedSkip MinimizedMode = "skip"
+	// MinimizedShow renders minimized comments like any other, with a minimized note.
+	MinimizedShow MinimizedMode = "show"
+)
+
+// authorBadge returns the " [owner]"-style badge for repository maintainers, or "".
+func authorBadge(association string) string {
+	switch association {
+	case "OWNER", "MEMBER", "COLLABORATOR":
+		return " [" + strings.ToLower(association) + "]"
+	default:
+		return ""
+	}
+}
+
+// minimizedAnnotation returns "minimized as <reason>", or "" for comments shown as posted.
+func minimizedAnnotation(comment gh.CommentNode) string {
+	switch {
+	case !comment.IsMinimized:
+		return ""
+	case comment.MinimizedReason == "":
+		return "minimized"
+	default:
+		return "minimized as " + comment.MinimizedReason
+	}
+}
+
+// collapsesBody reports whether the comment body is moved into a collapsed block.
+func collapsesBody(comment gh.CommentNode, opts RenderOptions) bool {
+	return comment.IsMinimized && opts.Minimized != MinimizedShow
+}
+
+// writeCommentItem writes one comment nested depth levels deep: a list item with its collapsed
+// body and edit diffs nested under it, or a quoted block under CommentStyleBlock. It reports
+// false when the comment is skipped as minimized.
+func writeCommentItem(b *strings.Builder, comment gh.CommentNode, depth int, opts RenderOptions) bool {
+	if comment.IsMinimized && opts.Minimized == MinimizedSkip {
+		return false
+	}
+	if opts.CommentStyle == CommentStyleBlock {
+		writeCommentBlock(b, comment, depth, opts)
+		return true
+	}
+	indent := strings.Repeat("  ", depth)
+	fmt.Fprintf(b, "%s- %s\n", indent, commentLine(comment, opts))
+	if collapsesBody(comment, opts) {
+		nested := indent + "  "
+		fmt.Fprintf(b, "\n%s<details>\n%s<summary>Show minimized comment</summary>\n\n", nested, nested)
+		for _, line := range strings.Split(strings.TrimRight(comment.Body, "\n"), "\n") {
+			fmt.Fprintf(b, "%s%s\n", nested, line)
+		}
+		fmt.Fprintf(b, "\n%s</details>\n\n", nested)
+	}
+	writeCommentEdits(b, comment.Edits, indent, opts)
+	return true
+}
+
+// skippedMinimizedNote reports how many minimized comments a section left out.
+func skippedMinimizedNote(skipped int) string {
+	if skipped == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n%d minimized comment(s) hidden (--minimized-comments=skip).\n", skipped)
+}
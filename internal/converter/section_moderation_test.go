@@ -0,0 +1,89 @@
+package converter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func TestRenderMinimizedComments(t *testing.T) {
+	t.Parallel()
+
+	data := sampleIssueData()
+	data.Thread[0].AuthorAssociation = "MEMBER"
+	data.Thread[1].Author = gh.GhostLogin
+	data.Thread[1].IsMinimized = true
+	data.Thread[1].MinimizedReason = "off-topic"
+
+	tcs := []struct {
+		name    string
+		mode    MinimizedMode
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "default collapses",
+			want: []string{
+				"- bob [member] (2026-01-01T12:00:00Z): I can reproduce this.",
+				"- ghost (2026-01-01T13:00:00Z, minimized as off-topic)\n\n  <details>\n  <summary>Show minimized comment</summary>\n\n  Fixed in #124?\n\n  </details>\n",
+			},
+			notWant: []string{"ghost (2026-01-01T13:00:00Z, minimized as off-topic): ", "hidden (--minimized-comments=skip)"},
+		},
+		{
+			name:    "skip",
+			mode:    MinimizedSkip,
+			want:    []string{"\n1 minimized comment(s) hidden (--minimized-comments=skip).\n"},
+			notWant: []string{"Fixed in #124?"},
+		},
+		{
+			name:    "show",
+			mode:    MinimizedShow,
+			want:    []string{"- ghost (2026-01-01T13:00:00Z, minimized as off-topic): Fixed in #124?\n"},
+			notWant: []string{"<details>"},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := NewRenderer(nil).Render(context.Background(), data, RenderOptions{IncludeComments: true, Minimized: tc.mode})
+			if err != nil {
+				t.Fatalf("Render error = %v, want nil", err)
+			}
+			for _, piece := range tc.want {
+				if !strings.Contains(string(out), piece) {
+					t.Fatalf("output missing %q\n%s", piece, out)
+				}
+			}
+			for _, piece := range tc.notWant {
+				if strings.Contains(string(out), piece) {
+					t.Fatalf("output contains %q\n%s", piece, out)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthorBadge(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		association string
+		want        string
+	}{
+		{association: "OWNER", want: " [owner]"},
+		{association: "MEMBER", want: " [member]"},
+		{association: "COLLABORATOR", want: " [collaborator]"},
+		{association: "CONTRIBUTOR", want: ""},
+		{association: "NONE", want: ""},
+		{association: "", want: ""},
+	}
+	for _, tc := range tcs {
+		if got := authorBadge(tc.association); got != tc.want {
+			t.Errorf("authorBadge(%q) = %q, want %q", tc.association, got, tc.want)
+		}
+	}
+}
@@ -3,15 +3,15 @@ package converter
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
-func renderPRReviewsSection(data gh.IssueData, includeComments bool) string {
+func renderPRReviewsSection(data gh.IssueData, opts RenderOptions) string {
 	var b strings.Builder
-
 	b.WriteString("## Reviews\n")
-	if !includeComments {
+	if !opts.IncludeComments {
 		b.WriteString("Reviews omitted (--include-comments=false).\n")
 		return b.String()
 	}
@@ -20,21 +20,109 @@ func renderPRReviewsSection(data gh.IssueData, includeComments bool) string {
 		return b.String()
 	}
 
+	hasThreads := false
+	for _, review := range data.Reviews {
+		if len(review.Threads) > 0 {
+			hasThreads = true
+			break
+		}
+	}
+
+	skipped := 0
 	for _, review := range data.Reviews {
-		fmt.Fprintf(&b, "- %s by %s at %s: %s\n", review.State, review.Author, review.CreatedAt, review.Body)
+		if opts.CommentStyle == CommentStyleBlock {
+			writeReviewBlock(&b, review, opts)
+		} else {
+			fmt.Fprintf(&b, "- %s by %s at %s: %s\n", review.State, review.Author, review.CreatedAt, review.Body)
+		}
+		if hasThreads {
+			// Thread-grouped comments are rendered below with their code anchors.
+			continue
+		}
 		for _, comment := range review.Comments {
-			fmt.Fprintf(&b, "  - %s (%s): %s\n", comment.Author, comment.CreatedAt, comment.Body)
+			if !writeCommentItem(&b, comment, 1, opts) {
+				skipped++
+			}
 		}
 	}
+	b.WriteString(skippedMinimizedNote(skipped))
+	if hasThreads {
+		b.WriteString(renderPRReviewThreads(data.Reviews, opts))
+	}
+	return b.String()
+}
 
+func renderPRReviewThreads(reviews []gh.ReviewData, opts RenderOptions) string {
+	var b strings.Builder
+	b.WriteString("\n### Review Threads\n")
+	hidden, skipped := 0, 0
+	for _, review := range reviews {
+		for _, thread := range review.Threads {
+			if opts.HideResolvedThreads && thread.IsResolved {
+				hidden++
+				continue
+			}
+			fmt.Fprintf(&b, "\n#### %s (%s)\n", thread.Path, describeThreadAnchor(thread))
+			fmt.Fprintf(&b, "- review: %s by %s\n", review.State, review.Author)
+			fmt.Fprintf(&b, "- status: %s\n", describeThreadStatus(thread))
+			if thread.DiffHunk != "" {
+				fence := codeFence(thread.DiffHunk)
+				fmt.Fprintf(&b, "\n%sdiff\n%s\n%s\n\n", fence, strings.TrimRight(thread.DiffHunk, "\n"), fence)
+			}
+			for idx, comment := range thread.Comments {
+				// Follow-up comments reply to the one that opened the thread.
+				if !writeCommentItem(&b, comment, min(idx, 1), opts) {
+					skipped++
+				}
+			}
+		}
+	}
+	if hidden > 0 {
+		fmt.Fprintf(&b, "\n%d resolved thread(s) hidden (--hide-resolved-threads).\n", hidden)
+	}
+	b.WriteString(skippedMinimizedNote(skipped))
 	return b.String()
 }
 
-func renderPRThreadSection(data gh.IssueData, includeComments bool) string {
+func describeThreadAnchor(thread gh.ReviewThread) string {
+	var anchor string
+	switch {
+	case thread.Line == 0 && thread.OriginalLine > 0:
+		anchor = fmt.Sprintf("original line %d", thread.OriginalLine)
+	case thread.StartLine > 0 && thread.StartLine != thread.Line:
+		anchor = fmt.Sprintf("lines %d-%d", thread.StartLine, thread.Line)
+	case thread.Line > 0:
+		anchor = fmt.Sprintf("line %d", thread.Line)
+	default:
+		anchor = "file"
+	}
+	if thread.Side != "" {
+		anchor += ", " + thread.Side
+	}
+	return anchor
+}
+
+func describeThreadStatus(thread gh.ReviewThread) string {
+	parts := make([]string, 0, 2)
+	switch {
+	case thread.IsResolved && thread.ResolvedBy != "":
+		parts = append(parts, "resolved by "+thread.ResolvedBy)
+	case thread.IsResolved:
+		parts = append(parts, "resolved")
+	default:
+		parts = append(parts, "unresolved")
+	}
+	if thread.IsOutdated {
+		parts = append(parts, "outdated")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func renderPRThreadSection(data gh.IssueData, opts RenderOptions) string {
 	var b strings.Builder
 
 	b.WriteString("## Discussion Thread\n")
-	if !includeComments {
+	if !opts.IncludeComments {
 		b.WriteString("Comments omitted (--include-comments=false).\n")
 		return b.String()
 	}
@@ -43,6 +131,189 @@ func renderPRThreadSection(data gh.IssueData, includeComments bool) string {
 		return b.String()
 	}
 
-	writeCommentList(&b, data.Thread, 0)
+	skipped := writeCommentList(&b, data.Thread, 0, opts)
+	b.WriteString(skippedMinimizedNote(skipped))
+	return b.String()
+}
+
+func renderPRCommitsSection(data gh.IssueData, includeCommits bool) string {
+	var b strings.Builder
+	b.WriteString("## Commits\n")
+	if !includeCommits {
+		b.WriteString("Commits omitted (--include-commits=false).\n")
+		return b.String()
+	}
+	if len(data.Commits) == 0 {
+		b.WriteString("- none\n")
+		return b.String()
+	}
+	for _, commit := range data.Commits {
+		fmt.Fprintf(&b, "- %s %s %s: %s\n", shortSHA(commit.SHA), commit.CommittedAt, commit.Author, commit.Headline)
+	}
+	return b.String()
+}
+
+func renderPRChecksSection(data gh.IssueData, includeChecks bool) string {
+	var b strings.Builder
+	b.WriteString("## Checks\n")
+	if !includeChecks {
+		b.WriteString("Checks omitted (--include-checks=false).\n")
+		return b.String()
+	}
+	if data.ChecksUnavailable != "" {
+		fmt.Fprintf(&b, "Checks unavailable (%s); the token may lack checks or commit status access.\n", data.ChecksUnavailable)
+		return b.String()
+	}
+	if len(data.Checks) == 0 {
+		b.WriteString("- none\n")
+		return b.String()
+	}
+
+	counts := map[string]int{}
+	for _, check := range data.Checks {
+		counts[checkOutcome(check)]++
+	}
+	fmt.Fprintf(&b, "%d checks on %s: %d passed, %d failed, %d pending\n\n",
+		len(data.Checks), shortSHA(data.Meta.HeadSHA), counts[checkPassed], counts[checkFailed], counts[checkPending])
+	b.WriteString("| Check | Source | Result | Details |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, check := range data.Checks {
+		result := check.Conclusion
+		if result == "" {
+			result = check.Status
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", tableCell(check.Name), check.Source, tableCell(result), tableCell(check.URL))
+	}
+	return b.String()
+}
+
+const (
+	checkPassed  = "passed"
+	checkFailed  = "failed"
+	checkPending = "pending"
+)
+
+// checkOutcome folds check run conclusions and commit status states into one verdict.
+func checkOutcome(check gh.CheckResult) string {
+	if check.Source == "status" {
+		switch check.Conclusion {
+		case "success":
+			return checkPassed
+		case "pending", "":
+			return checkPending
+		default:
+			return checkFailed
+		}
+	}
+	if check.Status != "completed" {
+		return checkPending
+	}
+	switch check.Conclusion {
+	case "success", "neutral", "skipped":
+		return checkPassed
+	default:
+		return checkFailed
+	}
+}
+
+// checksPassed reports whether every check passed; ok is false when no checks were reported.
+func checksPassed(checks []gh.CheckResult) (passed, ok bool) {
+	if len(checks) == 0 {
+		return false, false
+	}
+	for _, check := range checks {
+		if checkOutcome(check) != checkPassed {
+			return false, true
+		}
+	}
+	return true, true
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func tableCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// maxTotalPatchBytes bounds the sum of embedded patches so huge pull requests
+// still produce a readable document.
+const maxTotalPatchBytes = 512 * 1024
+
+func renderPRFilesSection(data gh.IssueData, opts RenderOptions) string {
+	var b strings.Builder
+	b.WriteString("## Changed Files\n")
+	if !opts.IncludeFiles {
+		b.WriteString("Changed files omitted (--include-files=false).\n")
+		return b.String()
+	}
+	if len(data.Files) == 0 {
+		b.WriteString("- none\n")
+		return b.String()
+	}
+
+	additions, deletions := 0, 0
+	for _, file := range data.Files {
+		additions += file.Additions
+		deletions += file.Deletions
+	}
+	fmt.Fprintf(&b, "%d files changed, +%d -%d\n\n", len(data.Files), additions, deletions)
+	for _, file := range data.Files {
+		fmt.Fprintf(&b, "- %s %s (+%d -%d)\n", file.Status, describeFileName(file), file.Additions, file.Deletions)
+	}
+	if !opts.IncludePatches {
+		return b.String()
+	}
+
+	maxPatchBytes := opts.MaxPatchBytes
+	if maxPatchBytes <= 0 {
+		maxPatchBytes = DefaultMaxPatchBytes
+	}
+	budget := maxTotalPatchBytes
+	for _, file := range data.Files {
+		fmt.Fprintf(&b, "\n### %s\n", file.Filename)
+		switch {
+		case file.Patch == "":
+			b.WriteString("Patch unavailable (binary or too large).\n")
+		case budget <= 0:
+			b.WriteString("Patch omitted (total patch size limit reached).\n")
+		default:
+			patch, truncated := truncatePatch(file.Patch, min(maxPatchBytes, budget))
+			budget -= len(patch)
+			fence := codeFence(patch)
+			fmt.Fprintf(&b, "%sdiff\n%s\n%s\n", fence, patch, fence)
+			if truncated {
+				fmt.Fprintf(&b, "Patch truncated at %d bytes.\n", len(patch))
+			}
+		}
+	}
 	return b.String()
 }
+
+func describeFileName(file gh.ChangedFile) string {
+	if file.PreviousFilename != "" && file.PreviousFilename != file.Filename {
+		return fmt.Sprintf("%s → %s", file.PreviousFilename, file.Filename)
+	}
+	return file.Filename
+}
+
+// truncatePatch cuts patch to at most limit bytes on a line boundary.
+func truncatePatch(patch string, limit int) (string, bool) {
+	patch = strings.TrimRight(patch, "\n")
+	if len(patch) <= limit {
+		return patch, false
+	}
+	cut := strings.LastIndexByte(patch[:limit], '\n')
+	if cut <= 0 {
+		cut = limit
+		for cut > 0 && !utf8.RuneStart(patch[cut]) {
+			cut--
+		}
+	}
+	return patch[:cut], true
+}
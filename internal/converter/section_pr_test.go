@@ -3,17 +3,27 @@ package converter
 import (
 	"strings"
 	"testing"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
 )
 
 func TestRenderPRReviewsSection(t *testing.T) {
 	t.Parallel()
 
-	out := renderPRReviewsSection(samplePRData(), true)
+	out := renderPRReviewsSection(samplePRData(), RenderOptions{IncludeComments: true})
 	expected := []string{
 		"## Reviews",
 		"- APPROVED by bob at 2026-01-03T12:00:00Z: Looks good.",
 		"- CHANGES_REQUESTED by carol at 2026-01-03T13:00:00Z: Need edge case coverage.",
-		"Please add test.",
+		"### Review Threads",
+		"#### internal/config/loader.go (lines 11-13, RIGHT)",
+		"- review: APPROVED by bob",
+		"- status: resolved by alice",
+		"```diff\n@@ -10,3 +10,6 @@ func Load() {",
+		"- bob (2026-01-03T12:10:00Z): Please add test.",
+		"  - alice (2026-01-03T12:20:00Z): Added in the next commit.",
+		"#### README.md (original line 4, LEFT)",
+		"- status: unresolved, outdated",
 	}
 	for _, piece := range expected {
 		if !strings.Contains(out, piece) {
@@ -25,15 +35,46 @@ func TestRenderPRReviewsSection(t *testing.T) {
 	}
 }
 
+func TestRenderPRReviewsSectionHideResolvedThreads(t *testing.T) {
+	t.Parallel()
+
+	out := renderPRReviewsSection(samplePRData(), RenderOptions{IncludeComments: true, HideResolvedThreads: true})
+	if strings.Contains(out, "Please add test.") {
+		t.Fatalf("resolved thread should be hidden:\n%s", out)
+	}
+	if !strings.Contains(out, "#### README.md") {
+		t.Fatalf("unresolved thread should remain:\n%s", out)
+	}
+	if !strings.Contains(out, "1 resolved thread(s) hidden (--hide-resolved-threads).") {
+		t.Fatalf("hidden thread note missing:\n%s", out)
+	}
+}
+
+func TestRenderPRReviewsSectionFlatCommentsWithoutThreads(t *testing.T) {
+	t.Parallel()
+
+	data := samplePRData()
+	for idx := range data.Reviews {
+		data.Reviews[idx].Threads = nil
+	}
+	out := renderPRReviewsSection(data, RenderOptions{IncludeComments: true})
+	if !strings.Contains(out, "  - bob (2026-01-03T12:10:00Z): Please add test.") {
+		t.Fatalf("flat review comment missing:\n%s", out)
+	}
+	if strings.Contains(out, "### Review Threads") {
+		t.Fatalf("thread section should be absent without threads:\n%s", out)
+	}
+}
+
 func TestRenderPRSectionsIncludeCommentsOption(t *testing.T) {
 	t.Parallel()
 
-	withComments := renderPRReviewsSection(samplePRData(), true)
+	withComments := renderPRReviewsSection(samplePRData(), RenderOptions{IncludeComments: true})
 	if !strings.Contains(withComments, "Please add test.") {
 		t.Fatalf("review thread comment missing when includeComments=true:\n%s", withComments)
 	}
 
-	withoutComments := renderPRReviewsSection(samplePRData(), false)
+	withoutComments := renderPRReviewsSection(samplePRData(), RenderOptions{})
 	if !strings.Contains(withoutComments, "Reviews omitted (--include-comments=false).") {
 		t.Fatalf("reviews should include omitted note:\n%s", withoutComments)
 	}
@@ -41,3 +82,166 @@ func TestRenderPRSectionsIncludeCommentsOption(t *testing.T) {
 		t.Fatalf("reviews should be omitted when includeComments=false:\n%s", withoutComments)
 	}
 }
+
+func TestRenderPRFilesSection(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		opts    RenderOptions
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "omitted",
+			opts: RenderOptions{},
+			want: []string{"## Changed Files", "Changed files omitted (--include-files=false)."},
+			notWant: []string{
+				"internal/config/loader.go",
+			},
+		},
+		{
+			name: "list only",
+			opts: RenderOptions{IncludeFiles: true},
+			want: []string{
+				"2 files changed, +3 -0",
+				"- modified internal/config/loader.go (+3 -0)",
+				"- renamed internal/config/nil_test.go → internal/config/loader_nil_test.go (+0 -0)",
+			},
+			notWant: []string{"```diff"},
+		},
+		{
+			name: "with patches",
+			opts: RenderOptions{IncludeFiles: true, IncludePatches: true},
+			want: []string{
+				"### internal/config/loader.go\n```diff\n@@ -10,3 +10,6 @@",
+				"### internal/config/loader_nil_test.go\nPatch unavailable (binary or too large).",
+			},
+			notWant: []string{"Patch truncated"},
+		},
+		{
+			name: "truncated patch",
+			opts: RenderOptions{IncludeFiles: true, IncludePatches: true, MaxPatchBytes: 40},
+			want: []string{
+				"```diff\n@@ -10,3 +10,6 @@ func Load() {\n```",
+				"Patch truncated at 31 bytes.",
+			},
+			notWant: []string{"+\tif cfg == nil"},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			out := renderPRFilesSection(samplePRData(), tc.opts)
+			for _, piece := range tc.want {
+				if !strings.Contains(out, piece) {
+					t.Fatalf("files section missing %q\n%s", piece, out)
+				}
+			}
+			for _, piece := range tc.notWant {
+				if strings.Contains(out, piece) {
+					t.Fatalf("files section should not contain %q\n%s", piece, out)
+				}
+			}
+		})
+	}
+}
+
+func TestCodeFenceOutgrowsBodyBackticks(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		body string
+		want string
+	}{
+		{body: "plain", want: "```"},
+		{body: "inline `code`", want: "```"},
+		{body: "```go\nx\n```", want: "````"},
+		{body: "`````", want: "``````"},
+	}
+	for _, tc := range tcs {
+		if got := codeFence(tc.body); got != tc.want {
+			t.Fatalf("codeFence(%q) = %q, want %q", tc.body, got, tc.want)
+		}
+	}
+}
+
+func TestRenderPRCommitsSection(t *testing.T) {
+	t.Parallel()
+
+	out := renderPRCommitsSection(samplePRData(), true)
+	for _, piece := range []string{
+		"## Commits",
+		"- 1a2b3c4 2026-01-03T08:00:00Z alice: Guard nil config",
+		"- 9f8e7d6 2026-01-03T12:30:00Z alice: Add regression test",
+	} {
+		if !strings.Contains(out, piece) {
+			t.Fatalf("commits section missing %q\n%s", piece, out)
+		}
+	}
+
+	omitted := renderPRCommitsSection(samplePRData(), false)
+	if !strings.Contains(omitted, "Commits omitted (--include-commits=false).") {
+		t.Fatalf("commits section should include omitted note:\n%s", omitted)
+	}
+}
+
+func TestRenderPRChecksSection(t *testing.T) {
+	t.Parallel()
+
+	out := renderPRChecksSection(samplePRData(), true)
+	for _, piece := range []string{
+		"## Checks",
+		"3 checks on 9f8e7d6: 3 passed, 0 failed, 0 pending",
+		"| build | check_run | success | https://github.com/octo/repo/runs/1 |",
+		"| lint \\| vet | check_run | skipped |  |",
+	} {
+		if !strings.Contains(out, piece) {
+			t.Fatalf("checks section missing %q\n%s", piece, out)
+		}
+	}
+
+	unavailable := samplePRData()
+	unavailable.Checks = nil
+	unavailable.ChecksUnavailable = "http status 403"
+	if out := renderPRChecksSection(unavailable, true); !strings.Contains(out, "Checks unavailable (http status 403);") {
+		t.Fatalf("checks section should include unavailable note:\n%s", out)
+	}
+}
+
+func TestChecksPassed(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		checks     []gh.CheckResult
+		wantPassed bool
+		wantOK     bool
+	}{
+		{name: "no checks"},
+		{
+			name:       "all green",
+			checks:     []gh.CheckResult{{Source: "check_run", Status: "completed", Conclusion: "success"}, {Source: "status", Conclusion: "success"}},
+			wantPassed: true,
+			wantOK:     true,
+		},
+		{
+			name:   "failed status",
+			checks: []gh.CheckResult{{Source: "check_run", Status: "completed", Conclusion: "success"}, {Source: "status", Conclusion: "error"}},
+			wantOK: true,
+		},
+		{
+			name:   "run in progress",
+			checks: []gh.CheckResult{{Source: "check_run", Status: "in_progress"}},
+			wantOK: true,
+		},
+	}
+	for _, tc := range tcs {
+		passed, ok := checksPassed(tc.checks)
+		if passed != tc.wantPassed || ok != tc.wantOK {
+			t.Fatalf("%s: checksPassed = (%t,%t), want (%t,%t)", tc.name, passed, ok, tc.wantPassed, tc.wantOK)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// maxHighlightExcerptRunes caps the body excerpt shown in the Most-reacted Comments section.
+const maxHighlightExcerptRunes = 120
+
+// formatReactions renders non-zero reaction counts compactly, e.g. "👍 3 ❤️ 1".
+// Counts the API reported only in the total are appended as "+N other".
+func formatReactions(r gh.ReactionSummary) string {
+	counts := []struct {
+		emoji string
+		count int
+	}{
+		{"👍", r.PlusOne},
+		{"👎", r.MinusOne},
+		{"😄", r.Laugh},
+		{"🎉", r.Hooray},
+		{"😕", r.Confused},
+		{"❤️", r.Heart},
+		{"🚀", r.Rocket},
+		{"👀", r.Eyes},
+	}
+
+	parts := make([]string, 0, len(counts)+1)
+	listed := 0
+	for _, c := range counts {
+		if c.count <= 0 {
+			continue
+		}
+		listed += c.count
+		parts = append(parts, fmt.Sprintf("%s %d", c.emoji, c.count))
+	}
+	if other := r.Total - listed; other > 0 {
+		parts = append(parts, fmt.Sprintf("+%d other", other))
+	}
+	return strings.Join(parts, " ")
+}
+
+// reactionSuffix returns " [counts]" for a comment line, or "" when hidden or empty.
+func reactionSuffix(r gh.ReactionSummary, show bool) string {
+	if !show {
+		return ""
+	}
+	formatted := formatReactions(r)
+	if formatted == "" {
+		return ""
+	}
+	return " [" + formatted + "]"
+}
+
+// commentLine renders the shared "author (created_at): body" comment form, noting edits as
+// "author (created_at, edited N times): body" and badging maintainers as "author [member]".
+// Collapsed minimized comments end after the annotation; their body is written separately.
+func commentLine(comment gh.CommentNode, opts RenderOptions) string {
+	when := comment.CreatedAt
+	if edited := editAnnotation(comment.Edits); edited != "" {
+		when += ", " + edited
+	}
+	if minimized := minimizedAnnotation(comment); minimized != "" {
+		when += ", " + minimized
+	}
+	head := fmt.Sprintf("%s%s (%s)", comment.Author, authorBadge(comment.AuthorAssociation), when)
+	if collapsesBody(comment, opts) {
+		return head + reactionSuffix(comment.Reactions, opts.IncludeReactions)
+	}
+	return fmt.Sprintf("%s: %s%s", head, comment.Body, reactionSuffix(comment.Reactions, opts.IncludeReactions))
+}
+
+// renderTopReactedSection ranks comments by reactions; minimized ones only compete when shown.
+func renderTopReactedSection(data gh.IssueData, opts RenderOptions) string {
+	var b strings.Builder
+	b.WriteString("## Most-reacted Comments\n")
+
+	comments := collectComments(data)
+	if opts.Minimized != MinimizedShow {
+		visible := comments[:0]
+		for _, comment := range comments {
+			if !comment.IsMinimized {
+				visible = append(visible, comment)
+			}
+		}
+		comments = visible
+	}
+	ranked := rankByReactions(comments)
+	limit := opts.TopReactedComments
+	if len(ranked) == 0 {
+		b.WriteString("- none\n")
+		return b.String()
+	}
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	for _, comment := range ranked {
+		fmt.Fprintf(&b, "- %s (%s, %s): %s", comment.Author, comment.CreatedAt, formatReactions(comment.Reactions), excerpt(comment.Body))
+		if comment.URL != "" {
+			fmt.Fprintf(&b, " (%s)", comment.URL)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// collectComments flattens thread comments, replies and review comments into one list.
+func collectComments(data gh.IssueData) []gh.CommentNode {
+	var out []gh.CommentNode
+	var walk func(nodes []gh.CommentNode)
+	walk = func(nodes []gh.CommentNode) {
+		for _, node := range nodes {
+			out = append(out, node)
+			walk(node.Replies)
+		}
+	}
+	walk(data.Thread)
+	for _, review := range data.Reviews {
+		walk(review.Comments)
+	}
+	return out
+}
+
+// rankByReactions keeps reacted comments ordered by total reactions, oldest first on ties.
+func rankByReactions(comments []gh.CommentNode) []gh.CommentNode {
+	ranked := make([]gh.CommentNode, 0, len(comments))
+	for _, comment := range comments {
+		if comment.Reactions.Total > 0 {
+			ranked = append(ranked, comment)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Reactions.Total != ranked[j].Reactions.Total {
+			return ranked[i].Reactions.Total > ranked[j].Reactions.Total
+		}
+		return ranked[i].CreatedAt < ranked[j].CreatedAt
+	})
+	return ranked
+}
+
+// excerpt returns the first line of body, capped at maxHighlightExcerptRunes.
+func excerpt(body string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(body), "\n")
+	line = strings.TrimSpace(line)
+	if utf8.RuneCountInString(line) <= maxHighlightExcerptRunes {
+		return line
+	}
+	runes := []rune(line)
+	return string(runes[:maxHighlightExcerptRunes]) + "…"
+}
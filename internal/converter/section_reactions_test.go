@@ -0,0 +1,76 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func TestFormatReactions(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name string
+		in   gh.ReactionSummary
+		want string
+	}{
+		{name: "empty", in: gh.ReactionSummary{}, want: ""},
+		{name: "ordered counts", in: gh.ReactionSummary{Heart: 1, PlusOne: 3, Eyes: 2, Total: 6}, want: "👍 3 ❤️ 1 👀 2"},
+		{name: "total beyond breakdown", in: gh.ReactionSummary{PlusOne: 1, Total: 3}, want: "👍 1 +2 other"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := formatReactions(tc.in); got != tc.want {
+				t.Fatalf("formatReactions() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCommentLineReactionSuffix(t *testing.T) {
+	t.Parallel()
+
+	comment := gh.CommentNode{Author: "bob", CreatedAt: "2026-01-01T00:00:00Z", Body: "+1", Reactions: gh.ReactionSummary{Rocket: 2, Total: 2}}
+	if got, want := commentLine(comment, RenderOptions{IncludeReactions: true}), "bob (2026-01-01T00:00:00Z): +1 [🚀 2]"; got != want {
+		t.Fatalf("commentLine(show) = %q, want %q", got, want)
+	}
+	if got, want := commentLine(comment, RenderOptions{}), "bob (2026-01-01T00:00:00Z): +1"; got != want {
+		t.Fatalf("commentLine(hide) = %q, want %q", got, want)
+	}
+}
+
+func TestRenderTopReactedSection(t *testing.T) {
+	t.Parallel()
+
+	data := samplePRData()
+	data.Thread = []gh.CommentNode{
+		{Author: "low", CreatedAt: "2026-01-01T00:00:00Z", Body: "meh", Reactions: gh.ReactionSummary{PlusOne: 1, Total: 1}},
+		{Author: "none", CreatedAt: "2026-01-01T00:30:00Z", Body: "silent"},
+		{
+			Author:    "high",
+			CreatedAt: "2026-01-01T01:00:00Z",
+			Body:      "first line\nsecond line",
+			Reactions: gh.ReactionSummary{Heart: 5, Total: 5},
+		},
+	}
+	data.Reviews[0].Comments[0].Reactions = gh.ReactionSummary{Hooray: 3, Total: 3}
+
+	out := renderTopReactedSection(data, RenderOptions{TopReactedComments: 2})
+	high := strings.Index(out, "- high (2026-01-01T01:00:00Z, ❤️ 5): first line\n")
+	review := strings.Index(out, "🎉 3")
+	if high < 0 || review < 0 || high > review {
+		t.Fatalf("most-reacted section should list high then review comment:\n%s", out)
+	}
+	if strings.Contains(out, "second line") || strings.Contains(out, "low") || strings.Contains(out, "silent") {
+		t.Fatalf("most-reacted section should keep first lines of the top 2 only:\n%s", out)
+	}
+
+	empty := renderTopReactedSection(sampleDiscussionData(), RenderOptions{TopReactedComments: 3})
+	if !strings.Contains(empty, "- none\n") {
+		t.Fatalf("most-reacted section without reactions should print none:\n%s", empty)
+	}
+}
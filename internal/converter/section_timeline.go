@@ -0,0 +1,24 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func renderTimelineSection(data gh.IssueData) string {
+	var b strings.Builder
+
+	b.WriteString("## Timeline\n")
+	if len(data.Timeline) == 0 {
+		b.WriteString("- none\n")
+		return b.String()
+	}
+
+	for _, event := range data.Timeline {
+		fmt.Fprintf(&b, "- %s | %s | %s | %s\n", event.CreatedAt, event.EventType, event.Actor, event.Details)
+	}
+
+	return b.String()
+}
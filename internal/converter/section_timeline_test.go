@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTimelineSection(t *testing.T) {
+	t.Parallel()
+
+	out := renderTimelineSection(sampleIssueData())
+	expected := []string{
+		"## Timeline",
+		"- 2026-01-01T10:00:00Z | opened | alice | Issue opened",
+		"- 2026-01-01T10:30:00Z | labeled | bot | bug",
+	}
+	for _, piece := range expected {
+		if !strings.Contains(out, piece) {
+			t.Fatalf("timeline missing %q\n%s", piece, out)
+		}
+	}
+}
+
+func TestRenderTimelineSectionPullRequestEvents(t *testing.T) {
+	t.Parallel()
+
+	out := renderTimelineSection(samplePRData())
+	expected := []string{
+		"- 2026-01-03T09:00:00Z | opened | alice | ",
+		"- 2026-01-03T11:00:00Z | head_ref_force_pushed | alice | 1a2b3c4 → 9f8e7d6",
+		"- 2026-01-04T09:30:00Z | merged | bob | 9f8e7d6 into main",
+	}
+	for _, piece := range expected {
+		if !strings.Contains(out, piece) {
+			t.Fatalf("timeline missing %q\n%s", piece, out)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// Format identifies the kind of document a Renderer produces.
+type Format string
+
+const (
+	// FormatMarkdown is the default markdown document.
+	FormatMarkdown Format = "markdown"
+	// FormatJSON is a Snapshot of the fetched data that the render command turns back into
+	// any other format offline.
+	FormatJSON Format = "json"
+	// FormatHTML is a self-contained HTML page with sanitized bodies and embedded styles.
+	FormatHTML Format = "html"
+	// FormatJSONL writes one Chunk per line for retrieval and LLM ingestion pipelines.
+	FormatJSONL Format = "jsonl"
+)
+
+// SnapshotSchemaVersion is the schema_version written to snapshots. It changes only when a
+// field is renamed, removed or changes meaning; added fields keep the version.
+const SnapshotSchemaVersion = 1
+
+// snapshotToolName identifies issue2md as the snapshot writer.
+const snapshotToolName = "issue2md"
+
+// ErrUnsupportedSnapshot indicates a snapshot without a schema version this build can read.
+var ErrUnsupportedSnapshot = errors.New("unsupported snapshot schema version")
+
+// Snapshot is the versioned --format json document. Data holds everything that was fetched,
+// independent of render options, with the field names of the gh types' JSON tags. Summary is
+// the AI summary as produced at export time, including a "skipped" status, and is omitted when
+// summarization was not configured.
+type Snapshot struct {
+	Summary       *Summary     `json:"summary,omitempty"`
+	Tool          SnapshotTool `json:"tool"`
+	Data          gh.IssueData `json:"data"`
+	SchemaVersion int          `json:"schema_version"`
+}
+
+// SnapshotTool records the program that wrote a snapshot. Version is the module version, or
+// "devel" for builds outside a tagged module.
+type SnapshotTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// DecodeSnapshot parses a snapshot written by any build sharing its schema version.
+func DecodeSnapshot(raw []byte) (Snapshot, error) {
+	var snapshot Snapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("decode snapshot: %w", err)
+	}
+	if snapshot.SchemaVersion != SnapshotSchemaVersion {
+		return Snapshot{}, fmt.Errorf("decode snapshot: %w %d (want %d)", ErrUnsupportedSnapshot, snapshot.SchemaVersion, SnapshotSchemaVersion)
+	}
+	if snapshot.Data.Meta.Type == "" {
+		return Snapshot{}, fmt.Errorf("decode snapshot: missing metadata.type")
+	}
+	return snapshot, nil
+}
+
+// NewSnapshotSummarizer replays the summary stored in a snapshot, so re-rendering needs no
+// network access. It returns nil when the snapshot has none, which leaves the summary out
+// exactly as the original export did.
+func NewSnapshotSummarizer(snapshot Snapshot) Summarizer {
+	if snapshot.Summary == nil {
+		return nil
+	}
+	return storedSummarizer{summary: *snapshot.Summary}
+}
+
+type storedSummarizer struct {
+	summary Summary
+}
+
+func (s storedSummarizer) Summarize(context.Context, gh.IssueData, string) (Summary, error) {
+	return s.summary, nil
+}
+
+func renderSnapshot(data gh.IssueData, summary *Summary) ([]byte, error) {
+	out, err := json.MarshalIndent(Snapshot{
+		SchemaVersion: SnapshotSchemaVersion,
+		Tool:          SnapshotTool{Name: snapshotToolName, Version: toolVersion()},
+		Summary:       summary,
+		Data:          data,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("render snapshot: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+func toolVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "devel"
+}
@@ -0,0 +1,97 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		summarizer  Summarizer
+		data        gh.IssueData
+		name        string
+		wantSummary bool
+	}{
+		{name: "issue with summary", data: sampleIssueData(), summarizer: &stubSummarizer{summary: fixedSummary()}, wantSummary: true},
+		{name: "failed summary", data: samplePRData(), summarizer: &stubSummarizer{err: errors.New("quota exceeded")}, wantSummary: true},
+		{name: "no summarizer", data: sampleDiscussionData()},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			opts := RenderOptions{IncludeComments: true, IncludeSummary: true, IncludeFiles: true, IncludeCommits: true, IncludeChecks: true}
+			renderer := NewRenderer(tc.summarizer)
+
+			jsonOpts := opts
+			jsonOpts.Format = FormatJSON
+			raw, err := renderer.Render(context.Background(), tc.data, jsonOpts)
+			if err != nil {
+				t.Fatalf("Render(json) error = %v, want nil", err)
+			}
+			snapshot, err := DecodeSnapshot(raw)
+			if err != nil {
+				t.Fatalf("DecodeSnapshot error = %v, want nil", err)
+			}
+			if snapshot.SchemaVersion != SnapshotSchemaVersion || snapshot.Tool.Name != "issue2md" || snapshot.Tool.Version == "" {
+				t.Fatalf("snapshot header = (%d, %+v), want current schema and tool", snapshot.SchemaVersion, snapshot.Tool)
+			}
+			if got := snapshot.Summary != nil; got != tc.wantSummary {
+				t.Fatalf("snapshot has summary = %v, want %v", got, tc.wantSummary)
+			}
+			if !reflect.DeepEqual(snapshot.Data, tc.data) {
+				t.Fatalf("snapshot data = %#v, want %#v", snapshot.Data, tc.data)
+			}
+
+			want, err := renderer.Render(context.Background(), tc.data, opts)
+			if err != nil {
+				t.Fatalf("Render(markdown) error = %v, want nil", err)
+			}
+			got, err := NewRenderer(NewSnapshotSummarizer(snapshot)).Render(context.Background(), snapshot.Data, opts)
+			if err != nil {
+				t.Fatalf("Render(snapshot) error = %v, want nil", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("re-rendered markdown differs\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeSnapshotErrors(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		raw     string
+		wantErr error
+		wantMsg string
+	}{
+		{name: "not json", raw: "# markdown", wantMsg: "decode snapshot"},
+		{name: "missing version", raw: `{"data":{"metadata":{"type":"issue"}}}`, wantErr: ErrUnsupportedSnapshot},
+		{name: "future version", raw: `{"schema_version":99,"data":{"metadata":{"type":"issue"}}}`, wantErr: ErrUnsupportedSnapshot},
+		{name: "missing type", raw: `{"schema_version":1,"data":{}}`, wantMsg: "missing metadata.type"},
+	}
+
+	for _, tc := range tcs {
+		_, err := DecodeSnapshot([]byte(tc.raw))
+		if err == nil {
+			t.Fatalf("%s: DecodeSnapshot error = nil, want error", tc.name)
+		}
+		if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+			t.Fatalf("%s: DecodeSnapshot error = %v, want %v", tc.name, err, tc.wantErr)
+		}
+		if !strings.Contains(err.Error(), tc.wantMsg) {
+			t.Fatalf("%s: DecodeSnapshot error = %v, want it to mention %q", tc.name, err, tc.wantMsg)
+		}
+	}
+}
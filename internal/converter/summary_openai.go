@@ -24,12 +24,12 @@ const (
 
 // Summary holds the normalized AI summary payload for markdown rendering.
 type Summary struct {
-	Summary      string
-	Language     string
-	Status       string
-	Reason       string
-	KeyDecisions []string
-	ActionItems  []string
+	Summary      string   `json:"summary,omitempty"`
+	Language     string   `json:"language,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Reason       string   `json:"reason,omitempty"`
+	KeyDecisions []string `json:"key_decisions,omitempty"`
+	ActionItems  []string `json:"action_items,omitempty"`
 }
 
 // Summarizer defines the AI summary capability used by renderer.
@@ -0,0 +1,185 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// DefaultTemplate is the built-in markdown layout. It renders the same document as issue2md
+// without --template and is a starting point for custom layouts.
+const DefaultTemplate = `{{frontMatter .}}# {{.Meta.Title}}
+
+{{metadataSection .}}
+{{- if .Summary.Summary}}
+{{summarySection .}}
+{{- end}}
+## Original Description
+
+{{if trim .Description}}{{.Description}}{{else}}(empty){{end}}
+{{descriptionEdits .}}
+{{- if and .Options.IncludeReactions (reactions .Reactions)}}
+Reactions: {{reactions .Reactions}}
+{{end}}
+{{- if and .Options.IncludeComments (gt .Options.TopReactedComments 0)}}
+{{topReactedSection .}}
+{{- end}}
+{{- if eq .Meta.Type "issue"}}
+{{timelineSection .}}
+{{threadSection .}}
+{{- else if eq .Meta.Type "pull_request"}}
+{{timelineSection .}}
+{{reviewsSection .}}
+{{threadSection .}}
+{{commitsSection .}}
+{{checksSection .}}
+{{filesSection .}}
+{{- else}}
+{{threadSection .}}
+{{- end}}
+## References
+- Original URL: {{.Meta.URL}}
+`
+
+// TemplateData is the value a markdown template executes against. The IssueData fields are
+// promoted, so templates read .Meta.Title, .Description, .Thread or .Reviews directly.
+type TemplateData struct {
+	gh.IssueData
+	// Summary is the AI summary; Summary.Summary is empty when none was generated.
+	Summary Summary
+	// SummaryStatus explains a skipped summary, such as "skipped (rate limited)", or is empty.
+	SummaryStatus string
+	Options       RenderOptions
+}
+
+// Template is a parsed markdown layout for RenderOptions.Template.
+type Template struct {
+	tmpl   *template.Template
+	source string
+}
+
+// ParseTemplate parses a text/template markdown layout executed against TemplateData.
+// Besides the text/template builtins, layouts can call these helpers:
+//
+//	date LAYOUT TIMESTAMP   reformat an RFC 3339 timestamp with a Go time layout
+//	labels LABELS           label names joined by ", ", or "none"
+//	names NAMES             logins joined by ", ", or "none"
+//	reactions REACTIONS     compact reaction counts such as "👍 3 ❤️ 1", or ""
+//	badge ASSOCIATION       " [owner]"-style maintainer badge, or ""
+//	indent N TEXT           prefix every non-empty line with N spaces
+//	quote TEXT              prefix every line with "> "
+//	fence TEXT              a backtick fence longer than any run inside TEXT
+//	trim TEXT               TEXT without leading and trailing white space
+//
+// and the built-in sections, each taking the TemplateData: frontMatter, metadataSection,
+// summarySection, descriptionEdits, topReactedSection, timelineSection, threadSection,
+// reviewsSection, commitsSection, checksSection and filesSection.
+func ParseTemplate(name, text string) (*Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse template %q: %w", name, err)
+	}
+	return &Template{tmpl: tmpl, source: text}, nil
+}
+
+// Source returns the layout text the template was parsed from; a nil template has none.
+func (t *Template) Source() string {
+	if t == nil {
+		return ""
+	}
+	return t.source
+}
+
+func (t *Template) execute(data TemplateData) ([]byte, error) {
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, data); err != nil {
+		return nil, fmt.Errorf("execute template %q: %w", t.tmpl.Name(), err)
+	}
+	return []byte(b.String()), nil
+}
+
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"date":      formatTemplateDate,
+		"labels":    joinLabels,
+		"names":     joinNames,
+		"reactions": formatReactions,
+		"badge":     authorBadge,
+		"indent":    indentLines,
+		"quote":     quoteLines,
+		"fence":     codeFence,
+		"trim":      strings.TrimSpace,
+
+		"frontMatter": func(d TemplateData) string { return renderFrontMatter(d.IssueData) },
+		"metadataSection": func(d TemplateData) string {
+			return renderMetadataSection(d.Meta, d.SummaryStatus)
+		},
+		"summarySection": func(d TemplateData) string {
+			if d.Summary.Summary == "" {
+				return ""
+			}
+			return renderSummarySection(d.Summary)
+		},
+		"descriptionEdits": func(d TemplateData) string {
+			return renderDescriptionEdits(d.DescriptionEdits, d.Options)
+		},
+		"topReactedSection": func(d TemplateData) string { return renderTopReactedSection(d.IssueData, d.Options) },
+		"timelineSection":   func(d TemplateData) string { return renderTimelineSection(d.IssueData) },
+		"threadSection":     renderTemplateThreadSection,
+		"reviewsSection":    func(d TemplateData) string { return renderPRReviewsSection(d.IssueData, d.Options) },
+		"commitsSection": func(d TemplateData) string {
+			return renderPRCommitsSection(d.IssueData, d.Options.IncludeCommits)
+		},
+		"checksSection": func(d TemplateData) string {
+			return renderPRChecksSection(d.IssueData, d.Options.IncludeChecks)
+		},
+		"filesSection": func(d TemplateData) string { return renderPRFilesSection(d.IssueData, d.Options) },
+	}
+}
+
+// renderTemplateThreadSection renders the Discussion Thread section of the resource type.
+func renderTemplateThreadSection(d TemplateData) string {
+	switch d.Meta.Type {
+	case gh.ResourcePullRequest:
+		return renderPRThreadSection(d.IssueData, d.Options)
+	case gh.ResourceDiscussion:
+		return renderDiscussionThreadSection(d.IssueData, d.Options)
+	default:
+		return renderIssueThreadSection(d.IssueData, d.Options)
+	}
+}
+
+// formatTemplateDate reformats an RFC 3339 timestamp; other values are returned unchanged.
+func formatTemplateDate(layout, value string) string {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return parsed.Format(layout)
+}
+
+func indentLines(width int, text string) string {
+	prefix := strings.Repeat(" ", max(width, 0))
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[idx] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func quoteLines(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for idx, line := range lines {
+		if line == "" {
+			lines[idx] = ">"
+			continue
+		}
+		lines[idx] = "> " + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,122 @@
+package converter
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRenderCustomTemplate(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		text    string
+		opts    RenderOptions
+		want    string
+		wantErr string
+	}{
+		{
+			name: "fields and helpers",
+			text: "## {{.Meta.Title}} ({{date \"Jan 2, 2006\" .Meta.CreatedAt}})\n" +
+				"Labels: {{labels .Meta.Labels}}; assignees: {{names .Meta.Assignees}}; {{reactions .Reactions}}\n" +
+				"{{range .Thread}}{{quote .Body}}\n— {{.Author}}{{badge .AuthorAssociation}}\n{{range .Replies}}{{indent 2 .Body}}\n{{end}}{{end}}",
+			opts: RenderOptions{IncludeComments: true},
+			want: "## Issue: Panic on nil config (Jan 1, 2026)\n" +
+				"Labels: bug, help wanted; assignees: maintainer; 👍 3 ❤️ 1\n" +
+				"> I can reproduce this.\n— bob\n  Thanks, investigating.\n" +
+				"> Fixed in #124?\n— carol\n",
+		},
+		{
+			name: "built-in sections",
+			text: "{{summarySection .}}---\n{{timelineSection .}}",
+			opts: RenderOptions{IncludeSummary: true},
+			want: renderSummarySection(fixedSummary()) + "---\n" + renderTimelineSection(sampleIssueData()),
+		},
+		{
+			name: "summary fields",
+			text: "{{.Summary.Summary}}|{{len .Summary.ActionItems}}|{{.Options.IncludeSummary}}",
+			opts: RenderOptions{IncludeSummary: true},
+			want: fixedSummary().Summary + "|2|true",
+		},
+		{
+			name:    "execution error",
+			text:    "{{.Meta.Missing}}",
+			wantErr: `render markdown: execute template "custom.tmpl"`,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tmpl, err := ParseTemplate("custom.tmpl", tc.text)
+			if err != nil {
+				t.Fatalf("ParseTemplate error = %v, want nil", err)
+			}
+			opts := tc.opts
+			opts.Template = tmpl
+			out, err := NewRenderer(&stubSummarizer{summary: fixedSummary()}).Render(context.Background(), sampleIssueData(), opts)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("Render error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render error = %v, want nil", err)
+			}
+			if string(out) != tc.want {
+				t.Fatalf("Render output\ngot:  %q\nwant: %q", out, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseTemplateError(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name string
+		text string
+	}{
+		{name: "unclosed action", text: "{{.Meta.Title"},
+		{name: "unknown function", text: "{{shout .Meta.Title}}"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := ParseTemplate("bad.tmpl", tc.text); err == nil || !strings.Contains(err.Error(), `parse template "bad.tmpl"`) {
+				t.Fatalf("ParseTemplate error = %v, want parse template error", err)
+			}
+		})
+	}
+}
+
+func TestTemplateTextHelpers(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "indent skips blank lines", got: indentLines(2, "a\n\nb"), want: "  a\n\n  b"},
+		{name: "negative indent", got: indentLines(-1, "a"), want: "a"},
+		{name: "quote", got: quoteLines("a\n\nb\n"), want: "> a\n>\n> b"},
+		{name: "date layout", got: formatTemplateDate("2006-01-02", "2026-01-01T12:00:00Z"), want: "2026-01-01"},
+		{name: "unparsable date", got: formatTemplateDate("2006-01-02", "yesterday"), want: "yesterday"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if tc.got != tc.want {
+				t.Fatalf("got %q, want %q", tc.got, tc.want)
+			}
+		})
+	}
+}
@@ -21,8 +21,11 @@ func sampleIssueData() gh.IssueData {
 				{Name: "bug"},
 				{Name: "help wanted"},
 			},
+			Assignees: []string{"maintainer"},
+			Milestone: "v1.2",
 		},
 		Description: "App panics when config is nil.\n\n![image](https://example.com/a.png)",
+		Reactions:   gh.ReactionSummary{PlusOne: 3, Heart: 1, Total: 4},
 		Timeline: []gh.TimelineEvent{
 			{EventType: "opened", Actor: "alice", CreatedAt: "2026-01-01T10:00:00Z", Details: "Issue opened"},
 			{EventType: "labeled", Actor: "bot", CreatedAt: "2026-01-01T10:30:00Z", Details: "bug"},
@@ -34,6 +37,8 @@ func sampleIssueData() gh.IssueData {
 				Author:    "bob",
 				Body:      "I can reproduce this.",
 				CreatedAt: "2026-01-01T12:00:00Z",
+				URL:       "https://github.com/octo/repo/issues/123#issuecomment-1",
+				Reactions: gh.ReactionSummary{PlusOne: 2, Total: 2},
 				Replies: []gh.CommentNode{
 					{
 						ID:        "c1-r1",
@@ -48,6 +53,7 @@ func sampleIssueData() gh.IssueData {
 				Author:    "carol",
 				Body:      "Fixed in #124?",
 				CreatedAt: "2026-01-01T13:00:00Z",
+				Reactions: gh.ReactionSummary{Hooray: 4, Rocket: 1, Total: 5},
 			},
 		},
 	}
@@ -56,20 +62,43 @@ func sampleIssueData() gh.IssueData {
 func samplePRData() gh.IssueData {
 	return gh.IssueData{
 		Meta: gh.Metadata{
-			Type:        gh.ResourcePullRequest,
-			Title:       "PR: Fix nil config panic",
-			Number:      124,
-			State:       "closed",
-			Author:      "alice",
-			CreatedAt:   "2026-01-03T09:00:00Z",
-			UpdatedAt:   "2026-01-04T10:00:00Z",
-			URL:         "https://github.com/octo/repo/pull/124",
-			Labels:      []gh.Label{{Name: "bugfix"}},
-			Merged:      true,
-			MergedAt:    "2026-01-04T09:30:00Z",
-			ReviewCount: 2,
+			Type:               gh.ResourcePullRequest,
+			Title:              "PR: Fix nil config panic",
+			Number:             124,
+			State:              "closed",
+			Author:             "alice",
+			CreatedAt:          "2026-01-03T09:00:00Z",
+			UpdatedAt:          "2026-01-04T10:00:00Z",
+			URL:                "https://github.com/octo/repo/pull/124",
+			Labels:             []gh.Label{{Name: "bugfix"}},
+			Merged:             true,
+			MergedAt:           "2026-01-04T09:30:00Z",
+			ReviewCount:        2,
+			HeadSHA:            "9f8e7d6c5b4a",
+			ClosedAt:           "2026-01-04T09:30:00Z",
+			ClosedBy:           "bob",
+			BaseRef:            "main",
+			HeadRef:            "fix/nil-config",
+			Additions:          12,
+			Deletions:          3,
+			RequestedReviewers: []string{"carol", "team:core"},
 		},
 		Description: "This PR adds a nil check.",
+		Timeline: []gh.TimelineEvent{
+			{EventType: "opened", Actor: "alice", CreatedAt: "2026-01-03T09:00:00Z"},
+			{EventType: "review_requested", Actor: "alice", CreatedAt: "2026-01-03T09:05:00Z", Details: "bob"},
+			{EventType: "head_ref_force_pushed", Actor: "alice", CreatedAt: "2026-01-03T11:00:00Z", Details: "1a2b3c4 → 9f8e7d6"},
+			{EventType: "merged", Actor: "bob", CreatedAt: "2026-01-04T09:30:00Z", Details: "9f8e7d6 into main"},
+		},
+		Commits: []gh.CommitData{
+			{SHA: "1a2b3c4d5e6f", Author: "alice", Headline: "Guard nil config", CommittedAt: "2026-01-03T08:00:00Z"},
+			{SHA: "9f8e7d6c5b4a", Author: "alice", Headline: "Add regression test", CommittedAt: "2026-01-03T12:30:00Z"},
+		},
+		Checks: []gh.CheckResult{
+			{Name: "build", Source: "check_run", Status: "completed", Conclusion: "success", URL: "https://github.com/octo/repo/runs/1"},
+			{Name: "lint | vet", Source: "check_run", Status: "completed", Conclusion: "skipped"},
+			{Name: "ci/legacy", Source: "status", Conclusion: "success"},
+		},
 		Reviews: []gh.ReviewData{
 			{
 				ID:        "r1",
@@ -85,6 +114,33 @@ func samplePRData() gh.IssueData {
 						CreatedAt: "2026-01-03T12:10:00Z",
 					},
 				},
+				Threads: []gh.ReviewThread{
+					{
+						ID:         "t1",
+						Path:       "internal/config/loader.go",
+						Side:       "RIGHT",
+						DiffHunk:   "@@ -10,3 +10,6 @@ func Load() {\n \tcfg := defaultConfig()\n+\tif cfg == nil {",
+						ResolvedBy: "alice",
+						StartLine:  11,
+						Line:       13,
+						IsResolved: true,
+						Comments: []gh.CommentNode{
+							{
+								ID:        "r1-c1",
+								Author:    "bob",
+								Body:      "Please add test.",
+								CreatedAt: "2026-01-03T12:10:00Z",
+							},
+							{
+								ID:          "r1-c2",
+								Author:      "alice",
+								Body:        "Added in the next commit.",
+								CreatedAt:   "2026-01-03T12:20:00Z",
+								InReplyToID: "r1-c1",
+							},
+						},
+					},
+				},
 			},
 			{
 				ID:        "r2",
@@ -92,6 +148,31 @@ func samplePRData() gh.IssueData {
 				Author:    "carol",
 				Body:      "Need edge case coverage.",
 				CreatedAt: "2026-01-03T13:00:00Z",
+				Comments: []gh.CommentNode{
+					{
+						ID:        "r2-c1",
+						Author:    "carol",
+						Body:      "Typo in heading.",
+						CreatedAt: "2026-01-03T13:05:00Z",
+					},
+				},
+				Threads: []gh.ReviewThread{
+					{
+						ID:           "t2",
+						Path:         "README.md",
+						Side:         "LEFT",
+						OriginalLine: 4,
+						IsOutdated:   true,
+						Comments: []gh.CommentNode{
+							{
+								ID:        "r2-c1",
+								Author:    "carol",
+								Body:      "Typo in heading.",
+								CreatedAt: "2026-01-03T13:05:00Z",
+							},
+						},
+					},
+				},
 			},
 		},
 		Thread: []gh.CommentNode{
@@ -102,6 +183,20 @@ func samplePRData() gh.IssueData {
 				CreatedAt: "2026-01-03T14:00:00Z",
 			},
 		},
+		Files: []gh.ChangedFile{
+			{
+				Filename:  "internal/config/loader.go",
+				Status:    "modified",
+				Patch:     "@@ -10,3 +10,6 @@ func Load() {\n \tcfg := defaultConfig()\n+\tif cfg == nil {\n+\t\treturn Config{}\n+\t}",
+				Additions: 3,
+				Changes:   3,
+			},
+			{
+				Filename:         "internal/config/loader_nil_test.go",
+				PreviousFilename: "internal/config/nil_test.go",
+				Status:           "renamed",
+			},
+		},
 	}
 }
 
@@ -120,6 +215,7 @@ func sampleDiscussionData() gh.IssueData {
 			IsAnswered:           true,
 			AcceptedAnswerID:     "d2",
 			AcceptedAnswerAuthor: "mentor",
+			Locked:               true,
 		},
 		Description: "What's the best config for tokens?",
 		Thread: []gh.CommentNode{
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	goGithub "github.com/google/go-github/v72/github"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// appJWTLifetime stays under the ten minutes GitHub accepts for app JWTs.
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockSkew backdates iat so small clock drift does not make the JWT "not yet valid".
+	appJWTClockSkew = time.Minute
+	// installationTokenRefresh renews installation tokens this long before they expire, so a
+	// request never carries a token that lapses in flight.
+	installationTokenRefresh = 5 * time.Minute
+)
+
+// AppAuth authenticates as a GitHub App installation instead of with a static token.
+type AppAuth struct {
+	// PrivateKey is the app's PEM-encoded RSA private key (PKCS#1 or PKCS#8).
+	PrivateKey []byte
+	// Owner is the user or organization whose installation is used when InstallationID is zero.
+	Owner          string
+	AppID          int64
+	InstallationID int64
+}
+
+// newAppTokenSource mints app JWTs and exchanges them for installation tokens, refreshing the
+// installation token shortly before it expires.
+func newAppTokenSource(cfg Config) (oauth2.TokenSource, error) {
+	app := cfg.App
+	if app.AppID <= 0 {
+		return nil, fmt.Errorf("invalid GitHub App ID %d", app.AppID)
+	}
+	if app.InstallationID < 0 || (app.InstallationID == 0 && app.Owner == "") {
+		return nil, errors.New("GitHub App auth requires an installation ID or an installation owner")
+	}
+	key, err := parseRSAPrivateKey(app.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse GitHub App private key: %w", err)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	jwt := oauth2.ReuseTokenSourceWithExpiry(nil, &appJWTSource{key: key, appID: app.AppID, now: time.Now}, appJWTClockSkew)
+	client, err := newGoGitHubClient(withTokenSource(httpClient, jwt), cfg.RESTBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	installation := &installationTokenSource{
+		client:         client,
+		owner:          app.Owner,
+		installationID: app.InstallationID,
+	}
+	return oauth2.ReuseTokenSourceWithExpiry(nil, installation, installationTokenRefresh), nil
+}
+
+type appJWTSource struct {
+	key   *rsa.PrivateKey
+	now   func() time.Time
+	appID int64
+}
+
+func (s *appJWTSource) Token() (*oauth2.Token, error) {
+	now := s.now()
+	expiresAt := now.Add(appJWTLifetime)
+	signed, err := signAppJWT(s.key, s.appID, now.Add(-appJWTClockSkew), expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{AccessToken: signed, TokenType: "Bearer", Expiry: expiresAt}, nil
+}
+
+// installationTokenSource is only called through oauth2.ReuseTokenSource, which serializes
+// Token calls, so the discovered installation ID needs no extra locking.
+type installationTokenSource struct {
+	client         *goGithub.Client
+	owner          string
+	installationID int64
+}
+
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	ctx := context.Background()
+	id, err := s.installation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, _, err := s.client.Apps.CreateInstallationToken(ctx, id, nil)
+	if err != nil {
+		return nil, wrapRESTError("create installation token", err)
+	}
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		TokenType:   "Bearer",
+		Expiry:      token.GetExpiresAt().Time,
+	}, nil
+}
+
+// installation returns the configured installation ID, or discovers it from the owner's
+// organization installation and then its user installation.
+func (s *installationTokenSource) installation(ctx context.Context) (int64, error) {
+	if s.installationID != 0 {
+		return s.installationID, nil
+	}
+
+	installation, resp, err := s.client.Apps.FindOrganizationInstallation(ctx, s.owner)
+	if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+		installation, _, err = s.client.Apps.FindUserInstallation(ctx, s.owner)
+	}
+	if err != nil {
+		return 0, wrapRESTError(fmt.Sprintf("find GitHub App installation for %q", s.owner), err)
+	}
+	s.installationID = installation.GetID()
+	return s.installationID, nil
+}
+
+func signAppJWT(key *rsa.PrivateKey, appID int64, issuedAt, expiresAt time.Time) (string, error) {
+	claims, err := json.Marshal(struct {
+		Issuer    string `json:"iss"`
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+	}{
+		Issuer:    strconv.FormatInt(appID, 10),
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode app JWT claims: %w", err)
+	}
+
+	encoding := base64.RawURLEncoding
+	signingInput := encoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`)) + "." + encoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign app JWT: %w", err)
+	}
+	return signingInput + "." + encoding.EncodeToString(signature), nil
+}
+
+func parseRSAPrivateKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse PKCS#1 or PKCS#8 key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key type %T is not RSA", parsed)
+	}
+	return key, nil
+}
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestAppKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key: %v", err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// verifyAppJWT checks the RS256 signature and issuer of an app JWT.
+func verifyAppJWT(key *rsa.PublicKey, authorization string, appID int64) error {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return fmt.Errorf("authorization %q is not a Bearer token", authorization)
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("JWT has %d parts, want 3", len(parts))
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return fmt.Errorf("verify signature: %w", err)
+	}
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("decode claims: %w", err)
+	}
+	var claims struct {
+		Issuer    string `json:"iss"`
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(rawClaims, &claims); err != nil {
+		return fmt.Errorf("decode claims: %w", err)
+	}
+	if claims.Issuer != fmt.Sprint(appID) || claims.ExpiresAt-claims.IssuedAt > 600 {
+		return fmt.Errorf("claims = %+v, want iss %d and a lifetime of at most 10 minutes", claims, appID)
+	}
+	return nil
+}
+
+func TestAppTokenSourceDiscoversInstallationAndRefreshes(t *testing.T) {
+	t.Parallel()
+
+	key, keyPEM := newTestAppKey(t)
+	expiries := []time.Duration{2 * time.Minute, time.Hour}
+
+	var mu sync.Mutex
+	var paths []string
+	exchanges := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+
+		if r.URL.Path == "/repos/acme/widgets/issues/1" {
+			if got := r.Header.Get("Authorization"); got != "Bearer ghs_2" {
+				t.Errorf("issue request Authorization = %q, want the refreshed installation token", got)
+			}
+			_, _ = fmt.Fprint(w, `{"number":1,"title":"t"}`)
+			return
+		}
+		if err := verifyAppJWT(&key.PublicKey, r.Header.Get("Authorization"), 7); err != nil {
+			t.Errorf("%s: %v", r.URL.Path, err)
+		}
+		switch r.URL.Path {
+		case "/orgs/acme/installation":
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+		case "/users/acme/installation":
+			_, _ = fmt.Fprint(w, `{"id":42}`)
+		case "/app/installations/42/access_tokens":
+			expiresAt := time.Now().Add(expiries[exchanges]).UTC().Format(time.RFC3339)
+			exchanges++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":%q}`, exchanges, expiresAt)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		HTTPClient:  server.Client(),
+		RESTBaseURL: server.URL + "/",
+		App:         &AppAuth{AppID: 7, Owner: "acme", PrivateKey: keyPEM},
+	}
+	tokens, err := newAppTokenSource(cfg)
+	if err != nil {
+		t.Fatalf("newAppTokenSource error = %v, want nil", err)
+	}
+
+	// The first token expires inside the refresh window, so the second call exchanges again;
+	// the third reuses the long-lived token.
+	for i, want := range []string{"ghs_1", "ghs_2", "ghs_2"} {
+		token, err := tokens.Token()
+		if err != nil {
+			t.Fatalf("Token() call %d error = %v, want nil", i+1, err)
+		}
+		if token.AccessToken != want {
+			t.Fatalf("Token() call %d = %q, want %q", i+1, token.AccessToken, want)
+		}
+	}
+
+	cfg.tokens = tokens
+	rest, err := newRESTClient(cfg)
+	if err != nil {
+		t.Fatalf("newRESTClient error = %v, want nil", err)
+	}
+	if _, err := rest.getIssue(context.Background(), "acme", "widgets", 1); err != nil {
+		t.Fatalf("getIssue error = %v, want nil", err)
+	}
+
+	want := []string{
+		"GET /orgs/acme/installation",
+		"GET /users/acme/installation",
+		"POST /app/installations/42/access_tokens",
+		"POST /app/installations/42/access_tokens",
+		"GET /repos/acme/widgets/issues/1",
+	}
+	if strings.Join(paths, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("requests =\n%s\nwant\n%s", strings.Join(paths, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNewAppTokenSourceValidation(t *testing.T) {
+	t.Parallel()
+
+	_, keyPEM := newTestAppKey(t)
+	tcs := []struct {
+		app     *AppAuth
+		name    string
+		wantErr string
+	}{
+		{name: "missing app id", app: &AppAuth{InstallationID: 1, PrivateKey: keyPEM}, wantErr: "invalid GitHub App ID"},
+		{name: "missing installation", app: &AppAuth{AppID: 7, PrivateKey: keyPEM}, wantErr: "installation ID or an installation owner"},
+		{name: "not PEM", app: &AppAuth{AppID: 7, InstallationID: 1, PrivateKey: []byte("secret")}, wantErr: "no PEM block"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := newAppTokenSource(Config{App: tc.app})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("newAppTokenSource error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
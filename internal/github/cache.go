@@ -0,0 +1,274 @@
+package github
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultGraphQLCacheTTL is how long a cached GraphQL response is served without asking GitHub.
+const DefaultGraphQLCacheTTL = 10 * time.Minute
+
+// HTTPCache stores GitHub API responses on disk. REST GETs are revalidated with
+// If-None-Match/If-Modified-Since, and 304 answers do not count against the primary rate limit;
+// GraphQL responses are keyed by query and variables and reused for a fixed TTL.
+// Cache I/O is best effort: unreadable or unwritable entries fall back to the network.
+type HTTPCache struct {
+	now          func() time.Time
+	onStoreError func(error)
+	dir          string
+	graphQLTTL   time.Duration
+	hits         atomic.Int64
+	misses       atomic.Int64
+	storeFailed  atomic.Bool
+}
+
+// NewHTTPCache creates a cache rooted at dir. The directory is created on first write.
+// onStoreError, when set, is called with the first entry that cannot be written, so a cache that
+// never stores anything is visible; later failures are not reported.
+func NewHTTPCache(dir string, graphQLTTL time.Duration, onStoreError func(error)) *HTTPCache {
+	return &HTTPCache{dir: dir, graphQLTTL: graphQLTTL, now: time.Now, onStoreError: onStoreError}
+}
+
+// Stats returns the number of requests answered from the cache and sent to GitHub.
+// A nil cache reports zero for both.
+func (c *HTTPCache) Stats() (hits, misses int64) {
+	if c == nil {
+		return 0, 0
+	}
+	return c.hits.Load(), c.misses.Load()
+}
+
+// CacheReporter is implemented by fetchers that count HTTP cache hits and misses.
+type CacheReporter interface {
+	CacheStats() (hits, misses int64)
+}
+
+type cacheEntry struct {
+	StoredAt time.Time   `json:"stored_at"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	Status   int         `json:"status"`
+}
+
+// wrapClient returns a copy of client whose transport consults the cache. graphQL selects
+// TTL caching of POST requests instead of conditional REST GETs.
+func (c *HTTPCache) wrapClient(client *http.Client, graphQL bool) *http.Client {
+	if c == nil {
+		return client
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	wrapped := *client
+	wrapped.Transport = &cacheTransport{base: base, cache: c, graphQL: graphQL}
+	return &wrapped
+}
+
+type cacheTransport struct {
+	base    http.RoundTripper
+	cache   *HTTPCache
+	graphQL bool
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch {
+	case t.graphQL && req.Method == http.MethodPost:
+		return t.roundTripTTL(req)
+	case !t.graphQL && req.Method == http.MethodGet:
+		return t.roundTripConditional(req)
+	default:
+		return t.base.RoundTrip(req)
+	}
+}
+
+// roundTripConditional revalidates a stored REST response and replays it on 304 Not Modified.
+func (t *cacheTransport) roundTripConditional(req *http.Request) (*http.Response, error) {
+	key := cacheKey(req, nil)
+	entry, cached := t.cache.load(key)
+
+	outgoing := req
+	if cached {
+		outgoing = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			outgoing.Header.Set("If-None-Match", etag)
+		}
+		if modified := entry.Header.Get("Last-Modified"); modified != "" {
+			outgoing.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	if cached && resp.StatusCode == http.StatusNotModified {
+		t.cache.hits.Add(1)
+		header := entry.Header.Clone()
+		for name, values := range resp.Header {
+			// Fresh rate-limit headers come with the 304; the stored body keeps its own length.
+			if name != "Content-Length" {
+				header[name] = values
+			}
+		}
+		if err := resp.Body.Close(); err != nil {
+			return nil, fmt.Errorf("close not-modified response: %w", err)
+		}
+		entry.Header = header
+		return entry.response(req), nil
+	}
+
+	t.cache.misses.Add(1)
+	if resp.StatusCode != http.StatusOK || (resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") {
+		return resp, nil
+	}
+	return t.storeAndReplay(key, req, resp)
+}
+
+// cacheHitHeader marks a GraphQL response replayed from the cache without contacting GitHub, so
+// the rateLimit field in its body is as old as the entry.
+const cacheHitHeader = "X-Issue2md-Cache-Hit"
+
+// roundTripTTL serves GraphQL responses younger than the TTL without contacting GitHub.
+func (t *cacheTransport) roundTripTTL(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := cacheKey(req, body)
+	if entry, ok := t.cache.load(key); ok && t.cache.now().Sub(entry.StoredAt) < t.cache.graphQLTTL {
+		t.cache.hits.Add(1)
+		resp := entry.response(req)
+		resp.Header.Set(cacheHitHeader, "1")
+		return resp, nil
+	}
+
+	outgoing := req.Clone(req.Context())
+	outgoing.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := t.base.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	t.cache.misses.Add(1)
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	return t.storeAndReplay(key, req, resp)
+}
+
+func (t *cacheTransport) storeAndReplay(key string, req *http.Request, resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read response for cache: %w", err)
+	}
+
+	entry := cacheEntry{StoredAt: t.cache.now(), Header: resp.Header.Clone(), Body: body, Status: resp.StatusCode}
+	if !t.graphQL || !hasGraphQLErrors(body) {
+		t.cache.store(key, entry)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (e cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+func (c *HTTPCache) load(key string) (cacheEntry, bool) {
+	raw, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.Status == 0 {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *HTTPCache) store(key string, entry cacheEntry) {
+	if err := c.write(key, entry); err != nil && c.onStoreError != nil && c.storeFailed.CompareAndSwap(false, true) {
+		c.onStoreError(err)
+	}
+}
+
+func (c *HTTPCache) write(key string, entry cacheEntry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("create cache directory %q: %w", c.dir, err)
+	}
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create cache entry: %w", err)
+	}
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write cache entry %q: %w", c.path(key), err)
+	}
+	return nil
+}
+
+func (c *HTTPCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// cacheKey covers the credential so responses fetched with one token are never served to another.
+func cacheKey(req *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n%s\n%s\n", req.Method, req.URL.String(), req.Header.Get("Accept"), req.Header.Get("Authorization"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if closeErr := req.Body.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read request body for cache: %w", err)
+	}
+	return body, nil
+}
+
+func hasGraphQLErrors(body []byte) bool {
+	var payload struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	return json.Unmarshal(body, &payload) != nil || len(payload.Errors) > 0
+}
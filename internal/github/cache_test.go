@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPCacheRevalidatesRESTResponses(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	client := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 2 && r.Header.Get("If-None-Match") != `"v1"` {
+			t.Fatalf("second request If-None-Match = %q, want \"v1\"", r.Header.Get("If-None-Match"))
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			resp := &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}
+			resp.Header.Set("X-RateLimit-Remaining", "4999")
+			return resp, nil
+		}
+		resp := mustJSONResponse(t, http.StatusOK, map[string]any{"number": 1})
+		resp.Header.Set("ETag", `"v1"`)
+		resp.Header.Set("X-RateLimit-Remaining", "5000")
+		return resp, nil
+	})
+
+	cache := NewHTTPCache(t.TempDir(), time.Minute, nil)
+	cached := cache.wrapClient(client, false)
+
+	var bodies []string
+	for i := 0; i < 2; i++ {
+		resp, err := cached.Get("https://api.test/repos/octo/repo/issues/1")
+		if err != nil {
+			t.Fatalf("Get #%d error = %v", i+1, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("read body #%d: %v", i+1, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Get #%d status = %d, want 200", i+1, resp.StatusCode)
+		}
+		if i == 1 && resp.Header.Get("X-RateLimit-Remaining") != "4999" {
+			t.Fatalf("replayed rate-limit header = %q, want the 304 value", resp.Header.Get("X-RateLimit-Remaining"))
+		}
+		bodies = append(bodies, string(body))
+	}
+
+	if bodies[0] != bodies[1] {
+		t.Fatalf("replayed body = %q, want %q", bodies[1], bodies[0])
+	}
+	if hits, misses := cache.Stats(); hits != 1 || misses != 1 {
+		t.Fatalf("Stats() = (%d, %d), want (1, 1)", hits, misses)
+	}
+}
+
+func TestHTTPCacheReportsFirstStoreError(t *testing.T) {
+	t.Parallel()
+
+	// A regular file where the cache directory should be makes every write fail.
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatalf("write blocker file: %v", err)
+	}
+	var reported []error
+	cache := NewHTTPCache(filepath.Join(blocker, "cache"), time.Minute, func(err error) {
+		reported = append(reported, err)
+	})
+	cached := cache.wrapClient(newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		resp := mustJSONResponse(t, http.StatusOK, map[string]any{"number": 1})
+		resp.Header.Set("ETag", `"v1"`)
+		return resp, nil
+	}), false)
+
+	for i := 0; i < 2; i++ {
+		resp, err := cached.Get(fmt.Sprintf("https://api.test/repos/octo/repo/issues/%d", i+1))
+		if err != nil {
+			t.Fatalf("Get #%d error = %v, want the uncached response", i+1, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if len(reported) != 1 || !strings.Contains(reported[0].Error(), "create cache directory") {
+		t.Fatalf("reported store errors = %v, want one directory error", reported)
+	}
+}
+
+func TestHTTPCacheServesGraphQLWithinTTL(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	client := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return mustJSONResponse(t, http.StatusOK, map[string]any{"data": map[string]any{"ok": true}}), nil
+	})
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewHTTPCache(t.TempDir(), time.Minute, nil)
+	cache.now = func() time.Time { return now }
+	cached := cache.wrapClient(client, true)
+
+	post := func(token string) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, "https://api.test/graphql", strings.NewReader(`{"query":"q","variables":{"n":1}}`))
+		if err != nil {
+			t.Fatalf("NewRequest error = %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := cached.Do(req)
+		if err != nil {
+			t.Fatalf("Do error = %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	post("a")
+	post("a")
+	if calls != 1 {
+		t.Fatalf("network calls within TTL = %d, want 1", calls)
+	}
+	post("b")
+	if calls != 2 {
+		t.Fatalf("network calls for another token = %d, want 2", calls)
+	}
+	now = now.Add(2 * time.Minute)
+	post("a")
+	if calls != 3 {
+		t.Fatalf("network calls after TTL = %d, want 3", calls)
+	}
+	if hits, misses := cache.Stats(); hits != 1 || misses != 3 {
+		t.Fatalf("Stats() = (%d, %d), want (1, 3)", hits, misses)
+	}
+}
+
+func TestFetcherReportsCacheStats(t *testing.T) {
+	t.Parallel()
+
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/repos/octo/repo/issues/1":
+			if r.Header.Get("If-None-Match") == `"i1"` {
+				return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}
+			resp := mustJSONResponse(t, http.StatusOK, map[string]any{
+				"number":   1,
+				"title":    "Cached issue",
+				"html_url": "https://github.com/octo/repo/issues/1",
+				"user":     map[string]any{"login": "alice"},
+			})
+			resp.Header.Set("ETag", `"i1"`)
+			return resp, nil
+		case "/graphql":
+			return timelineResponse(t, timelineFieldIssue, nil), nil
+		default:
+			return notFoundResponse(r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewFetcher(Config{
+		HTTPClient:  clientHTTP,
+		Token:       "token",
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+		Cache:       NewHTTPCache(t.TempDir(), time.Minute, nil),
+	})
+	if err != nil {
+		t.Fatalf("NewFetcher error = %v, want nil", err)
+	}
+
+	ref := ResourceRef{Owner: "octo", Repo: "repo", Number: 1, Type: ResourceIssue, URL: "https://github.com/octo/repo/issues/1"}
+	for i := 0; i < 2; i++ {
+		got, err := fetcher.Fetch(context.Background(), ref, FetchOptions{})
+		if err != nil {
+			t.Fatalf("Fetch #%d error = %v, want nil", i+1, err)
+		}
+		if got.Meta.Title != "Cached issue" {
+			t.Fatalf("Fetch #%d title = %q, want Cached issue", i+1, got.Meta.Title)
+		}
+	}
+
+	reporter, ok := fetcher.(CacheReporter)
+	if !ok {
+		t.Fatalf("fetcher does not implement CacheReporter")
+	}
+	if hits, misses := reporter.CacheStats(); hits != 2 || misses != 2 {
+		t.Fatalf("CacheStats() = (%d, %d), want REST and GraphQL each missing once then hitting once", hits, misses)
+	}
+}
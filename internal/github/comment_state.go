@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GhostLogin is the login GitHub shows for content whose author account was deleted.
+const GhostLogin = "ghost"
+
+// minimizedStateBatchSize is the most node IDs GitHub accepts in one nodes() lookup.
+const minimizedStateBatchSize = 100
+
+type minimizedStatePayload struct {
+	Nodes []*struct {
+		ID              string `json:"id"`
+		MinimizedReason string `json:"minimizedReason"`
+		IsMinimized     bool   `json:"isMinimized"`
+	} `json:"nodes"`
+}
+
+// loginOrGhost maps the missing author of a deleted account to GhostLogin.
+func loginOrGhost(login string) string {
+	if login == "" {
+		return GhostLogin
+	}
+	return login
+}
+
+// normalizeMinimizedReason lowercases GitHub's reason, which is reported both as "OFF_TOPIC"
+// and "off-topic" depending on the API, to the hyphenated form.
+func normalizeMinimizedReason(reason string) string {
+	return strings.ReplaceAll(strings.ToLower(reason), "_", "-")
+}
+
+// commentsByNodeID indexes every thread, reply and review comment by GraphQL node ID. Review
+// comments appear both in review lists and threads, so one ID may map to several copies.
+func commentsByNodeID(data *IssueData) map[string][]*CommentNode {
+	out := map[string][]*CommentNode{}
+	var walk func(nodes []CommentNode)
+	walk = func(nodes []CommentNode) {
+		for i := range nodes {
+			if nodes[i].NodeID != "" {
+				out[nodes[i].NodeID] = append(out[nodes[i].NodeID], &nodes[i])
+			}
+			walk(nodes[i].Replies)
+		}
+	}
+	walk(data.Thread)
+	for i := range data.Reviews {
+		walk(data.Reviews[i].Comments)
+		for j := range data.Reviews[i].Threads {
+			walk(data.Reviews[i].Threads[j].Comments)
+		}
+	}
+	return out
+}
+
+func sortedNodeIDs[T any](targets map[string]T) []string {
+	ids := make([]string, 0, len(targets))
+	for id := range targets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// attachMinimizedState looks up which REST-fetched comments moderators minimized; REST does
+// not report it. Discussion comments select it in their own GraphQL query.
+func (f *fetcher) attachMinimizedState(ctx context.Context, data *IssueData) error {
+	targets := commentsByNodeID(data)
+	ids := sortedNodeIDs(targets)
+	for start := 0; start < len(ids); start += minimizedStateBatchSize {
+		end := min(start+minimizedStateBatchSize, len(ids))
+		var payload minimizedStatePayload
+		if err := f.gql.Query(ctx, minimizedStateQuery, map[string]any{"ids": ids[start:end]}, &payload); err != nil {
+			return fmt.Errorf("query minimized comments: %w", err)
+		}
+		for _, node := range payload.Nodes {
+			if node == nil || !node.IsMinimized {
+				continue
+			}
+			for _, comment := range targets[node.ID] {
+				comment.IsMinimized = true
+				comment.MinimizedReason = normalizeMinimizedReason(node.MinimizedReason)
+			}
+		}
+	}
+	return nil
+}
+
+const minimizedStateQuery = `query MinimizedComments($ids:[ID!]!) {
+  nodes(ids:$ids) {
+    ... on Node { id }
+    ... on Minimizable { isMinimized minimizedReason }
+  }
+  rateLimit { cost remaining resetAt }
+}`
@@ -0,0 +1,94 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFetchIssueCommentState(t *testing.T) {
+	t.Parallel()
+
+	var minimizedQueries int
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/repos/octo/repo/issues/1":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"number":   1,
+				"node_id":  "I_1",
+				"title":    "Issue title",
+				"html_url": "https://github.com/octo/repo/issues/1",
+				"user":     nil,
+			}), nil
+		case "/repos/octo/repo/issues/1/comments":
+			return mustJSONResponse(t, http.StatusOK, []map[string]any{
+				{"id": 1001, "node_id": "IC_1001", "body": "buy now", "author_association": "NONE", "user": map[string]any{"login": "spammer"}},
+				{"id": 1002, "node_id": "IC_1002", "body": "fixed in main", "author_association": "MEMBER", "user": map[string]any{"login": "maintainer"}},
+				{"id": 1003, "body": "old account", "author_association": "NONE"},
+			}), nil
+		case "/graphql":
+			var req struct {
+				Query string `json:"query"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode graphql request: %v", err)
+			}
+			if !strings.Contains(req.Query, "Minimizable") {
+				return timelineResponse(t, "issue", nil), nil
+			}
+			minimizedQueries++
+			return mustJSONResponse(t, http.StatusOK, map[string]any{"data": map[string]any{"nodes": []any{
+				map[string]any{"id": "IC_1001", "isMinimized": true, "minimizedReason": "OFF_TOPIC"},
+				map[string]any{"id": "IC_1002", "isMinimized": false, "minimizedReason": nil},
+			}}}), nil
+		default:
+			return notFoundResponse(r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewFetcher(Config{
+		HTTPClient:  clientHTTP,
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+	})
+	if err != nil {
+		t.Fatalf("NewFetcher error = %v, want nil", err)
+	}
+
+	got, err := fetcher.Fetch(context.Background(), ResourceRef{
+		Owner: "octo", Repo: "repo", Number: 1, Type: ResourceIssue,
+		URL: "https://github.com/octo/repo/issues/1",
+	}, FetchOptions{IncludeComments: true})
+	if err != nil {
+		t.Fatalf("Fetch error = %v, want nil", err)
+	}
+	if minimizedQueries != 1 {
+		t.Fatalf("minimized queries = %d, want 1", minimizedQueries)
+	}
+	if got.Meta.Author != GhostLogin {
+		t.Fatalf("Meta.Author = %q, want %q", got.Meta.Author, GhostLogin)
+	}
+
+	tcs := []struct {
+		name        string
+		author      string
+		association string
+		reason      string
+		index       int
+		minimized   bool
+	}{
+		{name: "minimized", index: 0, author: "spammer", association: "NONE", minimized: true, reason: "off-topic"},
+		{name: "member", index: 1, author: "maintainer", association: "MEMBER"},
+		{name: "deleted author", index: 2, author: GhostLogin, association: "NONE"},
+	}
+	for _, tc := range tcs {
+		comment := got.Thread[tc.index]
+		if comment.Author != tc.author || comment.AuthorAssociation != tc.association ||
+			comment.IsMinimized != tc.minimized || comment.MinimizedReason != tc.reason {
+			t.Errorf("%s: comment = %+v, want author %q association %q minimized %v reason %q",
+				tc.name, comment, tc.author, tc.association, tc.minimized, tc.reason)
+		}
+	}
+}
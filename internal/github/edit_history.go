@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// editHistoryBatchSize bounds the nodes per query; each may carry maxEditRevisions revisions.
+	editHistoryBatchSize = 25
+	// maxEditRevisions is the most revisions kept per body, newest first as GitHub returns them.
+	maxEditRevisions = 100
+)
+
+type contentEditsPayload struct {
+	Nodes []*struct {
+		LastEditedAt *string `json:"lastEditedAt"`
+		Editor       *struct {
+			Login string `json:"login"`
+		} `json:"editor"`
+		UserContentEdits *struct {
+			Nodes []struct {
+				Editor *struct {
+					Login string `json:"login"`
+				} `json:"editor"`
+				Diff     *string `json:"diff"`
+				EditedAt string  `json:"editedAt"`
+			} `json:"nodes"`
+			TotalCount int `json:"totalCount"`
+		} `json:"userContentEdits"`
+		ID string `json:"id"`
+	} `json:"nodes"`
+}
+
+// attachEditHistory fills the edit history of the description and every comment that has a
+// GraphQL node ID.
+func (f *fetcher) attachEditHistory(ctx context.Context, data *IssueData, revisions bool) error {
+	targets := map[string][]*EditHistory{}
+	for id, comments := range commentsByNodeID(data) {
+		for _, comment := range comments {
+			targets[id] = append(targets[id], &comment.Edits)
+		}
+	}
+	if data.Meta.NodeID != "" {
+		targets[data.Meta.NodeID] = append(targets[data.Meta.NodeID], &data.DescriptionEdits)
+	}
+
+	ids := sortedNodeIDs(targets)
+	for start := 0; start < len(ids); start += editHistoryBatchSize {
+		end := min(start+editHistoryBatchSize, len(ids))
+		histories, err := f.fetchEditHistories(ctx, ids[start:end], revisions)
+		if err != nil {
+			return err
+		}
+		for id, history := range histories {
+			for _, target := range targets[id] {
+				*target = history
+			}
+		}
+	}
+	return nil
+}
+
+func (f *fetcher) fetchEditHistories(ctx context.Context, ids []string, revisions bool) (map[string]EditHistory, error) {
+	var payload contentEditsPayload
+	if err := f.gql.Query(ctx, contentEditsQuery(revisions), map[string]any{"ids": ids}, &payload); err != nil {
+		return nil, fmt.Errorf("query edit history: %w", err)
+	}
+
+	out := make(map[string]EditHistory, len(payload.Nodes))
+	for _, node := range payload.Nodes {
+		if node == nil || node.LastEditedAt == nil || node.UserContentEdits == nil {
+			continue
+		}
+		history := EditHistory{
+			LastEditedAt: *node.LastEditedAt,
+			Count:        max(node.UserContentEdits.TotalCount-1, 0),
+		}
+		if node.Editor != nil {
+			history.LastEditor = node.Editor.Login
+		}
+		edits := node.UserContentEdits.Nodes
+		history.Revisions = make([]ContentEdit, 0, len(edits))
+		for i := len(edits) - 1; i >= 0; i-- {
+			edit := ContentEdit{EditedAt: edits[i].EditedAt}
+			if edits[i].Editor != nil {
+				edit.Editor = edits[i].Editor.Login
+			}
+			if edits[i].Diff != nil {
+				edit.Body = *edits[i].Diff
+			}
+			history.Revisions = append(history.Revisions, edit)
+		}
+		out[node.ID] = history
+	}
+	return out, nil
+}
+
+// contentEditsQuery selects edit metadata for a batch of nodes, and the revision texts only when
+// they will be rendered since they dominate the response size.
+func contentEditsQuery(revisions bool) string {
+	diff := ""
+	if revisions {
+		diff = "\n            diff"
+	}
+	return fmt.Sprintf(`query ContentEdits($ids:[ID!]!) {
+  nodes(ids:$ids) {
+    ... on Node { id }
+    ... on UserContentEditable {
+      lastEditedAt
+      editor { login }
+      userContentEdits(first:%d) {
+        totalCount
+        nodes {
+          editedAt
+          editor { login }%s
+        }
+      }
+    }
+  }
+  rateLimit { cost remaining resetAt }
+}`, maxEditRevisions, diff)
+}
@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFetchIssueEditHistory(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name         string
+		wantComment  EditHistory
+		revisions    bool
+		wantDiffSent bool
+	}{
+		{
+			name: "counts only",
+			wantComment: EditHistory{
+				LastEditor:   "bob",
+				LastEditedAt: "2026-01-04T00:00:00Z",
+				Count:        1,
+				Revisions: []ContentEdit{
+					{EditedAt: "2026-01-03T00:00:00Z", Editor: "bob"},
+					{EditedAt: "2026-01-04T00:00:00Z", Editor: "bob"},
+				},
+			},
+		},
+		{
+			name:         "with revisions",
+			revisions:    true,
+			wantDiffSent: true,
+			wantComment: EditHistory{
+				LastEditor:   "bob",
+				LastEditedAt: "2026-01-04T00:00:00Z",
+				Count:        1,
+				Revisions: []ContentEdit{
+					{EditedAt: "2026-01-03T00:00:00Z", Editor: "bob", Body: "first draft"},
+					{EditedAt: "2026-01-04T00:00:00Z", Editor: "bob", Body: "comment one"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotIDs []any
+			clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+				switch r.URL.Path {
+				case "/repos/octo/repo/issues/1":
+					return mustJSONResponse(t, http.StatusOK, map[string]any{
+						"number":   1,
+						"node_id":  "I_1",
+						"title":    "Issue title",
+						"html_url": "https://github.com/octo/repo/issues/1",
+						"user":     map[string]any{"login": "alice"},
+					}), nil
+				case "/repos/octo/repo/issues/1/comments":
+					return mustJSONResponse(t, http.StatusOK, []map[string]any{
+						{"id": 1001, "node_id": "IC_1001", "body": "comment one", "user": map[string]any{"login": "bob"}},
+						{"id": 1002, "node_id": "IC_1002", "body": "never edited", "user": map[string]any{"login": "carol"}},
+					}), nil
+				case "/graphql":
+					var req struct {
+						Variables map[string]any `json:"variables"`
+						Query     string         `json:"query"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						t.Fatalf("decode graphql request: %v", err)
+					}
+					if !strings.Contains(req.Query, "userContentEdits") {
+						return timelineResponse(t, "issue", nil), nil
+					}
+					if got := strings.Contains(req.Query, "diff"); got != tc.wantDiffSent {
+						t.Errorf("query selects diff = %v, want %v", got, tc.wantDiffSent)
+					}
+					gotIDs, _ = req.Variables["ids"].([]any)
+					return mustJSONResponse(t, http.StatusOK, map[string]any{"data": map[string]any{"nodes": []any{
+						map[string]any{
+							"id":               "I_1",
+							"lastEditedAt":     nil,
+							"userContentEdits": map[string]any{"totalCount": 0, "nodes": []any{}},
+						},
+						map[string]any{
+							"id":           "IC_1001",
+							"lastEditedAt": "2026-01-04T00:00:00Z",
+							"editor":       map[string]any{"login": "bob"},
+							"userContentEdits": map[string]any{"totalCount": 2, "nodes": []any{
+								map[string]any{"editedAt": "2026-01-04T00:00:00Z", "editor": map[string]any{"login": "bob"}, "diff": "comment one"},
+								map[string]any{"editedAt": "2026-01-03T00:00:00Z", "editor": map[string]any{"login": "bob"}, "diff": "first draft"},
+							}},
+						},
+						nil,
+					}}}), nil
+				default:
+					return notFoundResponse(r.URL.Path), nil
+				}
+			})
+
+			fetcher, err := NewFetcher(Config{
+				HTTPClient:  clientHTTP,
+				RESTBaseURL: "https://api.test/",
+				GraphQLURL:  "https://api.test/graphql",
+			})
+			if err != nil {
+				t.Fatalf("NewFetcher error = %v, want nil", err)
+			}
+
+			got, err := fetcher.Fetch(context.Background(), ResourceRef{
+				Owner: "octo", Repo: "repo", Number: 1, Type: ResourceIssue,
+				URL: "https://github.com/octo/repo/issues/1",
+			}, FetchOptions{IncludeComments: true, IncludeEdits: true, IncludeEditRevisions: tc.revisions})
+			if err != nil {
+				t.Fatalf("Fetch error = %v, want nil", err)
+			}
+
+			if want := []any{"IC_1001", "IC_1002", "I_1"}; !reflect.DeepEqual(gotIDs, want) {
+				t.Fatalf("queried ids = %v, want %v", gotIDs, want)
+			}
+			if got.DescriptionEdits.Count != 0 || got.DescriptionEdits.LastEditor != "" {
+				t.Fatalf("DescriptionEdits = %#v, want none", got.DescriptionEdits)
+			}
+			if !tc.revisions {
+				// A server ignoring the selection could still send diff; only the query matters.
+				for i := range tc.wantComment.Revisions {
+					got.Thread[0].Edits.Revisions[i].Body = ""
+				}
+			}
+			if !reflect.DeepEqual(got.Thread[0].Edits, tc.wantComment) {
+				t.Fatalf("comment Edits = %#v, want %#v", got.Thread[0].Edits, tc.wantComment)
+			}
+			if !reflect.DeepEqual(got.Thread[1].Edits, EditHistory{}) {
+				t.Fatalf("unedited comment Edits = %#v, want zero", got.Thread[1].Edits)
+			}
+		})
+	}
+}
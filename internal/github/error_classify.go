@@ -20,6 +20,9 @@ func IsRateLimitError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if errors.Is(err, ErrRateLimitWaitExceeded) {
+		return true
+	}
 
 	var stErr *statusError
 	if errors.As(err, &stErr) {
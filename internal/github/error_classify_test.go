@@ -2,6 +2,7 @@ package github
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 )
@@ -47,6 +48,7 @@ func TestIsRateLimitError(t *testing.T) {
 		{name: "403 retry after", err: NewStatusError(http.StatusForbidden, errors.New("forbidden"), http.Header{"Retry-After": []string{"7"}}), want: true},
 		{name: "403 forbidden", err: NewStatusError(http.StatusForbidden, errors.New("forbidden"), nil), want: false},
 		{name: "text fallback", err: errors.New("API rate limit exceeded"), want: true},
+		{name: "max wait exceeded", err: fmt.Errorf("execute graphql request: %w", ErrRateLimitWaitExceeded), want: true},
 	}
 
 	for _, tc := range tcs {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 func (f *fetcher) fetchDiscussion(ctx context.Context, ref ResourceRef, opts FetchOptions) (IssueData, error) {
@@ -33,16 +34,17 @@ func (f *fetcher) fetchDiscussion(ctx context.Context, ref ResourceRef, opts Fet
 			if discussion.Answer != nil {
 				acceptedAnswerID = discussion.Answer.ID
 				if discussion.Answer.Author != nil {
-					acceptedAnswerAuthor = discussion.Answer.Author.Login
+					acceptedAnswerAuthor = loginOrGhost(discussion.Answer.Author.Login)
 				}
 			}
 
 			result.Meta = Metadata{
 				Type:                 ResourceDiscussion,
+				NodeID:               discussion.ID,
 				Title:                discussion.Title,
 				Number:               discussion.Number,
 				State:                discussionState(discussion.Closed),
-				Author:               discussion.Author.Login,
+				Author:               loginOrGhost(discussion.Author.Login),
 				CreatedAt:            discussion.CreatedAt,
 				UpdatedAt:            discussion.UpdatedAt,
 				URL:                  discussion.URL,
@@ -50,6 +52,9 @@ func (f *fetcher) fetchDiscussion(ctx context.Context, ref ResourceRef, opts Fet
 				IsAnswered:           discussion.IsAnswered,
 				AcceptedAnswerID:     acceptedAnswerID,
 				AcceptedAnswerAuthor: acceptedAnswerAuthor,
+				ClosedAt:             discussion.ClosedAt,
+				StateReason:          strings.ToLower(discussion.StateReason),
+				Locked:               discussion.Locked,
 			}
 			result.Description = discussion.Body
 			result.Reactions = mapGraphQLReactions(discussion.Reactions)
@@ -90,6 +95,9 @@ func discussionPageQuery(includeComments bool) string {
           updatedAt
           url
           author { login }
+          authorAssociation
+          isMinimized
+          minimizedReason
           reactions { plusOne heart total }
           replies(first:50) {
             nodes {
@@ -99,6 +107,9 @@ func discussionPageQuery(includeComments bool) string {
               updatedAt
               url
               author { login }
+              authorAssociation
+              isMinimized
+              minimizedReason
               reactions { plusOne heart total }
             }
             pageInfo { hasNextPage endCursor }
@@ -111,6 +122,7 @@ func discussionPageQuery(includeComments bool) string {
 	return fmt.Sprintf(`query DiscussionPage%s {
   repository(owner:$owner, name:$repo) {
     discussion(number:$number) {
+      id
       number
       title
       body
@@ -118,6 +130,9 @@ func discussionPageQuery(includeComments bool) string {
       createdAt
       updatedAt
       closed
+      closedAt
+      stateReason
+      locked
       author { login }
       category { name }
       isAnswered
@@ -129,18 +144,23 @@ func discussionPageQuery(includeComments bool) string {
 %s
     }
   }
+  rateLimit { cost remaining resetAt }
 }`, queryArgs, commentsBlock)
 }
 
 func (f *fetcher) mapDiscussionComment(ctx context.Context, in discussionCommentPayload) (CommentNode, error) {
 	out := CommentNode{
-		ID:        in.ID,
-		Author:    in.Author.Login,
-		Body:      in.Body,
-		CreatedAt: in.CreatedAt,
-		UpdatedAt: in.UpdatedAt,
-		URL:       in.URL,
-		Reactions: mapGraphQLReactions(in.Reactions),
+		ID:                in.ID,
+		NodeID:            in.ID,
+		Author:            loginOrGhost(in.Author.Login),
+		AuthorAssociation: in.AuthorAssociation,
+		IsMinimized:       in.IsMinimized,
+		MinimizedReason:   normalizeMinimizedReason(in.MinimizedReason),
+		Body:              in.Body,
+		CreatedAt:         in.CreatedAt,
+		UpdatedAt:         in.UpdatedAt,
+		URL:               in.URL,
+		Reactions:         mapGraphQLReactions(in.Reactions),
 	}
 
 	for _, reply := range in.Replies.Nodes {
@@ -170,12 +190,16 @@ func (f *fetcher) fetchDiscussionReplies(ctx context.Context, commentID, initial
           updatedAt
           url
           author { login }
+          authorAssociation
+          isMinimized
+          minimizedReason
           reactions { plusOne heart total }
         }
         pageInfo { hasNextPage endCursor }
       }
     }
   }
+  rateLimit { cost remaining resetAt }
 }`
 
 	var out []CommentNode
@@ -216,12 +240,15 @@ type discussionNode struct {
 		} `json:"author"`
 		ID string `json:"id"`
 	} `json:"answer"`
-	UpdatedAt string `json:"updatedAt"`
-	Title     string `json:"title"`
-	Body      string `json:"body"`
-	URL       string `json:"url"`
-	CreatedAt string `json:"createdAt"`
-	Author    struct {
+	ID          string `json:"id"`
+	UpdatedAt   string `json:"updatedAt"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	URL         string `json:"url"`
+	CreatedAt   string `json:"createdAt"`
+	ClosedAt    string `json:"closedAt"`
+	StateReason string `json:"stateReason"`
+	Author      struct {
 		Login string `json:"login"`
 	} `json:"author"`
 	Category struct {
@@ -238,6 +265,7 @@ type discussionNode struct {
 	Number     int                    `json:"number"`
 	Closed     bool                   `json:"closed"`
 	IsAnswered bool                   `json:"isAnswered"`
+	Locked     bool                   `json:"locked"`
 }
 
 type discussionCommentPayload struct {
@@ -249,14 +277,17 @@ type discussionCommentPayload struct {
 	Author    struct {
 		Login string `json:"login"`
 	} `json:"author"`
-	Replies struct {
+	AuthorAssociation string `json:"authorAssociation"`
+	MinimizedReason   string `json:"minimizedReason"`
+	Replies           struct {
 		PageInfo struct {
 			EndCursor   string `json:"endCursor"`
 			HasNextPage bool   `json:"hasNextPage"`
 		} `json:"pageInfo"`
 		Nodes []discussionReplyPayload `json:"nodes"`
 	} `json:"replies"`
-	Reactions graphQLReactionSummary `json:"reactions"`
+	Reactions   graphQLReactionSummary `json:"reactions"`
+	IsMinimized bool                   `json:"isMinimized"`
 }
 
 type discussionRepliesPayload struct {
@@ -280,7 +311,10 @@ type discussionReplyPayload struct {
 	Author    struct {
 		Login string `json:"login"`
 	} `json:"author"`
-	Reactions graphQLReactionSummary `json:"reactions"`
+	AuthorAssociation string                 `json:"authorAssociation"`
+	MinimizedReason   string                 `json:"minimizedReason"`
+	Reactions         graphQLReactionSummary `json:"reactions"`
+	IsMinimized       bool                   `json:"isMinimized"`
 }
 
 type graphQLReactionSummary struct {
@@ -291,13 +325,17 @@ type graphQLReactionSummary struct {
 
 func mapDiscussionReply(in discussionReplyPayload) CommentNode {
 	return CommentNode{
-		ID:        in.ID,
-		Author:    in.Author.Login,
-		Body:      in.Body,
-		CreatedAt: in.CreatedAt,
-		UpdatedAt: in.UpdatedAt,
-		URL:       in.URL,
-		Reactions: mapGraphQLReactions(in.Reactions),
+		ID:                in.ID,
+		NodeID:            in.ID,
+		Author:            loginOrGhost(in.Author.Login),
+		AuthorAssociation: in.AuthorAssociation,
+		IsMinimized:       in.IsMinimized,
+		MinimizedReason:   normalizeMinimizedReason(in.MinimizedReason),
+		Body:              in.Body,
+		CreatedAt:         in.CreatedAt,
+		UpdatedAt:         in.UpdatedAt,
+		URL:               in.URL,
+		Reactions:         mapGraphQLReactions(in.Reactions),
 	}
 }
 
@@ -69,16 +69,19 @@ func TestFetchDiscussion(t *testing.T) {
 				"data": map[string]any{
 					"repository": map[string]any{
 						"discussion": map[string]any{
-							"number":     9,
-							"title":      "Discussion title",
-							"body":       "Discussion body",
-							"url":        "https://github.com/octo/repo/discussions/9",
-							"createdAt":  "2026-01-01T00:00:00Z",
-							"updatedAt":  "2026-01-02T00:00:00Z",
-							"closed":     false,
-							"author":     map[string]any{"login": "alice"},
-							"category":   map[string]any{"name": "Q&A"},
-							"isAnswered": true,
+							"number":      9,
+							"title":       "Discussion title",
+							"body":        "Discussion body",
+							"url":         "https://github.com/octo/repo/discussions/9",
+							"createdAt":   "2026-01-01T00:00:00Z",
+							"updatedAt":   "2026-01-02T00:00:00Z",
+							"closed":      true,
+							"closedAt":    "2026-01-04T00:00:00Z",
+							"stateReason": "RESOLVED",
+							"locked":      true,
+							"author":      map[string]any{"login": "alice"},
+							"category":    map[string]any{"name": "Q&A"},
+							"isAnswered":  true,
 							"answer": map[string]any{
 								"id":     "answer-1",
 								"author": map[string]any{"login": "bob"},
@@ -193,6 +196,9 @@ func TestFetchDiscussion(t *testing.T) {
 	if got.Meta.AcceptedAnswerAuthor != "bob" {
 		t.Fatalf("AcceptedAnswerAuthor = %q, want bob", got.Meta.AcceptedAnswerAuthor)
 	}
+	if got.Meta.ClosedAt != "2026-01-04T00:00:00Z" || got.Meta.StateReason != "resolved" || !got.Meta.Locked {
+		t.Fatalf("discussion close metadata = (%q, %q, %t), want (2026-01-04T00:00:00Z, resolved, true)", got.Meta.ClosedAt, got.Meta.StateReason, got.Meta.Locked)
+	}
 	if got.Meta.AcceptedAnswerID != "answer-1" {
 		t.Fatalf("AcceptedAnswerID = %q, want answer-1", got.Meta.AcceptedAnswerID)
 	}
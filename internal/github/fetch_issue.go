@@ -2,35 +2,47 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
 	goGithub "github.com/google/go-github/v72/github"
 )
 
-const issueTimelineEventAssigned = "assigned"
-
 func (f *fetcher) fetchIssue(ctx context.Context, ref ResourceRef, opts FetchOptions) (IssueData, error) {
 	issue, err := f.rest.getIssue(ctx, ref.Owner, ref.Repo, ref.Number)
 	if err != nil {
 		return IssueData{}, fmt.Errorf("fetch issue resource: %w", err)
 	}
 
+	// GitHub serves pull requests under /issues/N too; export them with reviews and files.
+	ref = canonicalRef(ref, issue.GetHTMLURL())
+	if issue.IsPullRequest() {
+		ref.Type = ResourcePullRequest
+		return f.fetchPullRequest(ctx, ref, opts)
+	}
+
 	data := IssueData{
 		Meta: Metadata{
-			Type:      ResourceIssue,
-			Title:     issue.GetTitle(),
-			Number:    issue.GetNumber(),
-			State:     issue.GetState(),
-			Author:    issue.GetUser().GetLogin(),
-			CreatedAt: formatTimestamp(issue.CreatedAt),
-			UpdatedAt: formatTimestamp(issue.UpdatedAt),
-			URL:       issue.GetHTMLURL(),
-			Labels:    mapLabels(issue.Labels),
+			Type:        ResourceIssue,
+			NodeID:      issue.GetNodeID(),
+			Title:       issue.GetTitle(),
+			Number:      issue.GetNumber(),
+			State:       issue.GetState(),
+			Author:      loginOrGhost(issue.GetUser().GetLogin()),
+			CreatedAt:   formatTimestamp(issue.CreatedAt),
+			UpdatedAt:   formatTimestamp(issue.UpdatedAt),
+			URL:         issue.GetHTMLURL(),
+			Labels:      mapLabels(issue.Labels),
+			Assignees:   mapUserLogins(issue.Assignees),
+			Milestone:   issue.GetMilestone().GetTitle(),
+			ClosedAt:    formatTimestamp(issue.ClosedAt),
+			ClosedBy:    issue.GetClosedBy().GetLogin(),
+			StateReason: issue.GetStateReason(),
+			Locked:      issue.GetLocked(),
 		},
 		Description: issue.GetBody(),
 		Reactions:   mapReactions(issue.Reactions),
+		Resolved:    ref,
 	}
 
 	data.Timeline = append(data.Timeline, TimelineEvent{
@@ -57,144 +69,8 @@ func (f *fetcher) fetchIssue(ctx context.Context, ref ResourceRef, opts FetchOpt
 	return data, nil
 }
 
-type issueTimelinePayload struct {
-	Repository struct {
-		Issue *struct {
-			TimelineItems struct {
-				PageInfo struct {
-					EndCursor   string `json:"endCursor"`
-					HasNextPage bool   `json:"hasNextPage"`
-				} `json:"pageInfo"`
-				Nodes []issueTimelineNode `json:"nodes"`
-			} `json:"timelineItems"`
-		} `json:"issue"`
-	} `json:"repository"`
-}
-
-type issueTimelineNode struct {
-	TypeName string `json:"__typename"`
-	Created  string `json:"createdAt"`
-	Actor    struct {
-		Login string `json:"login"`
-	} `json:"actor"`
-	Label struct {
-		Name string `json:"name"`
-	} `json:"label"`
-	Assignee struct {
-		Login string `json:"login"`
-	} `json:"assignee"`
-	Milestone struct {
-		Title string `json:"title"`
-	} `json:"milestone"`
-	MilestoneTitle string `json:"milestoneTitle"`
-}
-
 func (f *fetcher) fetchIssueTimeline(ctx context.Context, ref ResourceRef) ([]TimelineEvent, error) {
-	query := `query IssueTimeline($owner:String!, $repo:String!, $number:Int!, $after:String) {
-  repository(owner:$owner, name:$repo) {
-    issue(number:$number) {
-      timelineItems(first:100, after:$after) {
-        nodes {
-          __typename
-          ... on ClosedEvent {
-            createdAt
-            actor { login }
-          }
-          ... on ReopenedEvent {
-            createdAt
-            actor { login }
-          }
-          ... on LabeledEvent {
-            createdAt
-            actor { login }
-            label { name }
-          }
-          ... on AssignedEvent {
-            createdAt
-            actor { login }
-            assignee {
-              __typename
-              ... on User { login }
-              ... on Bot { login }
-              ... on Mannequin { login }
-            }
-          }
-          ... on MilestonedEvent {
-            createdAt
-            actor { login }
-            milestoneTitle
-          }
-          ... on LockedEvent {
-            createdAt
-            actor { login }
-          }
-        }
-        pageInfo { hasNextPage endCursor }
-      }
-    }
-  }
-}`
-
-	var events []TimelineEvent
-	err := f.gql.QueryPaginated(ctx, query, map[string]any{
-		"owner":  ref.Owner,
-		"repo":   ref.Repo,
-		"number": ref.Number,
-	}, func(page json.RawMessage) (bool, string, error) {
-		var payload issueTimelinePayload
-		if err := json.Unmarshal(page, &payload); err != nil {
-			return false, "", fmt.Errorf("decode issue timeline page payload: %w", err)
-		}
-		if payload.Repository.Issue == nil {
-			return false, "", fmt.Errorf("issue timeline missing issue node: %w", ErrResourceNotFound)
-		}
-
-		for _, node := range payload.Repository.Issue.TimelineItems.Nodes {
-			eventType, details, ok := mapIssueTimelineNode(node)
-			if !ok {
-				continue
-			}
-			events = append(events, TimelineEvent{
-				EventType: eventType,
-				Actor:     node.Actor.Login,
-				CreatedAt: node.Created,
-				Details:   details,
-			})
-		}
-		return payload.Repository.Issue.TimelineItems.PageInfo.HasNextPage, payload.Repository.Issue.TimelineItems.PageInfo.EndCursor, nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("query timeline items: %w", err)
-	}
-
-	return events, nil
-}
-
-func mapIssueTimelineNode(node issueTimelineNode) (eventType, details string, ok bool) {
-	switch node.TypeName {
-	case "OpenedEvent":
-		return "opened", "", true
-	case "ClosedEvent":
-		return "closed", "", true
-	case "ReopenedEvent":
-		return "reopened", "", true
-	case "LabeledEvent":
-		return "labeled", node.Label.Name, true
-	case "AssignedEvent":
-		if node.Assignee.Login != "" {
-			return issueTimelineEventAssigned, node.Assignee.Login, true
-		}
-		return issueTimelineEventAssigned, node.Actor.Login, true
-	case "MilestonedEvent":
-		if node.MilestoneTitle != "" {
-			return "milestoned", node.MilestoneTitle, true
-		}
-		return "milestoned", node.Milestone.Title, true
-	case "LockedEvent":
-		return "locked", "", true
-	default:
-		return "", "", false
-	}
+	return f.fetchTimeline(ctx, ref, timelineFieldIssue, commonTimelineFragments+issueTimelineFragments)
 }
 
 func dedupeTimelineEvents(events []TimelineEvent) []TimelineEvent {
@@ -217,18 +93,33 @@ func mapIssueComments(comments []*goGithub.IssueComment) []CommentNode {
 	nodes := make([]CommentNode, 0, len(comments))
 	for _, comment := range comments {
 		nodes = append(nodes, CommentNode{
-			ID:        strconv.FormatInt(comment.GetID(), 10),
-			Author:    comment.GetUser().GetLogin(),
-			Body:      comment.GetBody(),
-			CreatedAt: formatTimestamp(comment.CreatedAt),
-			UpdatedAt: formatTimestamp(comment.UpdatedAt),
-			URL:       comment.GetHTMLURL(),
-			Reactions: mapReactions(comment.Reactions),
+			ID:                strconv.FormatInt(comment.GetID(), 10),
+			NodeID:            comment.GetNodeID(),
+			Author:            loginOrGhost(comment.GetUser().GetLogin()),
+			AuthorAssociation: comment.GetAuthorAssociation(),
+			Body:              comment.GetBody(),
+			CreatedAt:         formatTimestamp(comment.CreatedAt),
+			UpdatedAt:         formatTimestamp(comment.UpdatedAt),
+			URL:               comment.GetHTMLURL(),
+			Reactions:         mapReactions(comment.Reactions),
 		})
 	}
 	return nodes
 }
 
+func mapUserLogins(users []*goGithub.User) []string {
+	if len(users) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(users))
+	for _, user := range users {
+		if login := user.GetLogin(); login != "" {
+			out = append(out, login)
+		}
+	}
+	return out
+}
+
 func mapLabels(labels []*goGithub.Label) []Label {
 	result := make([]Label, 0, len(labels))
 	for _, label := range labels {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -16,15 +17,21 @@ func TestFetchIssue(t *testing.T) {
 		switch r.URL.Path {
 		case "/repos/octo/repo/issues/1":
 			return mustJSONResponse(t, http.StatusOK, map[string]any{
-				"number":     1,
-				"title":      "Issue title",
-				"state":      "open",
-				"body":       "Issue body",
-				"html_url":   "https://github.com/octo/repo/issues/1",
-				"created_at": "2026-01-01T00:00:00Z",
-				"updated_at": "2026-01-02T00:00:00Z",
-				"user":       map[string]any{"login": "alice"},
-				"labels":     []map[string]any{{"name": "bug"}},
+				"number":       1,
+				"title":        "Issue title",
+				"state":        "open",
+				"body":         "Issue body",
+				"html_url":     "https://github.com/octo/repo/issues/1",
+				"created_at":   "2026-01-01T00:00:00Z",
+				"updated_at":   "2026-01-02T00:00:00Z",
+				"user":         map[string]any{"login": "alice"},
+				"labels":       []map[string]any{{"name": "bug"}},
+				"assignees":    []map[string]any{{"login": "carol"}, {"login": "dave"}},
+				"milestone":    map[string]any{"title": "v1.0"},
+				"closed_at":    "2026-01-04T00:00:00Z",
+				"closed_by":    map[string]any{"login": "carol"},
+				"locked":       true,
+				"state_reason": "completed",
 				"reactions": map[string]any{
 					"+1":          2,
 					"-1":          1,
@@ -137,6 +144,15 @@ func TestFetchIssue(t *testing.T) {
 	if got.Reactions.Total != 4 {
 		t.Fatalf("top reactions total = %d, want 4", got.Reactions.Total)
 	}
+	if !reflect.DeepEqual(got.Meta.Assignees, []string{"carol", "dave"}) {
+		t.Fatalf("Meta.Assignees = %#v, want [carol dave]", got.Meta.Assignees)
+	}
+	if got.Meta.Milestone != "v1.0" || got.Meta.ClosedBy != "carol" || got.Meta.StateReason != "completed" || !got.Meta.Locked {
+		t.Fatalf("issue lifecycle metadata = %#v", got.Meta)
+	}
+	if got.Meta.ClosedAt != "2026-01-04T00:00:00Z" {
+		t.Fatalf("Meta.ClosedAt = %q, want 2026-01-04T00:00:00Z", got.Meta.ClosedAt)
+	}
 	if len(got.Thread) != 1 {
 		t.Fatalf("thread len = %d, want 1", len(got.Thread))
 	}
@@ -399,6 +415,153 @@ func TestFetchIssueTimelineAssignedEventUsesAssigneeLogin(t *testing.T) {
 	}
 }
 
+func TestFetchIssueTimelineReferenceEvents(t *testing.T) {
+	t.Parallel()
+
+	nodes := []map[string]any{
+		{
+			"__typename":  "ClosedEvent",
+			"createdAt":   "2026-01-02T00:00:00Z",
+			"actor":       map[string]any{"login": "bob"},
+			"stateReason": "COMPLETED",
+			"closer":      map[string]any{"__typename": "PullRequest", "url": "https://github.com/octo/repo/pull/9"},
+		},
+		{
+			"__typename":      "CrossReferencedEvent",
+			"createdAt":       "2026-01-03T00:00:00Z",
+			"actor":           map[string]any{"login": "carol"},
+			"willCloseTarget": true,
+			"source":          map[string]any{"__typename": "PullRequest", "url": "https://github.com/octo/other/pull/4"},
+		},
+		{
+			"__typename":       "ReferencedEvent",
+			"createdAt":        "2026-01-04T00:00:00Z",
+			"actor":            map[string]any{"login": "dave"},
+			"commit":           map[string]any{"abbreviatedOid": "abc1234"},
+			"commitRepository": map[string]any{"nameWithOwner": "octo/repo"},
+		},
+		{
+			"__typename":    "RenamedTitleEvent",
+			"createdAt":     "2026-01-05T00:00:00Z",
+			"actor":         map[string]any{"login": "erin"},
+			"previousTitle": "Old title",
+			"currentTitle":  "New title",
+		},
+		{
+			"__typename": "MarkedAsDuplicateEvent",
+			"createdAt":  "2026-01-06T00:00:00Z",
+			"actor":      map[string]any{"login": "frank"},
+			"canonical":  map[string]any{"__typename": "Issue", "url": "https://github.com/octo/repo/issues/2"},
+		},
+		{
+			"__typename":     "TransferredEvent",
+			"createdAt":      "2026-01-07T00:00:00Z",
+			"actor":          map[string]any{"login": "grace"},
+			"fromRepository": map[string]any{"nameWithOwner": "octo/legacy"},
+		},
+		{
+			"__typename": "UnlabeledEvent",
+			"createdAt":  "2026-01-08T00:00:00Z",
+			"actor":      map[string]any{"login": "heidi"},
+			"label":      map[string]any{"name": "bug"},
+		},
+		{
+			"__typename": "UnassignedEvent",
+			"createdAt":  "2026-01-09T00:00:00Z",
+			"actor":      map[string]any{"login": "ivan"},
+			"assignee":   map[string]any{"__typename": "User", "login": "judy"},
+		},
+		{
+			"__typename": "ConnectedEvent",
+			"createdAt":  "2026-01-10T00:00:00Z",
+			"actor":      map[string]any{"login": "mallory"},
+			"subject":    map[string]any{"__typename": "PullRequest", "url": "https://github.com/octo/repo/pull/11"},
+		},
+	}
+
+	var query string
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/repos/octo/repo/issues/1":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"number":     1,
+				"title":      "New title",
+				"state":      "closed",
+				"html_url":   "https://github.com/octo/repo/issues/1",
+				"created_at": "2026-01-01T00:00:00Z",
+				"updated_at": "2026-01-10T00:00:00Z",
+				"user":       map[string]any{"login": "alice"},
+			}), nil
+		case "/graphql":
+			query = decodeGraphQLQuery(t, r)
+			return timelineResponse(t, timelineFieldIssue, nodes), nil
+		default:
+			return notFoundResponse(r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewFetcher(Config{
+		HTTPClient:  clientHTTP,
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+	})
+	if err != nil {
+		t.Fatalf("NewFetcher error = %v, want nil", err)
+	}
+
+	got, err := fetcher.Fetch(context.Background(), ResourceRef{
+		Owner:  "octo",
+		Repo:   "repo",
+		Number: 1,
+		Type:   ResourceIssue,
+		URL:    "https://github.com/octo/repo/issues/1",
+	}, FetchOptions{})
+	if err != nil {
+		t.Fatalf("Fetch error = %v, want nil", err)
+	}
+
+	for _, fragment := range []string{"... on CrossReferencedEvent", "... on TransferredEvent", "stateReason"} {
+		if !strings.Contains(query, fragment) {
+			t.Fatalf("issue timeline query missing %q:\n%s", fragment, query)
+		}
+	}
+
+	tests := []struct {
+		eventType string
+		details   string
+	}{
+		{eventType: "closed", details: "completed via https://github.com/octo/repo/pull/9"},
+		{eventType: "cross_referenced", details: "https://github.com/octo/other/pull/4 (will close)"},
+		{eventType: "referenced", details: "abc1234 in octo/repo"},
+		{eventType: "renamed", details: "Old title → New title"},
+		{eventType: "marked_as_duplicate", details: "https://github.com/octo/repo/issues/2"},
+		{eventType: "transferred", details: "from octo/legacy"},
+		{eventType: "unlabeled", details: "bug"},
+		{eventType: "unassigned", details: "judy"},
+		{eventType: "connected", details: "https://github.com/octo/repo/pull/11"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			event := findTimelineEvent(got.Timeline, tt.eventType)
+			if event == nil {
+				t.Fatalf("timeline missing %s event: %#v", tt.eventType, got.Timeline)
+			}
+			if event.Details != tt.details {
+				t.Fatalf("%s details = %q, want %q", tt.eventType, event.Details, tt.details)
+			}
+		})
+	}
+}
+
+func findTimelineEvent(events []TimelineEvent, want string) *TimelineEvent {
+	for i := range events {
+		if events[i].EventType == want {
+			return &events[i]
+		}
+	}
+	return nil
+}
+
 func hasTimelineEvent(events []TimelineEvent, want string) bool {
 	for _, event := range events {
 		if event.EventType == want {
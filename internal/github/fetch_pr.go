@@ -3,7 +3,9 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 
 	goGithub "github.com/google/go-github/v72/github"
 )
@@ -13,6 +15,7 @@ func (f *fetcher) fetchPullRequest(ctx context.Context, ref ResourceRef, opts Fe
 	if err != nil {
 		return IssueData{}, fmt.Errorf("fetch pull request resource: %w", err)
 	}
+	ref = canonicalRef(ref, pr.GetHTMLURL())
 
 	// GitHub REST API does not include reactions on the PullRequest endpoint;
 	// fetch the issue envelope to retrieve them.
@@ -23,21 +26,73 @@ func (f *fetcher) fetchPullRequest(ctx context.Context, ref ResourceRef, opts Fe
 
 	data := IssueData{
 		Meta: Metadata{
-			Type:        ResourcePullRequest,
-			Title:       pr.GetTitle(),
-			Number:      pr.GetNumber(),
-			State:       pr.GetState(),
-			Author:      pr.GetUser().GetLogin(),
-			CreatedAt:   formatTimestamp(pr.CreatedAt),
-			UpdatedAt:   formatTimestamp(pr.UpdatedAt),
-			URL:         pr.GetHTMLURL(),
-			Labels:      mapLabels(pr.Labels),
-			Merged:      pr.GetMerged(),
-			MergedAt:    formatTimestamp(pr.MergedAt),
-			ReviewCount: pr.GetReviewComments(),
+			Type:               ResourcePullRequest,
+			NodeID:             pr.GetNodeID(),
+			Title:              pr.GetTitle(),
+			Number:             pr.GetNumber(),
+			State:              pr.GetState(),
+			Author:             loginOrGhost(pr.GetUser().GetLogin()),
+			CreatedAt:          formatTimestamp(pr.CreatedAt),
+			UpdatedAt:          formatTimestamp(pr.UpdatedAt),
+			URL:                pr.GetHTMLURL(),
+			Labels:             mapLabels(pr.Labels),
+			Merged:             pr.GetMerged(),
+			MergedAt:           formatTimestamp(pr.MergedAt),
+			ReviewCount:        pr.GetReviewComments(),
+			HeadSHA:            pr.GetHead().GetSHA(),
+			Assignees:          mapUserLogins(pr.Assignees),
+			Milestone:          pr.GetMilestone().GetTitle(),
+			ClosedAt:           formatTimestamp(pr.ClosedAt),
+			ClosedBy:           issueForPR.GetClosedBy().GetLogin(),
+			StateReason:        issueForPR.GetStateReason(),
+			Locked:             pr.GetLocked(),
+			Draft:              pr.GetDraft(),
+			BaseRef:            pr.GetBase().GetRef(),
+			HeadRef:            pr.GetHead().GetRef(),
+			Additions:          pr.GetAdditions(),
+			Deletions:          pr.GetDeletions(),
+			RequestedReviewers: mapRequestedReviewers(pr),
 		},
 		Description: pr.GetBody(),
 		Reactions:   mapReactions(issueForPR.Reactions),
+		Resolved:    ref,
+	}
+
+	data.Timeline = append(data.Timeline, TimelineEvent{
+		EventType: "opened",
+		Actor:     data.Meta.Author,
+		CreatedAt: data.Meta.CreatedAt,
+	})
+	timeline, err := f.fetchTimeline(ctx, ref, timelineFieldPullRequest, commonTimelineFragments+pullRequestTimelineFragments)
+	if err != nil {
+		return IssueData{}, fmt.Errorf("fetch pull request timeline: %w", err)
+	}
+	data.Timeline = dedupeTimelineEvents(append(data.Timeline, timeline...))
+
+	if opts.IncludeFiles {
+		files, err := f.rest.listPullRequestFiles(ctx, ref.Owner, ref.Repo, ref.Number)
+		if err != nil {
+			return IssueData{}, fmt.Errorf("fetch pull request files: %w", err)
+		}
+		data.Files = mapPRFiles(files)
+	}
+
+	if opts.IncludeCommits {
+		commits, err := f.rest.listPullRequestCommits(ctx, ref.Owner, ref.Repo, ref.Number)
+		if err != nil {
+			return IssueData{}, fmt.Errorf("fetch pull request commits: %w", err)
+		}
+		data.Commits = mapPRCommits(commits)
+	}
+
+	if opts.IncludeChecks && data.Meta.HeadSHA != "" {
+		checks, err := f.fetchHeadChecks(ctx, ref, data.Meta.HeadSHA)
+		if reason, ok := checksUnavailable(err); ok {
+			data.ChecksUnavailable = reason
+		} else if err != nil {
+			return IssueData{}, fmt.Errorf("fetch pull request checks: %w", err)
+		}
+		data.Checks = checks
 	}
 
 	if !opts.IncludeComments {
@@ -65,6 +120,9 @@ func (f *fetcher) fetchPullRequest(ctx context.Context, ref ResourceRef, opts Fe
 	if err != nil {
 		return IssueData{}, fmt.Errorf("fetch pull request review comments: %w", err)
 	}
+	if err := f.attachReviewThreads(ctx, ref, data.Reviews, reviewIDToIndex, comments); err != nil {
+		return IssueData{}, fmt.Errorf("fetch pull request review threads: %w", err)
+	}
 	for _, comment := range comments {
 		commentNode := mapPRComment(comment)
 		if index, ok := reviewIDToIndex[comment.GetPullRequestReviewID()]; ok {
@@ -84,7 +142,7 @@ func mapPRReviews(reviews []*goGithub.PullRequestReview) []ReviewData {
 		out = append(out, ReviewData{
 			ID:        strconv.FormatInt(review.GetID(), 10),
 			State:     review.GetState(),
-			Author:    review.GetUser().GetLogin(),
+			Author:    loginOrGhost(review.GetUser().GetLogin()),
 			Body:      review.GetBody(),
 			CreatedAt: formatTimestamp(review.SubmittedAt),
 			Reactions: ReactionSummary{},
@@ -94,13 +152,112 @@ func mapPRReviews(reviews []*goGithub.PullRequestReview) []ReviewData {
 }
 
 func mapPRComment(comment *goGithub.PullRequestComment) CommentNode {
-	return CommentNode{
-		ID:        strconv.FormatInt(comment.GetID(), 10),
-		Author:    comment.GetUser().GetLogin(),
-		Body:      comment.GetBody(),
-		CreatedAt: formatTimestamp(comment.CreatedAt),
-		UpdatedAt: formatTimestamp(comment.UpdatedAt),
-		URL:       comment.GetHTMLURL(),
-		Reactions: mapReactions(comment.Reactions),
+	node := CommentNode{
+		ID:                strconv.FormatInt(comment.GetID(), 10),
+		NodeID:            comment.GetNodeID(),
+		Author:            loginOrGhost(comment.GetUser().GetLogin()),
+		AuthorAssociation: comment.GetAuthorAssociation(),
+		Body:              comment.GetBody(),
+		CreatedAt:         formatTimestamp(comment.CreatedAt),
+		UpdatedAt:         formatTimestamp(comment.UpdatedAt),
+		URL:               comment.GetHTMLURL(),
+		Path:              comment.GetPath(),
+		DiffHunk:          comment.GetDiffHunk(),
+		Side:              comment.GetSide(),
+		Line:              comment.GetLine(),
+		StartLine:         comment.GetStartLine(),
+		Reactions:         mapReactions(comment.Reactions),
+	}
+	if comment.InReplyTo != nil {
+		node.InReplyToID = strconv.FormatInt(comment.GetInReplyTo(), 10)
+	}
+	return node
+}
+
+func mapPRFiles(files []*goGithub.CommitFile) []ChangedFile {
+	out := make([]ChangedFile, 0, len(files))
+	for _, file := range files {
+		out = append(out, ChangedFile{
+			Filename:         file.GetFilename(),
+			PreviousFilename: file.GetPreviousFilename(),
+			Status:           file.GetStatus(),
+			Patch:            file.GetPatch(),
+			Additions:        file.GetAdditions(),
+			Deletions:        file.GetDeletions(),
+			Changes:          file.GetChanges(),
+		})
+	}
+	return out
+}
+
+func (f *fetcher) fetchHeadChecks(ctx context.Context, ref ResourceRef, sha string) ([]CheckResult, error) {
+	runs, err := f.rest.listCheckRuns(ctx, ref.Owner, ref.Repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := f.rest.listCombinedStatuses(ctx, ref.Owner, ref.Repo, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]CheckResult, 0, len(runs)+len(statuses))
+	for _, run := range runs {
+		out = append(out, CheckResult{
+			Name:       run.GetName(),
+			Source:     "check_run",
+			Status:     run.GetStatus(),
+			Conclusion: run.GetConclusion(),
+			URL:        run.GetHTMLURL(),
+		})
+	}
+	for _, status := range statuses {
+		out = append(out, CheckResult{
+			Name:       status.GetContext(),
+			Source:     "status",
+			Conclusion: status.GetState(),
+			URL:        status.GetTargetURL(),
+		})
+	}
+	return out, nil
+}
+
+// checksUnavailable reports a checks error caused by missing access rather than a failed
+// request: fine-grained tokens and some apps cannot read checks, and the export goes on without.
+func checksUnavailable(err error) (string, bool) {
+	status, ok := StatusCode(err)
+	if !ok || (status != http.StatusForbidden && status != http.StatusNotFound) || IsRateLimitError(err) {
+		return "", false
 	}
+	return fmt.Sprintf("http status %d", status), true
+}
+
+func mapPRCommits(commits []*goGithub.RepositoryCommit) []CommitData {
+	out := make([]CommitData, 0, len(commits))
+	for _, commit := range commits {
+		author := commit.GetAuthor().GetLogin()
+		if author == "" {
+			// Commits authored with an email unknown to GitHub have no linked user.
+			author = commit.GetCommit().GetAuthor().GetName()
+		}
+		headline, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+		out = append(out, CommitData{
+			SHA:         commit.GetSHA(),
+			Author:      author,
+			Headline:    strings.TrimSpace(headline),
+			CommittedAt: formatTimestamp(commit.GetCommit().GetAuthor().Date),
+			URL:         commit.GetHTMLURL(),
+		})
+	}
+	return out
+}
+
+// mapRequestedReviewers lists pending user reviewers followed by pending team reviewers.
+func mapRequestedReviewers(pr *goGithub.PullRequest) []string {
+	out := mapUserLogins(pr.RequestedReviewers)
+	for _, team := range pr.RequestedTeams {
+		if slug := team.GetSlug(); slug != "" {
+			out = append(out, "team:"+slug)
+		}
+	}
+	return out
 }
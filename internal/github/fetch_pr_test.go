@@ -3,6 +3,8 @@ package github
 import (
 	"context"
 	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -40,18 +42,27 @@ func TestFetchPullRequest(t *testing.T) {
 			}), nil
 		case "/repos/octo/repo/pulls/2":
 			return mustJSONResponse(t, http.StatusOK, map[string]any{
-				"number":          2,
-				"title":           "PR title",
-				"state":           "open",
-				"body":            "PR body",
-				"html_url":        "https://github.com/octo/repo/pull/2",
-				"created_at":      "2026-01-01T00:00:00Z",
-				"updated_at":      "2026-01-02T00:00:00Z",
-				"merged":          true,
-				"merged_at":       "2026-01-03T00:00:00Z",
-				"user":            map[string]any{"login": "alice"},
-				"labels":          []map[string]any{{"name": "enhancement"}},
-				"review_comments": 1,
+				"number":              2,
+				"title":               "PR title",
+				"state":               "open",
+				"body":                "PR body",
+				"html_url":            "https://github.com/octo/repo/pull/2",
+				"created_at":          "2026-01-01T00:00:00Z",
+				"updated_at":          "2026-01-02T00:00:00Z",
+				"merged":              true,
+				"merged_at":           "2026-01-03T00:00:00Z",
+				"user":                map[string]any{"login": "alice"},
+				"labels":              []map[string]any{{"name": "enhancement"}},
+				"review_comments":     1,
+				"draft":               true,
+				"additions":           10,
+				"deletions":           4,
+				"base":                map[string]any{"ref": "main"},
+				"head":                map[string]any{"ref": "feature/x"},
+				"assignees":           []map[string]any{{"login": "alice"}},
+				"milestone":           map[string]any{"title": "v2"},
+				"requested_reviewers": []map[string]any{{"login": "carol"}},
+				"requested_teams":     []map[string]any{{"slug": "core"}},
 			}), nil
 		case "/repos/octo/repo/pulls/2/reviews":
 			return mustJSONResponse(t, http.StatusOK, []map[string]any{
@@ -84,6 +95,22 @@ func TestFetchPullRequest(t *testing.T) {
 					"user":                   map[string]any{"login": "orphan-user"},
 				},
 			}), nil
+		case "/graphql":
+			if strings.Contains(decodeGraphQLQuery(t, r), "timelineItems") {
+				return timelineResponse(t, "pullRequest", nil), nil
+			}
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"data": map[string]any{
+					"repository": map[string]any{
+						"pullRequest": map[string]any{
+							"reviewThreads": map[string]any{
+								"nodes":    []map[string]any{},
+								"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+							},
+						},
+					},
+				},
+			}), nil
 		default:
 			return notFoundResponse(r.URL.Path), nil
 		}
@@ -115,6 +142,18 @@ func TestFetchPullRequest(t *testing.T) {
 	if !got.Meta.Merged {
 		t.Fatal("Meta.Merged = false, want true")
 	}
+	if !got.Meta.Draft || got.Meta.BaseRef != "main" || got.Meta.HeadRef != "feature/x" {
+		t.Fatalf("pull request branch metadata = (%t, %q, %q), want (true, main, feature/x)", got.Meta.Draft, got.Meta.BaseRef, got.Meta.HeadRef)
+	}
+	if got.Meta.Additions != 10 || got.Meta.Deletions != 4 {
+		t.Fatalf("pull request changes = +%d -%d, want +10 -4", got.Meta.Additions, got.Meta.Deletions)
+	}
+	if !reflect.DeepEqual(got.Meta.RequestedReviewers, []string{"carol", "team:core"}) {
+		t.Fatalf("Meta.RequestedReviewers = %#v, want [carol team:core]", got.Meta.RequestedReviewers)
+	}
+	if !reflect.DeepEqual(got.Meta.Assignees, []string{"alice"}) || got.Meta.Milestone != "v2" {
+		t.Fatalf("pull request assignment metadata = (%#v, %q)", got.Meta.Assignees, got.Meta.Milestone)
+	}
 	if len(got.Reviews) != 1 {
 		t.Fatalf("reviews len = %d, want 1", len(got.Reviews))
 	}
@@ -133,4 +172,473 @@ func TestFetchPullRequest(t *testing.T) {
 	if got.Thread[1].Body != "orphan inline comment" {
 		t.Fatalf("second thread body = %q, want %q", got.Thread[1].Body, "orphan inline comment")
 	}
+	if len(got.Reviews[0].Threads) != 1 || got.Reviews[0].Threads[0].Comments[0].Body != "inline comment" {
+		t.Fatalf("review threads = %#v, want one thread rooted at inline comment", got.Reviews[0].Threads)
+	}
+}
+
+func TestFetchPullRequestIncludeFiles(t *testing.T) {
+	t.Parallel()
+
+	var filesRequested bool
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/repos/octo/repo/issues/3":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{"number": 3}), nil
+		case "/repos/octo/repo/pulls/3":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"number":   3,
+				"title":    "Rename config loader",
+				"state":    "open",
+				"html_url": "https://github.com/octo/repo/pull/3",
+				"user":     map[string]any{"login": "alice"},
+			}), nil
+		case "/repos/octo/repo/pulls/3/files":
+			filesRequested = true
+			if r.URL.Query().Get("page") == "2" {
+				return mustJSONResponse(t, http.StatusOK, []map[string]any{
+					{
+						"filename":  "assets/logo.png",
+						"status":    "added",
+						"additions": 0,
+						"deletions": 0,
+						"changes":   0,
+					},
+				}), nil
+			}
+			resp := mustJSONResponse(t, http.StatusOK, []map[string]any{
+				{
+					"filename":          "internal/config/loader.go",
+					"previous_filename": "internal/config/load.go",
+					"status":            "renamed",
+					"additions":         2,
+					"deletions":         1,
+					"changes":           3,
+					"patch":             "@@ -1,2 +1,3 @@\n-old\n+new\n+line",
+				},
+			})
+			resp.Header.Set("Link", `<https://api.test/repos/octo/repo/pulls/3/files?page=2>; rel="next"`)
+			return resp, nil
+		case "/graphql":
+			return timelineResponse(t, "pullRequest", nil), nil
+		default:
+			return notFoundResponse(r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewFetcher(Config{
+		HTTPClient:  clientHTTP,
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+	})
+	if err != nil {
+		t.Fatalf("NewFetcher error = %v, want nil", err)
+	}
+
+	ref := ResourceRef{Owner: "octo", Repo: "repo", Number: 3, Type: ResourcePullRequest}
+	got, err := fetcher.Fetch(context.Background(), ref, FetchOptions{IncludeFiles: true})
+	if err != nil {
+		t.Fatalf("Fetch error = %v, want nil", err)
+	}
+	if !filesRequested {
+		t.Fatal("files endpoint was not requested")
+	}
+	if len(got.Files) != 2 {
+		t.Fatalf("files len = %d, want 2", len(got.Files))
+	}
+	first := got.Files[0]
+	if first.Filename != "internal/config/loader.go" || first.PreviousFilename != "internal/config/load.go" {
+		t.Fatalf("first file = %+v, want renamed loader.go", first)
+	}
+	if first.Status != "renamed" || first.Additions != 2 || first.Deletions != 1 || first.Changes != 3 {
+		t.Fatalf("first file stats = %+v, want renamed +2 -1", first)
+	}
+	if first.Patch == "" {
+		t.Fatal("first file patch is empty, want patch")
+	}
+	if got.Files[1].Filename != "assets/logo.png" || got.Files[1].Patch != "" {
+		t.Fatalf("second file = %+v, want binary file without patch", got.Files[1])
+	}
+
+	filesRequested = false
+	got, err = fetcher.Fetch(context.Background(), ref, FetchOptions{})
+	if err != nil {
+		t.Fatalf("Fetch without files error = %v, want nil", err)
+	}
+	if filesRequested || len(got.Files) != 0 {
+		t.Fatalf("files fetched with IncludeFiles=false: requested=%t len=%d", filesRequested, len(got.Files))
+	}
+}
+
+func TestFetchPullRequestReviewThreads(t *testing.T) {
+	t.Parallel()
+
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/repos/octo/repo/issues/4":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{"number": 4}), nil
+		case "/repos/octo/repo/issues/4/comments":
+			return mustJSONResponse(t, http.StatusOK, []map[string]any{}), nil
+		case "/repos/octo/repo/pulls/4":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{"number": 4, "title": "Threads"}), nil
+		case "/repos/octo/repo/pulls/4/reviews":
+			return mustJSONResponse(t, http.StatusOK, []map[string]any{
+				{"id": 10, "state": "CHANGES_REQUESTED", "user": map[string]any{"login": "reviewer"}},
+				{"id": 11, "state": "COMMENTED", "user": map[string]any{"login": "alice"}},
+			}), nil
+		case "/repos/octo/repo/pulls/4/comments":
+			return mustJSONResponse(t, http.StatusOK, []map[string]any{
+				{
+					"id":                     100,
+					"body":                   "handle nil here",
+					"pull_request_review_id": 10,
+					"path":                   "main.go",
+					"line":                   14,
+					"start_line":             12,
+					"original_line":          14,
+					"side":                   "RIGHT",
+					"diff_hunk":              "@@ -10,3 +10,5 @@\n+x := load()",
+					"user":                   map[string]any{"login": "reviewer"},
+				},
+				{
+					"id":                     101,
+					"body":                   "done",
+					"pull_request_review_id": 11,
+					"in_reply_to_id":         100,
+					"path":                   "main.go",
+					"user":                   map[string]any{"login": "alice"},
+				},
+				{
+					"id":                     102,
+					"body":                   "typo",
+					"pull_request_review_id": 10,
+					"path":                   "README.md",
+					"original_line":          3,
+					"side":                   "LEFT",
+					"user":                   map[string]any{"login": "reviewer"},
+				},
+			}), nil
+		case "/graphql":
+			if strings.Contains(decodeGraphQLQuery(t, r), "timelineItems") {
+				return timelineResponse(t, "pullRequest", nil), nil
+			}
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"data": map[string]any{
+					"repository": map[string]any{
+						"pullRequest": map[string]any{
+							"reviewThreads": map[string]any{
+								"nodes": []map[string]any{
+									{
+										"id":           "PRRT_1",
+										"path":         "main.go",
+										"diffSide":     "RIGHT",
+										"line":         14,
+										"startLine":    12,
+										"originalLine": 14,
+										"isResolved":   true,
+										"isOutdated":   false,
+										"resolvedBy":   map[string]any{"login": "alice"},
+										"comments":     map[string]any{"nodes": []map[string]any{{"databaseId": 100}}},
+									},
+									{
+										"id":           "PRRT_2",
+										"path":         "README.md",
+										"diffSide":     "LEFT",
+										"originalLine": 3,
+										"isOutdated":   true,
+										"comments":     map[string]any{"nodes": []map[string]any{{"databaseId": 102}}},
+									},
+								},
+								"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+							},
+						},
+					},
+				},
+			}), nil
+		default:
+			return notFoundResponse(r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewFetcher(Config{
+		HTTPClient:  clientHTTP,
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+	})
+	if err != nil {
+		t.Fatalf("NewFetcher error = %v, want nil", err)
+	}
+
+	got, err := fetcher.Fetch(context.Background(), ResourceRef{Owner: "octo", Repo: "repo", Number: 4, Type: ResourcePullRequest}, FetchOptions{IncludeComments: true})
+	if err != nil {
+		t.Fatalf("Fetch error = %v, want nil", err)
+	}
+
+	threads := got.Reviews[0].Threads
+	if len(threads) != 2 {
+		t.Fatalf("first review threads len = %d, want 2", len(threads))
+	}
+	if len(got.Reviews[1].Threads) != 0 {
+		t.Fatalf("reply-only review threads len = %d, want 0", len(got.Reviews[1].Threads))
+	}
+
+	first := threads[0]
+	if first.ID != "PRRT_1" || first.Path != "main.go" || first.StartLine != 12 || first.Line != 14 || first.Side != "RIGHT" {
+		t.Fatalf("first thread anchor = %+v, want main.go lines 12-14 RIGHT", first)
+	}
+	if !first.IsResolved || first.ResolvedBy != "alice" || first.IsOutdated {
+		t.Fatalf("first thread state = resolved:%t by:%q outdated:%t, want resolved by alice", first.IsResolved, first.ResolvedBy, first.IsOutdated)
+	}
+	if first.DiffHunk == "" {
+		t.Fatal("first thread diff hunk is empty")
+	}
+	if len(first.Comments) != 2 || first.Comments[1].InReplyToID != "100" {
+		t.Fatalf("first thread comments = %#v, want root plus reply to 100", first.Comments)
+	}
+
+	second := threads[1]
+	if !second.IsOutdated || second.Line != 0 || second.OriginalLine != 3 {
+		t.Fatalf("second thread = %+v, want outdated thread at original line 3", second)
+	}
+}
+
+func TestFetchPullRequestCommitsAndChecks(t *testing.T) {
+	t.Parallel()
+
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/repos/octo/repo/issues/5":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{"number": 5}), nil
+		case "/repos/octo/repo/pulls/5":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"number": 5,
+				"title":  "Add retries",
+				"head":   map[string]any{"sha": "abc123", "ref": "feature/retries"},
+			}), nil
+		case "/repos/octo/repo/pulls/5/commits":
+			return mustJSONResponse(t, http.StatusOK, []map[string]any{
+				{
+					"sha":      "abc123",
+					"html_url": "https://github.com/octo/repo/commit/abc123",
+					"author":   map[string]any{"login": "alice"},
+					"commit": map[string]any{
+						"message": "Add retry loop\n\nLonger body.",
+						"author":  map[string]any{"name": "Alice", "date": "2026-01-02T03:04:05Z"},
+					},
+				},
+				{
+					"sha": "def456",
+					"commit": map[string]any{
+						"message": "Fix typo",
+						"author":  map[string]any{"name": "Unlinked Dev", "date": "2026-01-03T00:00:00Z"},
+					},
+				},
+			}), nil
+		case "/repos/octo/repo/commits/abc123/check-runs":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"total_count": 1,
+				"check_runs": []map[string]any{
+					{"name": "build", "status": "completed", "conclusion": "success", "html_url": "https://github.com/octo/repo/runs/1"},
+				},
+			}), nil
+		case "/repos/octo/repo/commits/abc123/status":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"state": "failure",
+				"statuses": []map[string]any{
+					{"context": "ci/legacy", "state": "failure", "target_url": "https://ci.example.com/1"},
+				},
+			}), nil
+		case "/graphql":
+			return timelineResponse(t, "pullRequest", nil), nil
+		default:
+			return notFoundResponse(r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewFetcher(Config{
+		HTTPClient:  clientHTTP,
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+	})
+	if err != nil {
+		t.Fatalf("NewFetcher error = %v, want nil", err)
+	}
+
+	got, err := fetcher.Fetch(context.Background(), ResourceRef{Owner: "octo", Repo: "repo", Number: 5, Type: ResourcePullRequest}, FetchOptions{
+		IncludeCommits: true,
+		IncludeChecks:  true,
+	})
+	if err != nil {
+		t.Fatalf("Fetch error = %v, want nil", err)
+	}
+
+	if got.Meta.HeadSHA != "abc123" {
+		t.Fatalf("Meta.HeadSHA = %q, want abc123", got.Meta.HeadSHA)
+	}
+	if len(got.Commits) != 2 {
+		t.Fatalf("commits len = %d, want 2", len(got.Commits))
+	}
+	first := got.Commits[0]
+	if first.SHA != "abc123" || first.Author != "alice" || first.Headline != "Add retry loop" || first.CommittedAt != "2026-01-02T03:04:05Z" {
+		t.Fatalf("first commit = %+v, want linked author and headline only", first)
+	}
+	if got.Commits[1].Author != "Unlinked Dev" {
+		t.Fatalf("second commit author = %q, want git author name fallback", got.Commits[1].Author)
+	}
+
+	want := []CheckResult{
+		{Name: "build", Source: "check_run", Status: "completed", Conclusion: "success", URL: "https://github.com/octo/repo/runs/1"},
+		{Name: "ci/legacy", Source: "status", Conclusion: "failure", URL: "https://ci.example.com/1"},
+	}
+	if len(got.Checks) != len(want) {
+		t.Fatalf("checks len = %d, want %d", len(got.Checks), len(want))
+	}
+	for idx := range want {
+		if got.Checks[idx] != want[idx] {
+			t.Fatalf("checks[%d] = %+v, want %+v", idx, got.Checks[idx], want[idx])
+		}
+	}
+}
+
+func TestFetchPullRequestChecksUnavailable(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name        string
+		failPath    string
+		wantReason  string
+		failStatus  int
+		wantFailure bool
+	}{
+		{name: "check runs forbidden", failPath: "/repos/octo/repo/commits/abc123/check-runs", failStatus: http.StatusForbidden, wantReason: "http status 403"},
+		{name: "statuses not found", failPath: "/repos/octo/repo/commits/abc123/status", failStatus: http.StatusNotFound, wantReason: "http status 404"},
+		{name: "other failures still fail", failPath: "/repos/octo/repo/commits/abc123/status", failStatus: http.StatusUnprocessableEntity, wantFailure: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+				switch r.URL.Path {
+				case tc.failPath:
+					return textHTTPResponse(tc.failStatus, `{"message":"Resource not accessible by personal access token"}`), nil
+				case "/repos/octo/repo/issues/5":
+					return mustJSONResponse(t, http.StatusOK, map[string]any{"number": 5}), nil
+				case "/repos/octo/repo/pulls/5":
+					return mustJSONResponse(t, http.StatusOK, map[string]any{"number": 5, "head": map[string]any{"sha": "abc123"}}), nil
+				case "/repos/octo/repo/commits/abc123/check-runs":
+					return mustJSONResponse(t, http.StatusOK, map[string]any{"total_count": 0, "check_runs": []any{}}), nil
+				case "/repos/octo/repo/commits/abc123/status":
+					return mustJSONResponse(t, http.StatusOK, map[string]any{"state": "success", "statuses": []any{}}), nil
+				case "/graphql":
+					return timelineResponse(t, "pullRequest", nil), nil
+				default:
+					return notFoundResponse(r.URL.Path), nil
+				}
+			})
+			fetcher, err := NewFetcher(Config{HTTPClient: clientHTTP, RESTBaseURL: "https://api.test/", GraphQLURL: "https://api.test/graphql"})
+			if err != nil {
+				t.Fatalf("NewFetcher error = %v, want nil", err)
+			}
+
+			got, err := fetcher.Fetch(context.Background(), ResourceRef{Owner: "octo", Repo: "repo", Number: 5, Type: ResourcePullRequest}, FetchOptions{IncludeChecks: true})
+			if tc.wantFailure {
+				if err == nil || !strings.Contains(err.Error(), "fetch pull request checks") {
+					t.Fatalf("Fetch error = %v, want checks failure", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Fetch error = %v, want nil", err)
+			}
+			if got.ChecksUnavailable != tc.wantReason || len(got.Checks) != 0 {
+				t.Fatalf("checks = (%q, %d), want (%q, 0)", got.ChecksUnavailable, len(got.Checks), tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestFetchPullRequestTimeline(t *testing.T) {
+	t.Parallel()
+
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/repos/octo/repo/issues/6":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{"number": 6}), nil
+		case "/repos/octo/repo/pulls/6":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"number":     6,
+				"title":      "Timeline",
+				"created_at": "2026-01-01T00:00:00Z",
+				"user":       map[string]any{"login": "alice"},
+			}), nil
+		case "/graphql":
+			query := decodeGraphQLQuery(t, r)
+			for _, fragment := range []string{"pullRequest(number:$number)", "... on HeadRefForcePushedEvent", "... on Team { name }", "... on DeploymentEnvironmentChangedEvent"} {
+				if !strings.Contains(query, fragment) {
+					t.Fatalf("pull request timeline query missing %q:\n%s", fragment, query)
+				}
+			}
+			if strings.Contains(query, "TransferredEvent") {
+				t.Fatalf("pull request timeline query should not select issue-only TransferredEvent:\n%s", query)
+			}
+			actor := map[string]any{"login": "alice"}
+			return timelineResponse(t, "pullRequest", []map[string]any{
+				{"__typename": "ReviewRequestedEvent", "createdAt": "2026-01-01T01:00:00Z", "actor": actor, "requestedReviewer": map[string]any{"login": "bob"}},
+				{"__typename": "ReviewRequestedEvent", "createdAt": "2026-01-01T01:00:01Z", "actor": actor, "requestedReviewer": map[string]any{"name": "core-team"}},
+				{"__typename": "HeadRefForcePushedEvent", "createdAt": "2026-01-01T02:00:00Z", "actor": actor, "beforeCommit": map[string]any{"abbreviatedOid": "aaa1111"}, "afterCommit": map[string]any{"abbreviatedOid": "bbb2222"}},
+				{"__typename": "ConvertToDraftEvent", "createdAt": "2026-01-01T03:00:00Z", "actor": actor},
+				{"__typename": "ReadyForReviewEvent", "createdAt": "2026-01-01T04:00:00Z", "actor": actor},
+				{"__typename": "BaseRefChangedEvent", "createdAt": "2026-01-01T05:00:00Z", "actor": actor, "previousRefName": "main", "currentRefName": "release"},
+				{"__typename": "AutoMergeEnabledEvent", "createdAt": "2026-01-01T06:00:00Z", "actor": actor},
+				{"__typename": "MergedEvent", "createdAt": "2026-01-01T07:00:00Z", "actor": actor, "commit": map[string]any{"abbreviatedOid": "ccc3333"}, "mergeRefName": "release"},
+				{"__typename": "DeployedEvent", "createdAt": "2026-01-01T08:00:00Z", "actor": actor, "deployment": map[string]any{"environment": "staging"}},
+				{"__typename": "DeploymentEnvironmentChangedEvent", "createdAt": "2026-01-01T09:00:00Z", "actor": actor, "deploymentStatus": map[string]any{"state": "SUCCESS", "deployment": map[string]any{"environment": "production"}}},
+				{"__typename": "SubscribedEvent", "createdAt": "2026-01-01T10:00:00Z"},
+			}), nil
+		default:
+			return notFoundResponse(r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewFetcher(Config{
+		HTTPClient:  clientHTTP,
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+	})
+	if err != nil {
+		t.Fatalf("NewFetcher error = %v, want nil", err)
+	}
+
+	got, err := fetcher.Fetch(context.Background(), ResourceRef{Owner: "octo", Repo: "repo", Number: 6, Type: ResourcePullRequest}, FetchOptions{})
+	if err != nil {
+		t.Fatalf("Fetch error = %v, want nil", err)
+	}
+
+	want := []struct {
+		eventType string
+		details   string
+	}{
+		{"opened", ""},
+		{"review_requested", "bob"},
+		{"review_requested", "core-team"},
+		{"head_ref_force_pushed", "aaa1111 → bbb2222"},
+		{"convert_to_draft", ""},
+		{"ready_for_review", ""},
+		{"base_ref_changed", "main → release"},
+		{"auto_merge_enabled", ""},
+		{"merged", "ccc3333 into release"},
+		{"deployed", "staging"},
+		{"deployment_environment_changed", "production (SUCCESS)"},
+	}
+	if len(got.Timeline) != len(want) {
+		t.Fatalf("timeline len = %d, want %d: %#v", len(got.Timeline), len(want), got.Timeline)
+	}
+	for idx, event := range want {
+		if got.Timeline[idx].EventType != event.eventType || got.Timeline[idx].Details != event.details {
+			t.Fatalf("timeline[%d] = %+v, want %s %q", idx, got.Timeline[idx], event.eventType, event.details)
+		}
+	}
 }
@@ -12,6 +12,45 @@ type fetcher struct {
 }
 
 func (f *fetcher) Fetch(ctx context.Context, ref ResourceRef, opts FetchOptions) (IssueData, error) {
+	data, err := f.dispatch(ctx, ref, opts)
+	if err != nil {
+		return IssueData{}, err
+	}
+	if opts.IncludeComments && data.Meta.Type != ResourceDiscussion {
+		if err := f.enrich(ctx, "minimized comments", func() error { return f.attachMinimizedState(ctx, &data) }); err != nil {
+			return IssueData{}, err
+		}
+	}
+	if opts.IncludeEdits {
+		if err := f.enrich(ctx, "edit history", func() error { return f.attachEditHistory(ctx, &data, opts.IncludeEditRevisions) }); err != nil {
+			return IssueData{}, err
+		}
+	}
+	return withResolvedRef(data, ref), nil
+}
+
+// HostToken returns the token this fetcher sends; a fetcher serves a single host.
+func (f *fetcher) HostToken(string) (string, error) {
+	tokens := f.cfg.tokenSource()
+	if tokens == nil {
+		return "", nil
+	}
+	token, err := tokens.Token()
+	if err != nil {
+		return "", fmt.Errorf("get GitHub token: %w", err)
+	}
+	return token.AccessToken, nil
+}
+
+// enrich runs a follow-up query over fetched data with the retry policy of the fetch itself.
+func (f *fetcher) enrich(ctx context.Context, label string, fn func() error) error {
+	if err := doWithRetry(ctx, f.cfg.MaxRetries, f.cfg.InitialBackoff, nil, fn); err != nil {
+		return fmt.Errorf("fetch %s: %w", label, err)
+	}
+	return nil
+}
+
+func (f *fetcher) dispatch(ctx context.Context, ref ResourceRef, opts FetchOptions) (IssueData, error) {
 	switch ref.Type {
 	case ResourceIssue:
 		return f.fetchWithRetry(ctx, "issue", func() (IssueData, error) {
@@ -43,3 +82,7 @@ func (f *fetcher) fetchWithRetry(ctx context.Context, label string, fn func() (I
 	}
 	return data, nil
 }
+
+func (f *fetcher) CacheStats() (hits, misses int64) {
+	return f.cfg.Cache.Stats()
+}
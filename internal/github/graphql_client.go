@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/johnqtcg/issue2md/internal/urlutil"
+	"golang.org/x/oauth2"
 )
 
 const (
@@ -20,8 +21,9 @@ const (
 
 type graphQLClient struct {
 	httpClient *http.Client
+	limiter    *rateLimiter
+	tokens     oauth2.TokenSource
 	endpoint   string
-	token      string
 }
 
 type graphQLRequest struct {
@@ -33,6 +35,15 @@ type graphQLErrorMessage struct {
 	Message string `json:"message"`
 }
 
+// graphQLRateLimit is the rateLimit field every query selects so the fetcher can pace itself.
+type graphQLRateLimit struct {
+	RateLimit *struct {
+		ResetAt   time.Time `json:"resetAt"`
+		Cost      int       `json:"cost"`
+		Remaining int       `json:"remaining"`
+	} `json:"rateLimit"`
+}
+
 type graphQLResponse struct {
 	Data   json.RawMessage       `json:"data"`
 	Errors []graphQLErrorMessage `json:"errors"`
@@ -43,6 +54,8 @@ func newGraphQLClient(cfg Config) (*graphQLClient, error) {
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: 30 * time.Second}
 	}
+	httpClient = cfg.rateLimits.wrapClient(httpClient, true)
+	httpClient = cfg.Cache.wrapClient(httpClient, true)
 
 	endpoint, err := resolveGraphQLEndpoint(cfg.GraphQLURL)
 	if err != nil {
@@ -51,8 +64,9 @@ func newGraphQLClient(cfg Config) (*graphQLClient, error) {
 
 	return &graphQLClient{
 		httpClient: httpClient,
+		limiter:    cfg.rateLimits,
+		tokens:     cfg.tokenSource(),
 		endpoint:   endpoint,
-		token:      cfg.Token,
 	}, nil
 }
 
@@ -115,8 +129,12 @@ func (c *graphQLClient) queryRaw(ctx context.Context, query string, variables ma
 		return nil, fmt.Errorf("create graphql request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	if c.tokens != nil {
+		token, err := c.tokens.Token()
+		if err != nil {
+			return nil, fmt.Errorf("get graphql token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
 	}
 
 	// #nosec G704 -- c.endpoint is validated by resolveGraphQLEndpoint before request creation.
@@ -149,10 +167,24 @@ func (c *graphQLClient) queryRaw(ctx context.Context, query string, variables ma
 	if len(envelope.Errors) > 0 {
 		return nil, fmt.Errorf("graphql returned errors: %s", envelope.Errors[0].Message)
 	}
+	if resp.Header.Get(cacheHitHeader) == "" {
+		// Like the REST limiter below the cache, only responses from GitHub report the quota.
+		c.recordRateLimit(envelope.Data)
+	}
 
 	return envelope.Data, nil
 }
 
+// recordRateLimit feeds the query's rateLimit field to the limiter. It refines the headers with
+// the remaining points after this query's cost.
+func (c *graphQLClient) recordRateLimit(data json.RawMessage) {
+	var payload graphQLRateLimit
+	if c.limiter == nil || json.Unmarshal(data, &payload) != nil || payload.RateLimit == nil {
+		return
+	}
+	c.limiter.record(graphQLResource, payload.RateLimit.Remaining, payload.RateLimit.ResetAt)
+}
+
 func copyVariables(in map[string]any) map[string]any {
 	if len(in) == 0 {
 		return map[string]any{}
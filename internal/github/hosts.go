@@ -0,0 +1,115 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultHost is the public GitHub web host.
+const DefaultHost = "github.com"
+
+// IsDefaultHost reports whether host refers to public GitHub rather than an Enterprise Server instance.
+func IsDefaultHost(host string) bool {
+	switch strings.ToLower(host) {
+	case "", DefaultHost, "www." + DefaultHost:
+		return true
+	default:
+		return false
+	}
+}
+
+// EnterpriseEndpoints derives the REST and GraphQL API endpoints served by a GitHub Enterprise Server host.
+func EnterpriseEndpoints(host string) (restBaseURL, graphQLURL string) {
+	host = strings.ToLower(host)
+	return "https://" + host + "/api/v3/", "https://" + host + "/api/graphql"
+}
+
+// NewHostFetcher returns a Fetcher that routes each ResourceRef to a client for ref.Host.
+// Public GitHub refs use base unchanged; Enterprise Server refs use endpoints derived by
+// EnterpriseEndpoints and the token from hostTokens, never base.Token.
+func NewHostFetcher(base Config, hostTokens map[string]string) (Fetcher, error) {
+	if token, ok := hostTokens[DefaultHost]; ok && token != "" {
+		base.Token = token
+	}
+	defaultFetcher, err := NewFetcher(base)
+	if err != nil {
+		return nil, fmt.Errorf("create %s fetcher: %w", DefaultHost, err)
+	}
+
+	return &hostFetcher{
+		base:   base,
+		tokens: hostTokens,
+		byHost: map[string]Fetcher{DefaultHost: defaultFetcher},
+	}, nil
+}
+
+type hostFetcher struct {
+	tokens map[string]string
+	byHost map[string]Fetcher
+	base   Config
+	mu     sync.Mutex
+}
+
+func (h *hostFetcher) Fetch(ctx context.Context, ref ResourceRef, opts FetchOptions) (IssueData, error) {
+	fetcher, err := h.fetcherFor(ref.Host)
+	if err != nil {
+		return IssueData{}, err
+	}
+	return fetcher.Fetch(ctx, ref, opts)
+}
+
+func (h *hostFetcher) Search(ctx context.Context, query string, opts SearchOptions) ([]ResourceRef, error) {
+	fetcher, err := h.fetcherFor(opts.Host)
+	if err != nil {
+		return nil, err
+	}
+	searcher, ok := fetcher.(Searcher)
+	if !ok {
+		return nil, fmt.Errorf("search host %q: fetcher does not support search", opts.Host)
+	}
+	return searcher.Search(ctx, query, opts)
+}
+
+func (h *hostFetcher) HostToken(host string) (string, error) {
+	fetcher, err := h.fetcherFor(host)
+	if err != nil {
+		return "", err
+	}
+	provider, ok := fetcher.(TokenProvider)
+	if !ok {
+		return "", fmt.Errorf("token for host %q: fetcher does not expose its token", host)
+	}
+	return provider.HostToken(host)
+}
+
+func (h *hostFetcher) fetcherFor(host string) (Fetcher, error) {
+	host = strings.ToLower(host)
+	if IsDefaultHost(host) {
+		host = DefaultHost
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if fetcher, ok := h.byHost[host]; ok {
+		return fetcher, nil
+	}
+
+	cfg := h.base
+	cfg.RESTBaseURL, cfg.GraphQLURL = EnterpriseEndpoints(host)
+	cfg.Token = h.tokens[host]
+	// A GitHub App is registered with one GitHub instance; Enterprise hosts keep per-host tokens.
+	cfg.App = nil
+	fetcher, err := NewFetcher(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create fetcher for host %q: %w", host, err)
+	}
+	h.byHost[host] = fetcher
+	return fetcher, nil
+}
+
+func (h *hostFetcher) CacheStats() (hits, misses int64) {
+	return h.base.Cache.Stats()
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEnterpriseEndpoints(t *testing.T) {
+	t.Parallel()
+
+	rest, graphql := EnterpriseEndpoints("GHE.Example.com")
+	if rest != "https://ghe.example.com/api/v3/" {
+		t.Fatalf("rest endpoint = %q, want https://ghe.example.com/api/v3/", rest)
+	}
+	if graphql != "https://ghe.example.com/api/graphql" {
+		t.Fatalf("graphql endpoint = %q, want https://ghe.example.com/api/graphql", graphql)
+	}
+}
+
+func TestIsDefaultHost(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		host string
+		want bool
+	}{
+		{host: "", want: true},
+		{host: "github.com", want: true},
+		{host: "WWW.GitHub.com", want: true},
+		{host: "ghe.example.com", want: false},
+	}
+	for _, tc := range tcs {
+		if got := IsDefaultHost(tc.host); got != tc.want {
+			t.Fatalf("IsDefaultHost(%q) = %t, want %t", tc.host, got, tc.want)
+		}
+	}
+}
+
+func TestHostFetcherRoutesByHost(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu   sync.Mutex
+		seen = map[string]string{}
+	)
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		seen[r.URL.Host+r.URL.Path] = r.Header.Get("Authorization")
+		mu.Unlock()
+
+		switch r.URL.Host + r.URL.Path {
+		case "api.test/repos/octo/repo/issues/1", "ghe.example.com/api/v3/repos/corp/app/issues/7":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"number":     1,
+				"title":      "Issue title",
+				"state":      "open",
+				"html_url":   "https://example.invalid/issues/1",
+				"created_at": "2026-01-01T00:00:00Z",
+				"updated_at": "2026-01-01T00:00:00Z",
+				"user":       map[string]any{"login": "alice"},
+			}), nil
+		case "api.test/graphql", "ghe.example.com/api/graphql":
+			return timelineResponse(t, timelineFieldIssue, nil), nil
+		default:
+			return notFoundResponse(r.URL.Host + r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewHostFetcher(Config{
+		HTTPClient:  clientHTTP,
+		Token:       "public-token",
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+	}, map[string]string{"ghe.example.com": "enterprise-token"})
+	if err != nil {
+		t.Fatalf("NewHostFetcher error = %v, want nil", err)
+	}
+
+	refs := []ResourceRef{
+		{Owner: "octo", Repo: "repo", Number: 1, Type: ResourceIssue},
+		{Owner: "corp", Repo: "app", Number: 7, Type: ResourceIssue, Host: "ghe.example.com"},
+	}
+	for _, ref := range refs {
+		if _, err := fetcher.Fetch(context.Background(), ref, FetchOptions{}); err != nil {
+			t.Fatalf("Fetch(%s) error = %v, want nil", ref.Host, err)
+		}
+	}
+
+	if got := seen["api.test/repos/octo/repo/issues/1"]; got != "Bearer public-token" {
+		t.Fatalf("public REST authorization = %q, want Bearer public-token", got)
+	}
+	if got := seen["ghe.example.com/api/v3/repos/corp/app/issues/7"]; got != "Bearer enterprise-token" {
+		t.Fatalf("enterprise REST authorization = %q, want Bearer enterprise-token", got)
+	}
+	if _, ok := seen["ghe.example.com/api/graphql"]; !ok {
+		t.Fatalf("enterprise GraphQL endpoint was not called: %#v", seen)
+	}
+}
+
+func TestHostFetcherHostToken(t *testing.T) {
+	t.Parallel()
+
+	_, keyPEM := newTestAppKey(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/installations/42/access_tokens" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"token":"ghs_installation","expires_at":%q}`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	}))
+	t.Cleanup(server.Close)
+
+	tcs := []struct {
+		name string
+		base Config
+		host string
+		want string
+	}{
+		{
+			name: "app installation token for the default host",
+			base: Config{
+				HTTPClient:  server.Client(),
+				RESTBaseURL: server.URL + "/",
+				App:         &AppAuth{AppID: 7, InstallationID: 42, PrivateKey: keyPEM},
+			},
+			host: "GitHub.com",
+			want: "ghs_installation",
+		},
+		{
+			name: "static token for the default host",
+			base: Config{Token: "public-token"},
+			host: DefaultHost,
+			want: "public-token",
+		},
+		{
+			name: "enterprise hosts keep their own token under app auth",
+			base: Config{
+				HTTPClient:  server.Client(),
+				RESTBaseURL: server.URL + "/",
+				App:         &AppAuth{AppID: 7, InstallationID: 42, PrivateKey: keyPEM},
+			},
+			host: "ghe.example.com",
+			want: "enterprise-token",
+		},
+		{
+			name: "hosts without a token are anonymous",
+			base: Config{Token: "public-token"},
+			host: "other.example.com",
+			want: "",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fetcher, err := NewHostFetcher(tc.base, map[string]string{"ghe.example.com": "enterprise-token"})
+			if err != nil {
+				t.Fatalf("NewHostFetcher error = %v, want nil", err)
+			}
+			provider, ok := fetcher.(TokenProvider)
+			if !ok {
+				t.Fatalf("%T does not implement TokenProvider", fetcher)
+			}
+			got, err := provider.HostToken(tc.host)
+			if err != nil {
+				t.Fatalf("HostToken(%q) error = %v, want nil", tc.host, err)
+			}
+			if got != tc.want {
+				t.Fatalf("HostToken(%q) = %q, want %q", tc.host, got, tc.want)
+			}
+		})
+	}
+}
@@ -56,3 +56,33 @@ func mustJSONResponse(t *testing.T, statusCode int, payload any) *http.Response
 func notFoundResponse(path string) *http.Response {
 	return textHTTPResponse(http.StatusNotFound, fmt.Sprintf(`{"message":"not found: %s"}`, path))
 }
+
+func decodeGraphQLQuery(t *testing.T, r *http.Request) string {
+	t.Helper()
+	var req struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		t.Fatalf("decode graphql request: %v", err)
+	}
+	return req.Query
+}
+
+func timelineResponse(t *testing.T, field string, nodes []map[string]any) *http.Response {
+	t.Helper()
+	if nodes == nil {
+		nodes = []map[string]any{}
+	}
+	return mustJSONResponse(t, http.StatusOK, map[string]any{
+		"data": map[string]any{
+			"repository": map[string]any{
+				field: map[string]any{
+					"timelineItems": map[string]any{
+						"nodes":    nodes,
+						"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+					},
+				},
+			},
+		},
+	})
+}
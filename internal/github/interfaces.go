@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"golang.org/x/oauth2"
 )
 
 const (
@@ -24,6 +26,17 @@ var ErrResourceNotFound = errors.New("github resource not found")
 // FetchOptions controls fetch-time behavior.
 type FetchOptions struct {
 	IncludeComments bool
+	// IncludeFiles fetches the changed-file list (with patches) for pull requests.
+	IncludeFiles bool
+	// IncludeCommits fetches the commit list for pull requests.
+	IncludeCommits bool
+	// IncludeChecks fetches check runs and commit statuses for the pull request head commit.
+	IncludeChecks bool
+	// IncludeEdits fetches the edit count, last editor and edit times of the description and
+	// every comment.
+	IncludeEdits bool
+	// IncludeEditRevisions also fetches the text of each revision; it requires IncludeEdits.
+	IncludeEditRevisions bool
 }
 
 // Fetcher defines the contract for fetching and normalizing one GitHub resource.
@@ -31,14 +44,53 @@ type Fetcher interface {
 	Fetch(ctx context.Context, ref ResourceRef, opts FetchOptions) (IssueData, error)
 }
 
+// MaxSearchResults is the most results the GitHub search API returns for one query; larger
+// result sets have to be split, for example by created: date ranges.
+const MaxSearchResults = 1000
+
+// SearchOptions controls resource enumeration for a search query.
+type SearchOptions struct {
+	// Host selects the GitHub host to search; empty means DefaultHost.
+	Host string
+	// Limit caps the number of returned resources.
+	Limit int
+}
+
+// Searcher enumerates issues, pull requests and discussions matching a GitHub search query.
+type Searcher interface {
+	Search(ctx context.Context, query string, opts SearchOptions) ([]ResourceRef, error)
+}
+
+// TokenProvider reports the token a fetcher sends to a GitHub host, so other requests to that
+// host, such as attachment downloads, can reuse it. With App auth it is the current installation
+// token; an empty token means the host is accessed anonymously.
+type TokenProvider interface {
+	HostToken(host string) (string, error)
+}
+
 // Config configures the GitHub fetcher client.
 type Config struct {
-	HTTPClient     *http.Client
+	// HTTPClient sends API requests; its Timeout bounds each attempt, not the pauses for a rate
+	// limit reset.
+	HTTPClient *http.Client
+	// Cache, when set, serves REST and GraphQL responses from disk; nil disables caching.
+	Cache *HTTPCache
+	// App, when set, authenticates as a GitHub App installation and takes precedence over Token.
+	App *AppAuth
+	// OnRateLimitWait, when set, is called before pausing until an exhausted rate limit resets.
+	OnRateLimitWait func(RateLimitWait)
+	// rateLimits is shared by the REST and GraphQL clients of one fetcher.
+	rateLimits *rateLimiter
+	// tokens is the shared credential source for App auth; static tokens need none.
+	tokens         oauth2.TokenSource
 	Token          string
 	RESTBaseURL    string
 	GraphQLURL     string
 	MaxRetries     int
 	InitialBackoff time.Duration
+	// MaxRateLimitWait caps the pause for an exhausted primary rate limit; a later reset fails
+	// the request with ErrRateLimitWaitExceeded.
+	MaxRateLimitWait time.Duration
 }
 
 // WithDefaults fills missing optional values with package defaults.
@@ -49,9 +101,23 @@ func (c Config) WithDefaults() Config {
 	if c.InitialBackoff == 0 {
 		c.InitialBackoff = DefaultInitialBackoff
 	}
+	if c.MaxRateLimitWait == 0 {
+		c.MaxRateLimitWait = DefaultMaxRateLimitWait
+	}
 	return c
 }
 
+// tokenSource returns the credential source for API requests, or nil for anonymous access.
+func (c Config) tokenSource() oauth2.TokenSource {
+	if c.tokens != nil {
+		return c.tokens
+	}
+	if c.Token != "" {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.Token})
+	}
+	return nil
+}
+
 // NewFetcher constructs a fetcher instance.
 func NewFetcher(cfg Config) (Fetcher, error) {
 	cfg = cfg.WithDefaults()
@@ -61,6 +127,17 @@ func NewFetcher(cfg Config) (Fetcher, error) {
 	if cfg.InitialBackoff < 0 {
 		return nil, fmt.Errorf("invalid InitialBackoff %s", cfg.InitialBackoff)
 	}
+	if cfg.MaxRateLimitWait < 0 {
+		return nil, fmt.Errorf("invalid MaxRateLimitWait %s", cfg.MaxRateLimitWait)
+	}
+	cfg.rateLimits = newRateLimiter(cfg.MaxRateLimitWait, cfg.OnRateLimitWait)
+	if cfg.App != nil {
+		tokens, err := newAppTokenSource(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("configure GitHub App auth: %w", err)
+		}
+		cfg.tokens = tokens
+	}
 
 	restClient, err := newRESTClient(cfg)
 	if err != nil {
@@ -18,6 +18,9 @@ func TestConfigWithDefaults(t *testing.T) {
 	if cfg.InitialBackoff != 2*time.Second {
 		t.Fatalf("InitialBackoff = %s, want 2s", cfg.InitialBackoff)
 	}
+	if cfg.MaxRateLimitWait != time.Hour {
+		t.Fatalf("MaxRateLimitWait = %s, want 1h", cfg.MaxRateLimitWait)
+	}
 }
 
 func TestNewFetcherReturnsFetcher(t *testing.T) {
@@ -0,0 +1,295 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxRateLimitWait caps how long a request pauses for an exhausted primary rate limit.
+	DefaultMaxRateLimitWait = time.Hour
+	// rateLimitThrottleBelow is the remaining quota under which requests are spread evenly over
+	// the time left until the reset instead of being sent back to back.
+	rateLimitThrottleBelow = 50
+	// rateLimitThrottleMax caps one throttling pause: with few requests left and a distant reset,
+	// spreading them evenly would stall a run, so the quota is spent and the reset awaited instead.
+	rateLimitThrottleMax = time.Minute
+	// rateLimitNotifyAfter is the throttling pause from which callers are told about the wait.
+	rateLimitNotifyAfter = 5 * time.Second
+
+	restCoreResource   = "core"
+	restSearchResource = "search"
+	graphQLResource    = "graphql"
+)
+
+// ErrRateLimitWaitExceeded indicates a primary rate limit resets later than the configured max wait.
+var ErrRateLimitWaitExceeded = errors.New("rate limit reset exceeds max wait")
+
+// RateLimitWait describes a pause until a GitHub rate limit resets.
+type RateLimitWait struct {
+	ResumeAt time.Time
+	// Resource is the GitHub rate limit bucket, such as core, search or graphql.
+	Resource string
+}
+
+type rateBucket struct {
+	resetAt   time.Time
+	remaining int
+}
+
+// rateLimiter tracks the primary rate limit per resource from response headers and GraphQL
+// rateLimit fields. It throttles requests when the quota runs low and pauses them until the
+// reset once it is exhausted. A nil limiter never waits.
+type rateLimiter struct {
+	now     func() time.Time
+	sleep   sleepFunc
+	notify  func(RateLimitWait)
+	buckets map[string]rateBucket
+	maxWait time.Duration
+	mu      sync.Mutex
+}
+
+func newRateLimiter(maxWait time.Duration, notify func(RateLimitWait)) *rateLimiter {
+	return &rateLimiter{
+		now:     time.Now,
+		sleep:   sleepContext,
+		notify:  notify,
+		buckets: map[string]rateBucket{},
+		maxWait: maxWait,
+	}
+}
+
+// before delays a request for resource according to the last known quota.
+func (l *rateLimiter) before(ctx context.Context, resource string) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	bucket, ok := l.buckets[resource]
+	l.mu.Unlock()
+
+	now := l.now()
+	if !ok || !now.Before(bucket.resetAt) {
+		return nil
+	}
+	if bucket.remaining <= 0 {
+		return l.waitForReset(ctx, resource, bucket.resetAt)
+	}
+	if bucket.remaining < rateLimitThrottleBelow {
+		return l.throttle(ctx, resource, bucket.resetAt.Sub(now)/time.Duration(bucket.remaining+1))
+	}
+	return nil
+}
+
+// throttle pauses for delay, capped at the max wait and rateLimitThrottleMax, and reports
+// noticeable pauses like a wait for the reset.
+func (l *rateLimiter) throttle(ctx context.Context, resource string, delay time.Duration) error {
+	delay = min(delay, l.maxWait, rateLimitThrottleMax)
+	if delay <= 0 {
+		return nil
+	}
+	if delay >= rateLimitNotifyAfter && l.notify != nil {
+		l.notify(RateLimitWait{ResumeAt: l.now().Add(delay), Resource: resource})
+	}
+	if err := l.sleep(ctx, delay); err != nil {
+		return fmt.Errorf("throttle %s requests: %w", resource, err)
+	}
+	return nil
+}
+
+// waitForReset pauses until resetAt, or fails with ErrRateLimitWaitExceeded when that is
+// further away than the max wait.
+func (l *rateLimiter) waitForReset(ctx context.Context, resource string, resetAt time.Time) error {
+	delay := resetAt.Sub(l.now())
+	if delay <= 0 {
+		return nil
+	}
+	if delay > l.maxWait {
+		return fmt.Errorf("%s rate limit resets at %s, %s from now: %w", resource, resetAt.UTC().Format(time.RFC3339), delay.Round(time.Second), ErrRateLimitWaitExceeded)
+	}
+	if l.notify != nil {
+		l.notify(RateLimitWait{ResumeAt: resetAt, Resource: resource})
+	}
+	if err := l.sleep(ctx, delay); err != nil {
+		return fmt.Errorf("wait for %s rate limit reset: %w", resource, err)
+	}
+
+	l.mu.Lock()
+	delete(l.buckets, resource)
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *rateLimiter) record(resource string, remaining int, resetAt time.Time) {
+	if l == nil || resetAt.IsZero() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buckets[resource] = rateBucket{resetAt: resetAt, remaining: remaining}
+}
+
+// observe records the X-RateLimit-* headers of a response and returns the resource they
+// describe, which GitHub names in X-RateLimit-Resource.
+func (l *rateLimiter) observe(resource string, header http.Header) string {
+	if name := strings.TrimSpace(headerValue(header, "X-RateLimit-Resource")); name != "" {
+		resource = name
+	}
+	remaining, err := strconv.Atoi(strings.TrimSpace(headerValue(header, "X-RateLimit-Remaining")))
+	if err != nil {
+		return resource
+	}
+	if resetAt, ok := parseRateLimitReset(header); ok {
+		l.record(resource, remaining, resetAt)
+	}
+	return resource
+}
+
+// wrapClient returns a copy of client whose transport applies the limiter. The client Timeout
+// would also bound the pauses for a reset, so the copy applies it to each attempt instead.
+func (l *rateLimiter) wrapClient(client *http.Client, graphQL bool) *http.Client {
+	if l == nil {
+		return client
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	wrapped := *client
+	wrapped.Transport = &rateLimitTransport{base: base, limiter: l, graphQL: graphQL, timeout: client.Timeout}
+	wrapped.Timeout = 0
+	return &wrapped
+}
+
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+	graphQL bool
+	// timeout bounds one attempt, from sending the request to closing the response body.
+	timeout time.Duration
+}
+
+// RoundTrip waits for quota before sending and, when the response reports an exhausted
+// primary limit, waits for the reset and sends the request once more.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := t.resource(req)
+	if err := t.limiter.before(req.Context(), resource); err != nil {
+		return nil, err
+	}
+	resp, err := t.send(req)
+	if err != nil {
+		return nil, err
+	}
+	resource = t.limiter.observe(resource, resp.Header)
+
+	resetAt, exhausted, err := t.exhausted(resp)
+	if err != nil || !exhausted {
+		return resp, err
+	}
+	retry, err := rewindRequest(req)
+	if err != nil {
+		// Without a replayable body the caller sees the rate limit error instead.
+		return resp, nil
+	}
+	if err := resp.Body.Close(); err != nil {
+		return nil, fmt.Errorf("close rate limited response: %w", err)
+	}
+	if err := t.limiter.waitForReset(req.Context(), resource, resetAt); err != nil {
+		return nil, err
+	}
+	resp, err = t.send(retry)
+	if err != nil {
+		return nil, err
+	}
+	t.limiter.observe(resource, resp.Header)
+	return resp, nil
+}
+
+// send performs one attempt under the attempt timeout, which ends when the body is closed.
+func (t *rateLimitTransport) send(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the attempt context of a response once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+func (t *rateLimitTransport) resource(req *http.Request) string {
+	switch {
+	case t.graphQL:
+		return graphQLResource
+	case strings.Contains(req.URL.Path, "/search/"):
+		return restSearchResource
+	default:
+		return restCoreResource
+	}
+}
+
+// exhausted reports whether resp was rejected by the primary rate limit. REST answers 403 or
+// 429; GraphQL answers 200 with a RATE_LIMITED error, so its body is inspected and restored.
+func (t *rateLimitTransport) exhausted(resp *http.Response) (time.Time, bool, error) {
+	if strings.TrimSpace(headerValue(resp.Header, "X-RateLimit-Remaining")) != "0" {
+		return time.Time{}, false, nil
+	}
+	resetAt, ok := parseRateLimitReset(resp.Header)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	switch {
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests:
+		return resetAt, true, nil
+	case t.graphQL && resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		if closeErr := resp.Body.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("read graphql response for rate limit: %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resetAt, bytes.Contains(body, []byte(`"RATE_LIMITED"`)), nil
+	default:
+		return time.Time{}, false, nil
+	}
+}
+
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return retry, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("request body cannot be replayed")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("replay request body: %w", err)
+	}
+	retry.Body = body
+	return retry, nil
+}
@@ -0,0 +1,285 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestRateLimiter(now time.Time, maxWait time.Duration) (*rateLimiter, *[]time.Duration, *[]RateLimitWait) {
+	var sleeps []time.Duration
+	var waits []RateLimitWait
+	limiter := newRateLimiter(maxWait, func(wait RateLimitWait) {
+		waits = append(waits, wait)
+	})
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return limiter, &sleeps, &waits
+}
+
+func rateLimitHeader(remaining int, resetAt time.Time) http.Header {
+	return http.Header{
+		"X-Ratelimit-Remaining": []string{strconv.Itoa(remaining)},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(resetAt.Unix(), 10)},
+	}
+}
+
+func TestRateLimiterBefore(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0).UTC()
+	tcs := []struct {
+		bucket     *rateBucket
+		wantErr    error
+		wantResume time.Time
+		name       string
+		wantSleeps []time.Duration
+		maxWait    time.Duration
+		wantWaits  int
+	}{
+		{name: "unknown quota"},
+		{name: "reset already passed", bucket: &rateBucket{remaining: 0, resetAt: now.Add(-time.Second)}},
+		{name: "plenty remaining", bucket: &rateBucket{remaining: 4000, resetAt: now.Add(time.Hour)}},
+		{name: "low quota is spread until reset", bucket: &rateBucket{remaining: 9, resetAt: now.Add(20 * time.Second)}, wantSleeps: []time.Duration{2 * time.Second}},
+		{
+			name: "noticeable throttle is reported", bucket: &rateBucket{remaining: 9, resetAt: now.Add(100 * time.Second)},
+			wantSleeps: []time.Duration{10 * time.Second}, wantWaits: 1, wantResume: now.Add(10 * time.Second),
+		},
+		{
+			name: "throttle capped for a distant reset", bucket: &rateBucket{remaining: 1, resetAt: now.Add(50 * time.Minute)},
+			wantSleeps: []time.Duration{rateLimitThrottleMax}, wantWaits: 1, wantResume: now.Add(rateLimitThrottleMax),
+		},
+		{
+			name: "throttle capped at max wait", bucket: &rateBucket{remaining: 1, resetAt: now.Add(50 * time.Minute)}, maxWait: 30 * time.Second,
+			wantSleeps: []time.Duration{30 * time.Second}, wantWaits: 1, wantResume: now.Add(30 * time.Second),
+		},
+		{name: "exhausted waits for reset", bucket: &rateBucket{remaining: 0, resetAt: now.Add(30 * time.Second)}, wantSleeps: []time.Duration{30 * time.Second}, wantWaits: 1},
+		{name: "reset beyond max wait fails", bucket: &rateBucket{remaining: 0, resetAt: now.Add(2 * time.Hour)}, wantErr: ErrRateLimitWaitExceeded},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			maxWait := tc.maxWait
+			if maxWait == 0 {
+				maxWait = time.Hour
+			}
+			limiter, sleeps, waits := newTestRateLimiter(now, maxWait)
+			if tc.bucket != nil {
+				limiter.record(restCoreResource, tc.bucket.remaining, tc.bucket.resetAt)
+			}
+
+			err := limiter.before(context.Background(), restCoreResource)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("before() error = %v, want %v", err, tc.wantErr)
+			}
+			if !slices.Equal(*sleeps, tc.wantSleeps) {
+				t.Fatalf("sleeps = %v, want %v", *sleeps, tc.wantSleeps)
+			}
+			if len(*waits) != tc.wantWaits {
+				t.Fatalf("notified waits = %v, want %d", *waits, tc.wantWaits)
+			}
+			wantResume := tc.wantResume
+			if wantResume.IsZero() && tc.bucket != nil {
+				wantResume = tc.bucket.resetAt
+			}
+			if tc.wantWaits > 0 && !(*waits)[0].ResumeAt.Equal(wantResume) {
+				t.Fatalf("ResumeAt = %s, want %s", (*waits)[0].ResumeAt, wantResume)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransportWaitsForResetAndResends(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0).UTC()
+	resetAt := now.Add(20 * time.Second)
+	limiter, sleeps, waits := newTestRateLimiter(now, time.Hour)
+
+	calls := 0
+	client := limiter.wrapClient(newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			resp := textHTTPResponse(http.StatusForbidden, `{"message":"API rate limit exceeded"}`)
+			resp.Header = rateLimitHeader(0, resetAt)
+			return resp, nil
+		}
+		resp := mustJSONResponse(t, http.StatusOK, map[string]any{"number": 1})
+		resp.Header = rateLimitHeader(4999, resetAt.Add(time.Hour))
+		return resp, nil
+	}), false)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.github.com/repos/o/r/issues/1", nil)
+	if err != nil {
+		t.Fatalf("NewRequest error = %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do error = %v, want nil", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || calls != 2 {
+		t.Fatalf("status = %d after %d calls, want 200 after 2", resp.StatusCode, calls)
+	}
+	if want := []time.Duration{20 * time.Second}; !slices.Equal(*sleeps, want) {
+		t.Fatalf("sleeps = %v, want %v", *sleeps, want)
+	}
+	if len(*waits) != 1 || (*waits)[0].Resource != restCoreResource || !(*waits)[0].ResumeAt.Equal(resetAt) {
+		t.Fatalf("notified waits = %+v, want one core wait until %s", *waits, resetAt)
+	}
+}
+
+func TestDefaultClientWaitsPastItsTimeout(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Second)
+	resetAt := now.Add(10 * time.Minute)
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+		if first {
+			for key, values := range rateLimitHeader(0, resetAt) {
+				w.Header()[key] = values
+			}
+			http.Error(w, `{"message":"API rate limit exceeded"}`, http.StatusForbidden)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"number":1,"title":"t"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	limiter, _, waits := newTestRateLimiter(now, time.Hour)
+	var sleeps []time.Duration
+	limiter.sleep = func(ctx context.Context, d time.Duration) error {
+		// The fake clock skips the pause, but a deadline that ends before it would fail it.
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+			return fmt.Errorf("deadline %s before the end of a %s pause: %w", deadline, d, context.DeadlineExceeded)
+		}
+		sleeps = append(sleeps, d)
+		return nil
+	}
+
+	rest, err := newRESTClient(Config{RESTBaseURL: server.URL + "/", rateLimits: limiter})
+	if err != nil {
+		t.Fatalf("newRESTClient error = %v, want nil", err)
+	}
+	if _, err := rest.getIssue(context.Background(), "acme", "widgets", 1); err != nil {
+		t.Fatalf("getIssue error = %v, want nil", err)
+	}
+	if want := []time.Duration{10 * time.Minute}; !slices.Equal(sleeps, want) {
+		t.Fatalf("sleeps = %v, want %v", sleeps, want)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(*waits) != 1 || calls != 2 {
+		t.Fatalf("notified waits = %+v after %d calls, want one wait and 2 calls", *waits, calls)
+	}
+}
+
+func TestGraphQLRateLimitedResponseIsResent(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0).UTC()
+	resetAt := now.Add(45 * time.Second)
+	limiter, sleeps, _ := newTestRateLimiter(now, time.Hour)
+
+	calls := 0
+	client, err := newGraphQLClient(Config{
+		HTTPClient: newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+			calls++
+			if decodeGraphQLQuery(t, r) != "query { viewer { login } }" {
+				t.Fatalf("resent request lost its body")
+			}
+			if calls == 1 {
+				resp := mustJSONResponse(t, http.StatusOK, map[string]any{
+					"errors": []map[string]any{{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}},
+				})
+				resp.Header = rateLimitHeader(0, resetAt)
+				return resp, nil
+			}
+			return mustJSONResponse(t, http.StatusOK, map[string]any{"data": map[string]any{
+				"viewer":    map[string]any{"login": "octocat"},
+				"rateLimit": map[string]any{"cost": 1, "remaining": 12, "resetAt": resetAt.Add(time.Hour).Format(time.RFC3339)},
+			}}), nil
+		}),
+		rateLimits: limiter,
+	})
+	if err != nil {
+		t.Fatalf("newGraphQLClient error = %v, want nil", err)
+	}
+
+	if err := client.Query(context.Background(), "query { viewer { login } }", nil, nil); err != nil {
+		t.Fatalf("Query error = %v, want nil", err)
+	}
+	if want := []time.Duration{45 * time.Second}; !slices.Equal(*sleeps, want) {
+		t.Fatalf("sleeps = %v, want %v", *sleeps, want)
+	}
+	if got := limiter.buckets[graphQLResource]; got.remaining != 12 || !got.resetAt.Equal(resetAt.Add(time.Hour)) {
+		t.Fatalf("graphql bucket = %+v, want remaining 12 from the rateLimit field", got)
+	}
+}
+
+func TestGraphQLCacheHitsKeepRateLimit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0).UTC()
+	resetAt := now.Add(time.Hour)
+	limiter, _, _ := newTestRateLimiter(now, time.Hour)
+
+	calls := 0
+	client, err := newGraphQLClient(Config{
+		HTTPClient: newTestHTTPClient(func(*http.Request) (*http.Response, error) {
+			calls++
+			return mustJSONResponse(t, http.StatusOK, map[string]any{"data": map[string]any{
+				"viewer":    map[string]any{"login": "octocat"},
+				"rateLimit": map[string]any{"cost": 1, "remaining": 4000, "resetAt": resetAt.Format(time.RFC3339)},
+			}}), nil
+		}),
+		Cache:      NewHTTPCache(t.TempDir(), time.Hour, nil),
+		rateLimits: limiter,
+	})
+	if err != nil {
+		t.Fatalf("newGraphQLClient error = %v, want nil", err)
+	}
+
+	query := func() {
+		t.Helper()
+		if err := client.Query(context.Background(), "query { viewer { login } }", nil, nil); err != nil {
+			t.Fatalf("Query error = %v, want nil", err)
+		}
+	}
+	query()
+	if got := limiter.buckets[graphQLResource]; got.remaining != 4000 {
+		t.Fatalf("graphql bucket after network response = %+v, want remaining 4000", got)
+	}
+
+	// Other requests spent quota since the response was cached.
+	limiter.record(graphQLResource, 120, resetAt)
+	query()
+	if calls != 1 {
+		t.Fatalf("network calls = %d, want 1 with the second query cached", calls)
+	}
+	if got := limiter.buckets[graphQLResource]; got.remaining != 120 {
+		t.Fatalf("graphql bucket after cache hit = %+v, want remaining 120", got)
+	}
+}
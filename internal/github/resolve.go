@@ -0,0 +1,57 @@
+package github
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// canonicalRef rebuilds ref from the html_url GitHub reports for the fetched object. The REST
+// client follows the redirect GitHub serves for transferred issues and renamed repositories,
+// so the returned URL names the current owner, repo and kind. Unrecognized URLs keep ref.
+func canonicalRef(ref ResourceRef, htmlURL string) ResourceRef {
+	parsed, err := url.Parse(htmlURL)
+	if err != nil {
+		return ref
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) != 4 || segments[0] == "" || segments[1] == "" {
+		return ref
+	}
+	number, err := strconv.Atoi(segments[3])
+	if err != nil || number <= 0 {
+		return ref
+	}
+
+	var resourceType ResourceType
+	switch segments[2] {
+	case "issues":
+		resourceType = ResourceIssue
+	case "pull":
+		resourceType = ResourcePullRequest
+	case "discussions":
+		resourceType = ResourceDiscussion
+	default:
+		return ref
+	}
+
+	out := ref
+	out.Owner = segments[0]
+	out.Repo = segments[1]
+	out.Number = number
+	out.Type = resourceType
+	out.URL = htmlURL
+	return out
+}
+
+// withResolvedRef records the canonical identity on data and remembers the requested URL
+// when fetching landed somewhere else.
+func withResolvedRef(data IssueData, ref ResourceRef) IssueData {
+	if data.Resolved.Type == "" {
+		data.Resolved = ref
+	}
+	if ref.URL != "" && data.Resolved.URL != "" && !strings.EqualFold(data.Resolved.URL, ref.URL) {
+		data.Meta.RequestedURL = ref.URL
+	}
+	return data
+}
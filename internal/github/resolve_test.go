@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCanonicalRef(t *testing.T) {
+	t.Parallel()
+
+	ref := ResourceRef{Owner: "octo", Repo: "repo", Number: 1, Type: ResourceIssue, URL: "https://github.com/octo/repo/issues/1", CommentAnchor: "issuecomment-9"}
+	tcs := []struct {
+		name    string
+		htmlURL string
+		want    ResourceRef
+	}{
+		{name: "same resource", htmlURL: "https://github.com/octo/repo/issues/1", want: ref},
+		{
+			name:    "pull request under issues",
+			htmlURL: "https://github.com/octo/repo/pull/1",
+			want:    ResourceRef{Owner: "octo", Repo: "repo", Number: 1, Type: ResourcePullRequest, URL: "https://github.com/octo/repo/pull/1", CommentAnchor: "issuecomment-9"},
+		},
+		{
+			name:    "transferred issue",
+			htmlURL: "https://github.com/neworg/newrepo/issues/5",
+			want:    ResourceRef{Owner: "neworg", Repo: "newrepo", Number: 5, Type: ResourceIssue, URL: "https://github.com/neworg/newrepo/issues/5", CommentAnchor: "issuecomment-9"},
+		},
+		{name: "unrecognized url", htmlURL: "https://example.invalid/issues/1", want: ref},
+		{name: "empty url", htmlURL: "", want: ref},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := canonicalRef(ref, tc.htmlURL); got != tc.want {
+				t.Fatalf("canonicalRef() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetchIssueFollowsTransfer(t *testing.T) {
+	t.Parallel()
+
+	var graphqlOwner any
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/repos/octo/repo/issues/1":
+			resp := mustJSONResponse(t, http.StatusMovedPermanently, map[string]any{
+				"message": "Moved Permanently",
+				"url":     "https://api.test/repositories/9/issues/5",
+			})
+			resp.Header.Set("Location", "https://api.test/repositories/9/issues/5")
+			return resp, nil
+		case "/repositories/9/issues/5":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"number":     5,
+				"title":      "Moved issue",
+				"state":      "open",
+				"html_url":   "https://github.com/neworg/newrepo/issues/5",
+				"created_at": "2026-01-01T00:00:00Z",
+				"updated_at": "2026-01-01T00:00:00Z",
+				"user":       map[string]any{"login": "alice"},
+			}), nil
+		case "/repos/neworg/newrepo/issues/5/comments":
+			return mustJSONResponse(t, http.StatusOK, []map[string]any{}), nil
+		case "/graphql":
+			var req struct {
+				Variables map[string]any `json:"variables"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode graphql request: %v", err)
+			}
+			graphqlOwner = req.Variables["owner"]
+			return timelineResponse(t, timelineFieldIssue, nil), nil
+		default:
+			return notFoundResponse(r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewFetcher(Config{
+		HTTPClient:  clientHTTP,
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+	})
+	if err != nil {
+		t.Fatalf("NewFetcher error = %v, want nil", err)
+	}
+
+	requested := "https://github.com/octo/repo/issues/1"
+	got, err := fetcher.Fetch(context.Background(), ResourceRef{
+		Owner:  "octo",
+		Repo:   "repo",
+		Number: 1,
+		Type:   ResourceIssue,
+		URL:    requested,
+	}, FetchOptions{IncludeComments: true})
+	if err != nil {
+		t.Fatalf("Fetch error = %v, want nil", err)
+	}
+
+	if got.Resolved.Owner != "neworg" || got.Resolved.Repo != "newrepo" || got.Resolved.Number != 5 {
+		t.Fatalf("Resolved = %#v, want neworg/newrepo#5", got.Resolved)
+	}
+	if got.Meta.RequestedURL != requested {
+		t.Fatalf("Meta.RequestedURL = %q, want %q", got.Meta.RequestedURL, requested)
+	}
+	if graphqlOwner != "neworg" {
+		t.Fatalf("graphql owner = %v, want neworg", graphqlOwner)
+	}
+}
+
+func TestFetchIssueDetectsPullRequest(t *testing.T) {
+	t.Parallel()
+
+	issueEnvelope := map[string]any{
+		"number":       7,
+		"title":        "Actually a PR",
+		"state":        "open",
+		"html_url":     "https://github.com/octo/repo/pull/7",
+		"created_at":   "2026-01-01T00:00:00Z",
+		"updated_at":   "2026-01-01T00:00:00Z",
+		"user":         map[string]any{"login": "alice"},
+		"pull_request": map[string]any{"url": "https://api.test/repos/octo/repo/pulls/7"},
+	}
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/repos/octo/repo/issues/7":
+			return mustJSONResponse(t, http.StatusOK, issueEnvelope), nil
+		case "/repos/octo/repo/pulls/7":
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"number":     7,
+				"title":      "Actually a PR",
+				"state":      "open",
+				"html_url":   "https://github.com/octo/repo/pull/7",
+				"created_at": "2026-01-01T00:00:00Z",
+				"updated_at": "2026-01-01T00:00:00Z",
+				"user":       map[string]any{"login": "alice"},
+				"base":       map[string]any{"ref": "main"},
+				"head":       map[string]any{"ref": "feature", "sha": "abc123"},
+			}), nil
+		case "/graphql":
+			return timelineResponse(t, timelineFieldPullRequest, nil), nil
+		default:
+			return notFoundResponse(r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewFetcher(Config{
+		HTTPClient:  clientHTTP,
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+	})
+	if err != nil {
+		t.Fatalf("NewFetcher error = %v, want nil", err)
+	}
+
+	got, err := fetcher.Fetch(context.Background(), ResourceRef{
+		Owner:  "octo",
+		Repo:   "repo",
+		Number: 7,
+		Type:   ResourceIssue,
+		URL:    "https://github.com/octo/repo/issues/7",
+	}, FetchOptions{})
+	if err != nil {
+		t.Fatalf("Fetch error = %v, want nil", err)
+	}
+
+	if got.Meta.Type != ResourcePullRequest || got.Resolved.Type != ResourcePullRequest {
+		t.Fatalf("type = (%q, %q), want pull_request", got.Meta.Type, got.Resolved.Type)
+	}
+	if got.Meta.HeadRef != "feature" || got.Meta.BaseRef != "main" {
+		t.Fatalf("branches = (%q, %q), want feature → main", got.Meta.HeadRef, got.Meta.BaseRef)
+	}
+	if got.Meta.RequestedURL != "https://github.com/octo/repo/issues/7" {
+		t.Fatalf("Meta.RequestedURL = %q, want the /issues/7 URL", got.Meta.RequestedURL)
+	}
+}
@@ -23,25 +23,39 @@ func newRESTClient(cfg Config) (*restClient, error) {
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: 30 * time.Second}
 	}
+	// The limiter sits below the cache so cache hits neither wait nor report stale quotas, and
+	// the cache sits below the token transport so cache keys see the Authorization header.
+	httpClient = cfg.rateLimits.wrapClient(httpClient, false)
+	httpClient = cfg.Cache.wrapClient(httpClient, false)
 
-	if cfg.Token != "" {
-		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cfg.Token})
-		baseTransport := httpClient.Transport
-		if baseTransport == nil {
-			baseTransport = http.DefaultTransport
-		}
-		httpClient = &http.Client{
-			Transport: &oauth2.Transport{
-				Source: ts,
-				Base:   baseTransport,
-			},
-			Timeout: httpClient.Timeout,
-		}
+	if ts := cfg.tokenSource(); ts != nil {
+		httpClient = withTokenSource(httpClient, ts)
 	}
 
-	client := goGithub.NewClient(httpClient)
+	client, err := newGoGitHubClient(httpClient, cfg.RESTBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &restClient{client: client}, nil
+}
 
-	baseURL := cfg.RESTBaseURL
+// withTokenSource returns a client that sends the current token from ts as a Bearer credential.
+func withTokenSource(httpClient *http.Client, ts oauth2.TokenSource) *http.Client {
+	baseTransport := httpClient.Transport
+	if baseTransport == nil {
+		baseTransport = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport: &oauth2.Transport{
+			Source: ts,
+			Base:   baseTransport,
+		},
+		Timeout: httpClient.Timeout,
+	}
+}
+
+func newGoGitHubClient(httpClient *http.Client, baseURL string) (*goGithub.Client, error) {
+	client := goGithub.NewClient(httpClient)
 	if baseURL == "" {
 		baseURL = defaultRESTBaseURL
 	}
@@ -50,8 +64,7 @@ func newRESTClient(cfg Config) (*restClient, error) {
 		return nil, fmt.Errorf("parse REST base URL %q: %w", baseURL, err)
 	}
 	client.BaseURL = parsed
-
-	return &restClient{client: client}, nil
+	return client, nil
 }
 
 func (c *restClient) getIssue(ctx context.Context, owner, repo string, number int) (*goGithub.Issue, error) {
@@ -127,6 +140,98 @@ func (c *restClient) listPullRequestComments(ctx context.Context, owner, repo st
 	return all, nil
 }
 
+func (c *restClient) listPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*goGithub.CommitFile, error) {
+	var all []*goGithub.CommitFile
+	opts := &goGithub.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := c.client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, wrapRESTError("list pull request files", err)
+		}
+		all = append(all, files...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return all, nil
+}
+
+func (c *restClient) listPullRequestCommits(ctx context.Context, owner, repo string, number int) ([]*goGithub.RepositoryCommit, error) {
+	var all []*goGithub.RepositoryCommit
+	opts := &goGithub.ListOptions{PerPage: 100}
+	for {
+		commits, resp, err := c.client.PullRequests.ListCommits(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, wrapRESTError("list pull request commits", err)
+		}
+		all = append(all, commits...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return all, nil
+}
+
+func (c *restClient) listCheckRuns(ctx context.Context, owner, repo, ref string) ([]*goGithub.CheckRun, error) {
+	var all []*goGithub.CheckRun
+	opts := &goGithub.ListCheckRunsOptions{
+		ListOptions: goGithub.ListOptions{PerPage: 100},
+	}
+	for {
+		result, resp, err := c.client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, wrapRESTError("list check runs", err)
+		}
+		all = append(all, result.CheckRuns...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return all, nil
+}
+
+func (c *restClient) listCombinedStatuses(ctx context.Context, owner, repo, ref string) ([]*goGithub.RepoStatus, error) {
+	var all []*goGithub.RepoStatus
+	opts := &goGithub.ListOptions{PerPage: 100}
+	for {
+		combined, resp, err := c.client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, wrapRESTError("get combined commit status", err)
+		}
+		all = append(all, combined.Statuses...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return all, nil
+}
+
+func (c *restClient) searchIssues(ctx context.Context, query string, limit int) ([]*goGithub.Issue, error) {
+	var all []*goGithub.Issue
+	opts := &goGithub.SearchOptions{
+		ListOptions: goGithub.ListOptions{PerPage: min(limit, 100)},
+	}
+	for len(all) < limit {
+		result, resp, err := c.client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return nil, wrapRESTError("search issues", err)
+		}
+		all = append(all, result.Issues...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	if len(all) > limit {
+		all = all[:limit]
+	}
+	return all, nil
+}
+
 func wrapRESTError(op string, err error) error {
 	if err == nil {
 		return nil
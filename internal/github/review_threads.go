@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	goGithub "github.com/google/go-github/v72/github"
+)
+
+// reviewThreadState is the GraphQL-only thread data that REST review comments lack.
+type reviewThreadState struct {
+	ID           string `json:"id"`
+	Path         string `json:"path"`
+	DiffSide     string `json:"diffSide"`
+	Line         int    `json:"line"`
+	StartLine    int    `json:"startLine"`
+	OriginalLine int    `json:"originalLine"`
+	ResolvedBy   *struct {
+		Login string `json:"login"`
+	} `json:"resolvedBy"`
+	Comments struct {
+		Nodes []struct {
+			DatabaseID int64 `json:"databaseId"`
+		} `json:"nodes"`
+	} `json:"comments"`
+	IsResolved bool `json:"isResolved"`
+	IsOutdated bool `json:"isOutdated"`
+}
+
+type reviewThreadsPayload struct {
+	Repository struct {
+		PullRequest *struct {
+			ReviewThreads struct {
+				PageInfo struct {
+					EndCursor   string `json:"endCursor"`
+					HasNextPage bool   `json:"hasNextPage"`
+				} `json:"pageInfo"`
+				Nodes []reviewThreadState `json:"nodes"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// attachReviewThreads groups REST review comments into reply chains, enriches them
+// with GraphQL resolution state and hangs each thread off the review that started it.
+func (f *fetcher) attachReviewThreads(ctx context.Context, ref ResourceRef, reviews []ReviewData, reviewIDToIndex map[int64]int, comments []*goGithub.PullRequestComment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	states, err := f.fetchReviewThreadStates(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groupReviewComments(comments) {
+		root := group[0]
+		index, ok := reviewIDToIndex[root.GetPullRequestReviewID()]
+		if !ok {
+			continue
+		}
+		reviews[index].Threads = append(reviews[index].Threads, buildReviewThread(group, states[root.GetID()]))
+	}
+	return nil
+}
+
+func (f *fetcher) fetchReviewThreadStates(ctx context.Context, ref ResourceRef) (map[int64]reviewThreadState, error) {
+	query := `query PullRequestReviewThreads($owner:String!, $repo:String!, $number:Int!, $after:String) {
+  repository(owner:$owner, name:$repo) {
+    pullRequest(number:$number) {
+      reviewThreads(first:100, after:$after) {
+        nodes {
+          id
+          path
+          diffSide
+          line
+          startLine
+          originalLine
+          isResolved
+          isOutdated
+          resolvedBy { login }
+          comments(first:1) {
+            nodes { databaseId }
+          }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+  rateLimit { cost remaining resetAt }
+}`
+
+	states := make(map[int64]reviewThreadState)
+	err := f.gql.QueryPaginated(ctx, query, map[string]any{
+		"owner":  ref.Owner,
+		"repo":   ref.Repo,
+		"number": ref.Number,
+	}, func(page json.RawMessage) (bool, string, error) {
+		var payload reviewThreadsPayload
+		if err := json.Unmarshal(page, &payload); err != nil {
+			return false, "", fmt.Errorf("decode review threads page payload: %w", err)
+		}
+		pr := payload.Repository.PullRequest
+		if pr == nil {
+			return false, "", fmt.Errorf("review threads missing pull request node: %w", ErrResourceNotFound)
+		}
+		for _, node := range pr.ReviewThreads.Nodes {
+			if len(node.Comments.Nodes) == 0 {
+				continue
+			}
+			states[node.Comments.Nodes[0].DatabaseID] = node
+		}
+		return pr.ReviewThreads.PageInfo.HasNextPage, pr.ReviewThreads.PageInfo.EndCursor, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query review threads: %w", err)
+	}
+	return states, nil
+}
+
+// groupReviewComments returns reply chains in first-seen order, each starting with its root comment.
+func groupReviewComments(comments []*goGithub.PullRequestComment) [][]*goGithub.PullRequestComment {
+	byID := make(map[int64]*goGithub.PullRequestComment, len(comments))
+	for _, comment := range comments {
+		byID[comment.GetID()] = comment
+	}
+
+	rootOf := func(comment *goGithub.PullRequestComment) int64 {
+		current := comment
+		// Bound the walk so malformed reply cycles cannot hang the fetch.
+		for range len(comments) {
+			if current.InReplyTo == nil {
+				break
+			}
+			parent, ok := byID[current.GetInReplyTo()]
+			if !ok {
+				break
+			}
+			current = parent
+		}
+		return current.GetID()
+	}
+
+	var order []int64
+	groups := make(map[int64][]*goGithub.PullRequestComment)
+	for _, comment := range comments {
+		root := rootOf(comment)
+		if _, ok := groups[root]; !ok {
+			order = append(order, root)
+		}
+		if comment.GetID() == root {
+			groups[root] = append([]*goGithub.PullRequestComment{comment}, groups[root]...)
+			continue
+		}
+		groups[root] = append(groups[root], comment)
+	}
+
+	out := make([][]*goGithub.PullRequestComment, 0, len(order))
+	for _, root := range order {
+		out = append(out, groups[root])
+	}
+	return out
+}
+
+func buildReviewThread(group []*goGithub.PullRequestComment, state reviewThreadState) ReviewThread {
+	root := group[0]
+	thread := ReviewThread{
+		ID:           state.ID,
+		Path:         root.GetPath(),
+		Side:         root.GetSide(),
+		DiffHunk:     root.GetDiffHunk(),
+		Line:         root.GetLine(),
+		StartLine:    root.GetStartLine(),
+		OriginalLine: root.GetOriginalLine(),
+		IsResolved:   state.IsResolved,
+		IsOutdated:   state.IsOutdated,
+	}
+	if state.Path != "" {
+		thread.Path = state.Path
+		thread.Side = state.DiffSide
+		thread.Line = state.Line
+		thread.StartLine = state.StartLine
+		thread.OriginalLine = state.OriginalLine
+	}
+	if state.ResolvedBy != nil {
+		thread.ResolvedBy = state.ResolvedBy.Login
+	}
+	for _, comment := range group {
+		thread.Comments = append(thread.Comments, mapPRComment(comment))
+	}
+	return thread
+}
@@ -0,0 +1,153 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const discussionSearchQuery = `
+query($query: String!, $first: Int!, $after: String) {
+  search(query: $query, type: DISCUSSION, first: $first, after: $after) {
+    nodes {
+      ... on Discussion {
+        url
+      }
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+  rateLimit { cost remaining resetAt }
+}`
+
+type discussionSearchPayload struct {
+	Search struct {
+		Nodes []struct {
+			URL string `json:"url"`
+		} `json:"nodes"`
+		PageInfo struct {
+			EndCursor   string `json:"endCursor"`
+			HasNextPage bool   `json:"hasNextPage"`
+		} `json:"pageInfo"`
+	} `json:"search"`
+}
+
+// searchPlan splits one user query into REST issue searches and a GraphQL discussion search.
+type searchPlan struct {
+	restQueries     []string
+	discussionQuery string
+}
+
+// planSearch honors is:issue, is:pr and is:discussion qualifiers. A query naming none of them
+// enumerates all three kinds, since the REST search requires an explicit issue or pr qualifier.
+// The discussion search never sees the issue and pr qualifiers, which would match nothing there.
+func planSearch(query string) searchPlan {
+	var (
+		restTerms, discussionTerms []string
+		issueOrPR, discussions     bool
+	)
+	for _, term := range strings.Fields(query) {
+		switch strings.ToLower(term) {
+		case "is:discussion", "type:discussion":
+			discussions = true
+		case "is:issue", "type:issue", "is:pr", "is:pull-request", "type:pr":
+			issueOrPR = true
+			restTerms = append(restTerms, term)
+		default:
+			restTerms = append(restTerms, term)
+			discussionTerms = append(discussionTerms, term)
+		}
+	}
+	base := strings.Join(discussionTerms, " ")
+	restQuery := strings.Join(restTerms, " ")
+
+	switch {
+	case discussions && !issueOrPR:
+		return searchPlan{discussionQuery: base}
+	case issueOrPR && !discussions:
+		return searchPlan{restQueries: []string{restQuery}}
+	case issueOrPR:
+		return searchPlan{restQueries: []string{restQuery}, discussionQuery: base}
+	default:
+		return searchPlan{
+			restQueries:     []string{base + " is:issue", base + " is:pr"},
+			discussionQuery: base,
+		}
+	}
+}
+
+func (f *fetcher) Search(ctx context.Context, query string, opts SearchOptions) ([]ResourceRef, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("search: empty query")
+	}
+	if opts.Limit <= 0 {
+		return nil, fmt.Errorf("search: limit must be positive")
+	}
+
+	var refs []ResourceRef
+	err := doWithRetry(ctx, f.cfg.MaxRetries, f.cfg.InitialBackoff, nil, func() error {
+		var err error
+		refs, err = f.search(ctx, planSearch(query), opts)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search %q: %w", query, err)
+	}
+	return refs, nil
+}
+
+func (f *fetcher) search(ctx context.Context, plan searchPlan, opts SearchOptions) ([]ResourceRef, error) {
+	base := ResourceRef{Host: opts.Host}
+	var refs []ResourceRef
+	for _, query := range plan.restQueries {
+		issues, err := f.rest.searchIssues(ctx, query, opts.Limit-len(refs))
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			refs = append(refs, canonicalRef(base, issue.GetHTMLURL()))
+		}
+		if len(refs) >= opts.Limit {
+			return refs[:opts.Limit], nil
+		}
+	}
+	if plan.discussionQuery == "" {
+		return refs, nil
+	}
+
+	urls, err := f.searchDiscussions(ctx, plan.discussionQuery, opts.Limit-len(refs))
+	if err != nil {
+		return nil, err
+	}
+	for _, url := range urls {
+		refs = append(refs, canonicalRef(base, url))
+	}
+	return refs, nil
+}
+
+func (f *fetcher) searchDiscussions(ctx context.Context, query string, limit int) ([]string, error) {
+	var urls []string
+	err := f.gql.QueryPaginated(ctx, discussionSearchQuery, map[string]any{
+		"query": query,
+		"first": min(limit, 100),
+	}, func(page json.RawMessage) (bool, string, error) {
+		var payload discussionSearchPayload
+		if err := json.Unmarshal(page, &payload); err != nil {
+			return false, "", fmt.Errorf("decode discussion search page: %w", err)
+		}
+		for _, node := range payload.Search.Nodes {
+			if node.URL != "" && len(urls) < limit {
+				urls = append(urls, node.URL)
+			}
+		}
+		info := payload.Search.PageInfo
+		return info.HasNextPage && len(urls) < limit, info.EndCursor, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search discussions: %w", err)
+	}
+	return urls, nil
+}
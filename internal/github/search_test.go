@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlanSearch(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name  string
+		query string
+		want  searchPlan
+	}{
+		{
+			name:  "no kind qualifier enumerates everything",
+			query: "repo:org/x label:bug",
+			want: searchPlan{
+				restQueries:     []string{"repo:org/x label:bug is:issue", "repo:org/x label:bug is:pr"},
+				discussionQuery: "repo:org/x label:bug",
+			},
+		},
+		{
+			name:  "issues only",
+			query: "repo:org/x is:issue updated:>2026-01-01",
+			want:  searchPlan{restQueries: []string{"repo:org/x is:issue updated:>2026-01-01"}},
+		},
+		{
+			name:  "discussions only",
+			query: "repo:org/x is:discussion",
+			want:  searchPlan{discussionQuery: "repo:org/x"},
+		},
+		{
+			name:  "issues and discussions",
+			query: "repo:org/x is:issue is:discussion label:bug",
+			want: searchPlan{
+				restQueries:     []string{"repo:org/x is:issue label:bug"},
+				discussionQuery: "repo:org/x label:bug",
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := planSearch(tc.query); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("planSearch(%q) = %#v, want %#v", tc.query, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetcherSearch(t *testing.T) {
+	t.Parallel()
+
+	clientHTTP := newTestHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/search/issues":
+			q := r.URL.Query().Get("q")
+			item := map[string]any{"number": 1, "html_url": "https://github.com/org/x/issues/1"}
+			if strings.HasSuffix(q, "is:pr") {
+				item = map[string]any{"number": 2, "html_url": "https://github.com/org/x/pull/2", "pull_request": map[string]any{}}
+			}
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"total_count": 1,
+				"items":       []map[string]any{item},
+			}), nil
+		case "/graphql":
+			var req struct {
+				Variables map[string]any `json:"variables"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode graphql request: %v", err)
+			}
+			if req.Variables["query"] != "repo:org/x" {
+				t.Fatalf("discussion search query = %v, want repo:org/x", req.Variables["query"])
+			}
+			return mustJSONResponse(t, http.StatusOK, map[string]any{
+				"data": map[string]any{
+					"search": map[string]any{
+						"nodes": []map[string]any{
+							{"url": "https://github.com/org/x/discussions/3"},
+							{"url": "https://github.com/org/x/discussions/4"},
+						},
+						"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "c1"},
+					},
+				},
+			}), nil
+		default:
+			return notFoundResponse(r.URL.Path), nil
+		}
+	})
+
+	fetcher, err := NewFetcher(Config{
+		HTTPClient:  clientHTTP,
+		RESTBaseURL: "https://api.test/",
+		GraphQLURL:  "https://api.test/graphql",
+	})
+	if err != nil {
+		t.Fatalf("NewFetcher error = %v, want nil", err)
+	}
+	searcher, ok := fetcher.(Searcher)
+	if !ok {
+		t.Fatalf("fetcher does not implement Searcher")
+	}
+
+	refs, err := searcher.Search(context.Background(), "repo:org/x", SearchOptions{Limit: 3})
+	if err != nil {
+		t.Fatalf("Search error = %v, want nil", err)
+	}
+	var got []string
+	for _, ref := range refs {
+		got = append(got, string(ref.Type)+" "+ref.URL)
+	}
+	want := []string{
+		"issue https://github.com/org/x/issues/1",
+		"pull_request https://github.com/org/x/pull/2",
+		"discussion https://github.com/org/x/discussions/3",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Search refs = %#v, want %#v", got, want)
+	}
+}
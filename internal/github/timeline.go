@@ -0,0 +1,446 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	timelineFieldIssue       = "issue"
+	timelineFieldPullRequest = "pullRequest"
+
+	timelineEventAssigned = "assigned"
+)
+
+// commonTimelineFragments select the timeline events shared by issues and pull requests.
+const commonTimelineFragments = `
+          ... on ClosedEvent {
+            createdAt
+            actor { login }
+            stateReason
+            closer {
+              __typename
+              ... on Commit { abbreviatedOid }
+              ... on PullRequest { url }
+            }
+          }
+          ... on ReopenedEvent {
+            createdAt
+            actor { login }
+          }
+          ... on LabeledEvent {
+            createdAt
+            actor { login }
+            label { name }
+          }
+          ... on AssignedEvent {
+            createdAt
+            actor { login }
+            assignee {
+              __typename
+              ... on User { login }
+              ... on Bot { login }
+              ... on Mannequin { login }
+            }
+          }
+          ... on MilestonedEvent {
+            createdAt
+            actor { login }
+            milestoneTitle
+          }
+          ... on LockedEvent {
+            createdAt
+            actor { login }
+          }
+          ... on UnlabeledEvent {
+            createdAt
+            actor { login }
+            label { name }
+          }
+          ... on UnassignedEvent {
+            createdAt
+            actor { login }
+            assignee {
+              __typename
+              ... on User { login }
+              ... on Bot { login }
+              ... on Mannequin { login }
+            }
+          }
+          ... on RenamedTitleEvent {
+            createdAt
+            actor { login }
+            previousTitle
+            currentTitle
+          }
+          ... on CrossReferencedEvent {
+            createdAt
+            actor { login }
+            willCloseTarget
+            source {
+              __typename
+              ... on Issue { url }
+              ... on PullRequest { url }
+            }
+          }
+          ... on ReferencedEvent {
+            createdAt
+            actor { login }
+            commit { abbreviatedOid }
+            commitRepository { nameWithOwner }
+          }
+          ... on MarkedAsDuplicateEvent {
+            createdAt
+            actor { login }
+            canonical {
+              __typename
+              ... on Issue { url }
+              ... on PullRequest { url }
+            }
+          }
+          ... on ConnectedEvent {
+            createdAt
+            actor { login }
+            subject {
+              __typename
+              ... on Issue { url }
+              ... on PullRequest { url }
+            }
+          }`
+
+// issueTimelineFragments select the events that only exist on issue timelines.
+const issueTimelineFragments = `
+          ... on TransferredEvent {
+            createdAt
+            actor { login }
+            fromRepository { nameWithOwner }
+          }`
+
+// pullRequestTimelineFragments select the events that only exist on pull request timelines.
+const pullRequestTimelineFragments = `
+          ... on HeadRefForcePushedEvent {
+            createdAt
+            actor { login }
+            beforeCommit { abbreviatedOid }
+            afterCommit { abbreviatedOid }
+          }
+          ... on ReviewRequestedEvent {
+            createdAt
+            actor { login }
+            requestedReviewer {
+              __typename
+              ... on User { login }
+              ... on Bot { login }
+              ... on Mannequin { login }
+              ... on Team { name }
+            }
+          }
+          ... on ReadyForReviewEvent {
+            createdAt
+            actor { login }
+          }
+          ... on ConvertToDraftEvent {
+            createdAt
+            actor { login }
+          }
+          ... on MergedEvent {
+            createdAt
+            actor { login }
+            commit { abbreviatedOid }
+            mergeRefName
+          }
+          ... on BaseRefChangedEvent {
+            createdAt
+            actor { login }
+            previousRefName
+            currentRefName
+          }
+          ... on AutoMergeEnabledEvent {
+            createdAt
+            actor { login }
+          }
+          ... on DeployedEvent {
+            createdAt
+            actor { login }
+            deployment { environment }
+          }
+          ... on DeploymentEnvironmentChangedEvent {
+            createdAt
+            actor { login }
+            deploymentStatus {
+              state
+              deployment { environment }
+            }
+          }`
+
+type timelinePayload struct {
+	Repository struct {
+		Issue       *timelineConnection `json:"issue"`
+		PullRequest *timelineConnection `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+type timelineConnection struct {
+	TimelineItems struct {
+		PageInfo struct {
+			EndCursor   string `json:"endCursor"`
+			HasNextPage bool   `json:"hasNextPage"`
+		} `json:"pageInfo"`
+		Nodes []timelineNode `json:"nodes"`
+	} `json:"timelineItems"`
+}
+
+type timelineCommitRef struct {
+	AbbreviatedOID string `json:"abbreviatedOid"`
+}
+
+type timelineDeployment struct {
+	Environment string `json:"environment"`
+}
+
+type timelineLinkedItem struct {
+	URL            string `json:"url"`
+	AbbreviatedOID string `json:"abbreviatedOid"`
+}
+
+type timelineRepository struct {
+	NameWithOwner string `json:"nameWithOwner"`
+}
+
+type timelineNode struct {
+	TypeName string `json:"__typename"`
+	Created  string `json:"createdAt"`
+	Actor    struct {
+		Login string `json:"login"`
+	} `json:"actor"`
+	Label struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	Assignee struct {
+		Login string `json:"login"`
+	} `json:"assignee"`
+	Milestone struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	RequestedReviewer struct {
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	} `json:"requestedReviewer"`
+	DeploymentStatus struct {
+		State      string             `json:"state"`
+		Deployment timelineDeployment `json:"deployment"`
+	} `json:"deploymentStatus"`
+	Deployment       timelineDeployment `json:"deployment"`
+	BeforeCommit     timelineCommitRef  `json:"beforeCommit"`
+	AfterCommit      timelineCommitRef  `json:"afterCommit"`
+	Commit           timelineCommitRef  `json:"commit"`
+	Closer           timelineLinkedItem `json:"closer"`
+	Source           timelineLinkedItem `json:"source"`
+	Canonical        timelineLinkedItem `json:"canonical"`
+	Subject          timelineLinkedItem `json:"subject"`
+	CommitRepository timelineRepository `json:"commitRepository"`
+	FromRepository   timelineRepository `json:"fromRepository"`
+	MilestoneTitle   string             `json:"milestoneTitle"`
+	MergeRefName     string             `json:"mergeRefName"`
+	PreviousRefName  string             `json:"previousRefName"`
+	CurrentRefName   string             `json:"currentRefName"`
+	StateReason      string             `json:"stateReason"`
+	PreviousTitle    string             `json:"previousTitle"`
+	CurrentTitle     string             `json:"currentTitle"`
+	WillCloseTarget  bool               `json:"willCloseTarget"`
+}
+
+func timelineQuery(field, fragments string) string {
+	return fmt.Sprintf(`query Timeline($owner:String!, $repo:String!, $number:Int!, $after:String) {
+  repository(owner:$owner, name:$repo) {
+    %s(number:$number) {
+      timelineItems(first:100, after:$after) {
+        nodes {
+          __typename%s
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+  rateLimit { cost remaining resetAt }
+}`, field, fragments)
+}
+
+// fetchTimeline pages through timelineItems on the issue or pullRequest field and keeps the mapped events.
+func (f *fetcher) fetchTimeline(ctx context.Context, ref ResourceRef, field, fragments string) ([]TimelineEvent, error) {
+	var events []TimelineEvent
+	err := f.gql.QueryPaginated(ctx, timelineQuery(field, fragments), map[string]any{
+		"owner":  ref.Owner,
+		"repo":   ref.Repo,
+		"number": ref.Number,
+	}, func(page json.RawMessage) (bool, string, error) {
+		var payload timelinePayload
+		if err := json.Unmarshal(page, &payload); err != nil {
+			return false, "", fmt.Errorf("decode %s timeline page payload: %w", field, err)
+		}
+		conn := payload.Repository.Issue
+		if field == timelineFieldPullRequest {
+			conn = payload.Repository.PullRequest
+		}
+		if conn == nil {
+			return false, "", fmt.Errorf("%s timeline missing %s node: %w", field, field, ErrResourceNotFound)
+		}
+
+		for _, node := range conn.TimelineItems.Nodes {
+			eventType, details, ok := mapTimelineNode(node)
+			if !ok {
+				continue
+			}
+			events = append(events, TimelineEvent{
+				EventType: eventType,
+				Actor:     node.Actor.Login,
+				CreatedAt: node.Created,
+				Details:   details,
+			})
+		}
+		return conn.TimelineItems.PageInfo.HasNextPage, conn.TimelineItems.PageInfo.EndCursor, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query timeline items: %w", err)
+	}
+
+	return events, nil
+}
+
+type timelineNodeMapper func(node timelineNode) (eventType, details string, ok bool)
+
+func mapTimelineNode(node timelineNode) (eventType, details string, ok bool) {
+	mappers := []timelineNodeMapper{
+		mapLifecycleTimelineNode,
+		mapTriageTimelineNode,
+		mapReferenceTimelineNode,
+		mapPullRequestTimelineNode,
+	}
+	for _, mapper := range mappers {
+		if eventType, details, ok = mapper(node); ok {
+			return eventType, details, true
+		}
+	}
+	return "", "", false
+}
+
+func mapLifecycleTimelineNode(node timelineNode) (eventType, details string, ok bool) {
+	switch node.TypeName {
+	case "OpenedEvent":
+		return "opened", "", true
+	case "ClosedEvent":
+		return "closed", describeClose(node), true
+	case "ReopenedEvent":
+		return "reopened", "", true
+	case "LockedEvent":
+		return "locked", "", true
+	case "RenamedTitleEvent":
+		return "renamed", joinArrow(node.PreviousTitle, node.CurrentTitle), true
+	case "TransferredEvent":
+		return "transferred", "from " + node.FromRepository.NameWithOwner, true
+	default:
+		return "", "", false
+	}
+}
+
+func mapTriageTimelineNode(node timelineNode) (eventType, details string, ok bool) {
+	switch node.TypeName {
+	case "LabeledEvent":
+		return "labeled", node.Label.Name, true
+	case "UnlabeledEvent":
+		return "unlabeled", node.Label.Name, true
+	case "AssignedEvent":
+		if node.Assignee.Login != "" {
+			return timelineEventAssigned, node.Assignee.Login, true
+		}
+		return timelineEventAssigned, node.Actor.Login, true
+	case "UnassignedEvent":
+		return "unassigned", node.Assignee.Login, true
+	case "MilestonedEvent":
+		if node.MilestoneTitle != "" {
+			return "milestoned", node.MilestoneTitle, true
+		}
+		return "milestoned", node.Milestone.Title, true
+	default:
+		return "", "", false
+	}
+}
+
+func mapReferenceTimelineNode(node timelineNode) (eventType, details string, ok bool) {
+	switch node.TypeName {
+	case "CrossReferencedEvent":
+		if node.WillCloseTarget {
+			return "cross_referenced", node.Source.URL + " (will close)", true
+		}
+		return "cross_referenced", node.Source.URL, true
+	case "ReferencedEvent":
+		if node.CommitRepository.NameWithOwner == "" {
+			return "referenced", node.Commit.AbbreviatedOID, true
+		}
+		return "referenced", fmt.Sprintf("%s in %s", node.Commit.AbbreviatedOID, node.CommitRepository.NameWithOwner), true
+	case "MarkedAsDuplicateEvent":
+		return "marked_as_duplicate", node.Canonical.URL, true
+	case "ConnectedEvent":
+		return "connected", node.Subject.URL, true
+	default:
+		return "", "", false
+	}
+}
+
+// describeClose renders the close reason and the commit or pull request that closed the item.
+func describeClose(node timelineNode) string {
+	reason := strings.ToLower(node.StateReason)
+	closer := node.Closer.URL
+	if closer == "" {
+		closer = node.Closer.AbbreviatedOID
+	}
+	switch {
+	case reason != "" && closer != "":
+		return fmt.Sprintf("%s via %s", reason, closer)
+	case closer != "":
+		return "via " + closer
+	default:
+		return reason
+	}
+}
+
+func mapPullRequestTimelineNode(node timelineNode) (eventType, details string, ok bool) {
+	switch node.TypeName {
+	case "HeadRefForcePushedEvent":
+		return "head_ref_force_pushed", joinArrow(node.BeforeCommit.AbbreviatedOID, node.AfterCommit.AbbreviatedOID), true
+	case "ReviewRequestedEvent":
+		if node.RequestedReviewer.Login != "" {
+			return "review_requested", node.RequestedReviewer.Login, true
+		}
+		return "review_requested", node.RequestedReviewer.Name, true
+	case "ReadyForReviewEvent":
+		return "ready_for_review", "", true
+	case "ConvertToDraftEvent":
+		return "convert_to_draft", "", true
+	case "MergedEvent":
+		if node.MergeRefName == "" {
+			return "merged", node.Commit.AbbreviatedOID, true
+		}
+		return "merged", fmt.Sprintf("%s into %s", node.Commit.AbbreviatedOID, node.MergeRefName), true
+	case "BaseRefChangedEvent":
+		return "base_ref_changed", joinArrow(node.PreviousRefName, node.CurrentRefName), true
+	case "AutoMergeEnabledEvent":
+		return "auto_merge_enabled", "", true
+	case "DeployedEvent":
+		return "deployed", node.Deployment.Environment, true
+	case "DeploymentEnvironmentChangedEvent":
+		environment := node.DeploymentStatus.Deployment.Environment
+		if node.DeploymentStatus.State == "" {
+			return "deployment_environment_changed", environment, true
+		}
+		return "deployment_environment_changed", fmt.Sprintf("%s (%s)", environment, node.DeploymentStatus.State), true
+	default:
+		return "", "", false
+	}
+}
+
+func joinArrow(from, to string) string {
+	return from + " → " + to
+}
@@ -13,89 +13,203 @@ const (
 )
 
 // ResourceRef is the normalized resource identity extracted from an input URL.
+// Host is the lowercase web host; empty means DefaultHost. CommentAnchor keeps a
+// single-comment URL fragment such as "issuecomment-123" or "discussion_r456".
 type ResourceRef struct {
-	Owner  string
-	Repo   string
-	Type   ResourceType
-	URL    string
-	Number int
+	Owner         string       `json:"owner"`
+	Repo          string       `json:"repo"`
+	Type          ResourceType `json:"type"`
+	URL           string       `json:"url"`
+	Host          string       `json:"host,omitempty"`
+	CommentAnchor string       `json:"comment_anchor,omitempty"`
+	Number        int          `json:"number"`
 }
 
 // ReactionSummary captures aggregate reaction counts on a GitHub entity.
 type ReactionSummary struct {
-	PlusOne  int
-	MinusOne int
-	Laugh    int
-	Hooray   int
-	Confused int
-	Heart    int
-	Rocket   int
-	Eyes     int
-	Total    int
+	PlusOne  int `json:"plus_one,omitempty"`
+	MinusOne int `json:"minus_one,omitempty"`
+	Laugh    int `json:"laugh,omitempty"`
+	Hooray   int `json:"hooray,omitempty"`
+	Confused int `json:"confused,omitempty"`
+	Heart    int `json:"heart,omitempty"`
+	Rocket   int `json:"rocket,omitempty"`
+	Eyes     int `json:"eyes,omitempty"`
+	Total    int `json:"total"`
 }
 
 // Label stores minimal label data needed for output rendering.
 type Label struct {
-	Name string
+	Name string `json:"name"`
 }
 
 // Metadata stores top-level fields used in front matter and metadata sections.
+// Assignees and Milestone apply to issues and pull requests; BaseRef, HeadRef,
+// RequestedReviewers, Additions, Deletions and Draft only to pull requests.
+// RequestedURL is set only when the requested URL differs from the canonical URL.
+// NodeID is the GraphQL global ID of the issue, pull request or discussion.
 type Metadata struct {
-	Category             string
-	MergedAt             string
-	AcceptedAnswerAuthor string
-	State                string
-	Author               string
-	CreatedAt            string
-	Title                string
-	AcceptedAnswerID     string
-	Type                 ResourceType
-	URL                  string
-	UpdatedAt            string
-	Labels               []Label
-	ReviewCount          int
-	Number               int
-	IsAnswered           bool
-	Merged               bool
+	NodeID               string       `json:"node_id,omitempty"`
+	Category             string       `json:"category,omitempty"`
+	MergedAt             string       `json:"merged_at,omitempty"`
+	AcceptedAnswerAuthor string       `json:"accepted_answer_author,omitempty"`
+	State                string       `json:"state"`
+	Author               string       `json:"author"`
+	CreatedAt            string       `json:"created_at"`
+	Title                string       `json:"title"`
+	AcceptedAnswerID     string       `json:"accepted_answer_id,omitempty"`
+	Type                 ResourceType `json:"type"`
+	URL                  string       `json:"url"`
+	UpdatedAt            string       `json:"updated_at"`
+	HeadSHA              string       `json:"head_sha,omitempty"`
+	Milestone            string       `json:"milestone,omitempty"`
+	ClosedAt             string       `json:"closed_at,omitempty"`
+	ClosedBy             string       `json:"closed_by,omitempty"`
+	StateReason          string       `json:"state_reason,omitempty"`
+	BaseRef              string       `json:"base_ref,omitempty"`
+	HeadRef              string       `json:"head_ref,omitempty"`
+	RequestedURL         string       `json:"requested_url,omitempty"`
+	Labels               []Label      `json:"labels,omitempty"`
+	Assignees            []string     `json:"assignees,omitempty"`
+	RequestedReviewers   []string     `json:"requested_reviewers,omitempty"`
+	ReviewCount          int          `json:"review_count,omitempty"`
+	Number               int          `json:"number"`
+	Additions            int          `json:"additions,omitempty"`
+	Deletions            int          `json:"deletions,omitempty"`
+	IsAnswered           bool         `json:"is_answered,omitempty"`
+	Merged               bool         `json:"merged,omitempty"`
+	Locked               bool         `json:"locked,omitempty"`
+	Draft                bool         `json:"draft,omitempty"`
 }
 
 // TimelineEvent represents one normalized timeline event.
 type TimelineEvent struct {
-	EventType string
-	Actor     string
-	CreatedAt string
-	Details   string
+	EventType string `json:"event_type"`
+	Actor     string `json:"actor,omitempty"`
+	CreatedAt string `json:"created_at"`
+	Details   string `json:"details,omitempty"`
+}
+
+// ContentEdit is one stored revision of an edited body. Body is only fetched on request.
+type ContentEdit struct {
+	EditedAt string `json:"edited_at"`
+	Editor   string `json:"editor,omitempty"`
+	Body     string `json:"body,omitempty"`
+}
+
+// EditHistory records how a description or comment changed after it was posted. Count excludes
+// the original text, which GitHub lists as the oldest revision. Revisions are oldest first and
+// may be limited to the newest ones.
+type EditHistory struct {
+	LastEditor   string        `json:"last_editor,omitempty"`
+	LastEditedAt string        `json:"last_edited_at,omitempty"`
+	Revisions    []ContentEdit `json:"revisions,omitempty"`
+	Count        int           `json:"count"`
 }
 
 // CommentNode represents one comment and its nested replies.
+// Path, DiffHunk, Side, Line, StartLine and InReplyToID are only set on pull request review comments.
+// NodeID is the GraphQL global ID; Edits is only filled when edit history was requested.
+// Author is GhostLogin for deleted accounts. AuthorAssociation is GitHub's relationship of the
+// author to the repository, e.g. OWNER, MEMBER, COLLABORATOR, CONTRIBUTOR or NONE.
+// MinimizedReason is the lowercase reason a moderator hid the comment, e.g. "spam" or "off-topic".
 type CommentNode struct {
-	ID        string
-	Author    string
-	Body      string
-	CreatedAt string
-	UpdatedAt string
-	URL       string
-	Replies   []CommentNode
-	Reactions ReactionSummary
+	ID                string          `json:"id,omitempty"`
+	NodeID            string          `json:"node_id,omitempty"`
+	Author            string          `json:"author"`
+	AuthorAssociation string          `json:"author_association,omitempty"`
+	MinimizedReason   string          `json:"minimized_reason,omitempty"`
+	Body              string          `json:"body"`
+	CreatedAt         string          `json:"created_at"`
+	UpdatedAt         string          `json:"updated_at,omitempty"`
+	URL               string          `json:"url,omitempty"`
+	Path              string          `json:"path,omitempty"`
+	DiffHunk          string          `json:"diff_hunk,omitempty"`
+	Side              string          `json:"side,omitempty"`
+	InReplyToID       string          `json:"in_reply_to_id,omitempty"`
+	Replies           []CommentNode   `json:"replies,omitempty"`
+	Edits             EditHistory     `json:"edits,omitzero"`
+	Reactions         ReactionSummary `json:"reactions,omitzero"`
+	Line              int             `json:"line,omitempty"`
+	StartLine         int             `json:"start_line,omitempty"`
+	IsMinimized       bool            `json:"is_minimized,omitempty"`
+}
+
+// ReviewThread groups the review comments anchored to one diff location.
+type ReviewThread struct {
+	ID           string        `json:"id,omitempty"`
+	Path         string        `json:"path"`
+	Side         string        `json:"side,omitempty"`
+	DiffHunk     string        `json:"diff_hunk,omitempty"`
+	ResolvedBy   string        `json:"resolved_by,omitempty"`
+	Comments     []CommentNode `json:"comments,omitempty"`
+	Line         int           `json:"line,omitempty"`
+	StartLine    int           `json:"start_line,omitempty"`
+	OriginalLine int           `json:"original_line,omitempty"`
+	IsResolved   bool          `json:"is_resolved,omitempty"`
+	IsOutdated   bool          `json:"is_outdated,omitempty"`
 }
 
 // ReviewData stores review summary data and review-thread comments.
+// Threads holds the threads whose first comment was submitted with this review.
 type ReviewData struct {
-	ID        string
-	State     string
-	Author    string
-	Body      string
-	CreatedAt string
-	Comments  []CommentNode
-	Reactions ReactionSummary
+	ID        string          `json:"id,omitempty"`
+	State     string          `json:"state"`
+	Author    string          `json:"author"`
+	Body      string          `json:"body,omitempty"`
+	CreatedAt string          `json:"created_at,omitempty"`
+	Comments  []CommentNode   `json:"comments,omitempty"`
+	Threads   []ReviewThread  `json:"threads,omitempty"`
+	Reactions ReactionSummary `json:"reactions,omitzero"`
+}
+
+// ChangedFile stores one file touched by a pull request.
+type ChangedFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Status           string `json:"status"`
+	Patch            string `json:"patch,omitempty"`
+	Additions        int    `json:"additions,omitempty"`
+	Deletions        int    `json:"deletions,omitempty"`
+	Changes          int    `json:"changes,omitempty"`
+}
+
+// CommitData stores one commit that belongs to a pull request.
+type CommitData struct {
+	SHA         string `json:"sha"`
+	Author      string `json:"author,omitempty"`
+	Headline    string `json:"headline,omitempty"`
+	CommittedAt string `json:"committed_at,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// CheckResult stores one check run or commit status reported for a pull request head commit.
+// Source is "check_run" or "status"; commit statuses only carry a Conclusion.
+type CheckResult struct {
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	Status     string `json:"status,omitempty"`
+	Conclusion string `json:"conclusion,omitempty"`
+	URL        string `json:"url,omitempty"`
 }
 
 // IssueData is the normalized transport payload consumed by other layers.
 type IssueData struct {
-	Description string
-	Timeline    []TimelineEvent
-	Reviews     []ReviewData
-	Thread      []CommentNode
-	Meta        Metadata
-	Reactions   ReactionSummary
+	Description string          `json:"description"`
+	Timeline    []TimelineEvent `json:"timeline,omitempty"`
+	Reviews     []ReviewData    `json:"reviews,omitempty"`
+	Thread      []CommentNode   `json:"thread,omitempty"`
+	Files       []ChangedFile   `json:"files,omitempty"`
+	Commits     []CommitData    `json:"commits,omitempty"`
+	Checks      []CheckResult   `json:"checks,omitempty"`
+	// ChecksUnavailable explains why the head commit checks could not be read, such as a token
+	// without checks or statuses access; Checks is empty then.
+	ChecksUnavailable string   `json:"checks_unavailable,omitempty"`
+	Meta              Metadata `json:"metadata"`
+	// Resolved is the canonical identity of the fetched resource. It differs from the
+	// requested ref when an /issues/N URL names a pull request or the issue was transferred.
+	Resolved  ResourceRef     `json:"resolved"`
+	Reactions ReactionSummary `json:"reactions,omitzero"`
+	// DescriptionEdits is only filled when edit history was requested.
+	DescriptionEdits EditHistory `json:"description_edits,omitzero"`
 }
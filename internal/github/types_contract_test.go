@@ -15,6 +15,10 @@ func TestIssueDataContractFields(t *testing.T) {
 		"Timeline",
 		"Reviews",
 		"Thread",
+		"Files",
+		"Commits",
+		"Checks",
+		"Resolved",
 	}
 
 	assertStructHasFields(t, reflect.TypeOf(IssueData{}), required)
@@ -40,6 +44,19 @@ func TestMetadataContractFields(t *testing.T) {
 		"IsAnswered",
 		"AcceptedAnswerID",
 		"AcceptedAnswerAuthor",
+		"Assignees",
+		"Milestone",
+		"ClosedAt",
+		"ClosedBy",
+		"StateReason",
+		"Locked",
+		"Draft",
+		"BaseRef",
+		"HeadRef",
+		"RequestedReviewers",
+		"Additions",
+		"Deletions",
+		"RequestedURL",
 	}
 
 	assertStructHasFields(t, reflect.TypeOf(Metadata{}), required)
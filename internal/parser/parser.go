@@ -18,47 +18,131 @@ type URLParser interface {
 	Parse(rawURL string) (gh.ResourceRef, error)
 }
 
-// New creates the default URL parser implementation.
+// Options configures optional parser behavior.
+type Options struct {
+	// DefaultRepo resolves short references such as #123 and repo#123; format [HOST/]OWNER/REPO.
+	DefaultRepo string
+	// AllowedHosts lists GitHub Enterprise Server hosts accepted in addition to github.com.
+	AllowedHosts []string
+}
+
+const apiHost = "api." + gh.DefaultHost
+
+// New creates the default URL parser implementation for github.com URLs.
 func New() URLParser {
-	return &defaultParser{}
+	return NewWithOptions(Options{})
+}
+
+// NewWithOptions creates a URL parser that also accepts enterprise hosts and short references.
+func NewWithOptions(opts Options) URLParser {
+	hosts := make(map[string]struct{}, len(opts.AllowedHosts))
+	for _, host := range opts.AllowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts[host] = struct{}{}
+		}
+	}
+	return &defaultParser{
+		enterpriseHosts: hosts,
+		defaultRepo:     strings.TrimSpace(opts.DefaultRepo),
+	}
 }
 
-type defaultParser struct{}
+type defaultParser struct {
+	enterpriseHosts map[string]struct{}
+	defaultRepo     string
+}
 
 func (p *defaultParser) Parse(rawURL string) (gh.ResourceRef, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if isShortReference(rawURL) {
+		return p.parseShortReference(rawURL)
+	}
+
 	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
 		return gh.ResourceRef{}, fmt.Errorf("parse URL %q: %w", rawURL, err)
 	}
+	if parsedURL.Scheme == "" {
+		return gh.ResourceRef{}, fmt.Errorf("validate URL %q: %w", rawURL, invalid("expected an https:// URL or an owner/repo#number reference"))
+	}
 
-	host := strings.ToLower(parsedURL.Hostname())
-	if host != "github.com" && host != "www.github.com" {
-		return gh.ResourceRef{}, fmt.Errorf("validate URL host %q: %w", host, invalid("unsupported host"))
+	host, api, err := p.resolveHost(parsedURL.Hostname(), parsedURL.Path)
+	if err != nil {
+		return gh.ResourceRef{}, err
 	}
 
-	owner, repo, kind, number, err := splitAndValidatePath(parsedURL.Path)
+	segments := splitPathSegments(parsedURL.Path)
+	if api {
+		segments, err = webSegmentsFromAPIPath(host, segments)
+	} else {
+		segments, err = trimPullRequestTab(segments)
+	}
 	if err != nil {
 		return gh.ResourceRef{}, fmt.Errorf("parse URL path %q: %w", parsedURL.Path, err)
 	}
 
-	resourceType, err := resolveResourceType(kind)
+	owner, repo, kind, number, err := splitAndValidatePath(segments)
 	if err != nil {
-		return gh.ResourceRef{}, fmt.Errorf("resolve resource kind %q: %w", kind, err)
+		return gh.ResourceRef{}, fmt.Errorf("parse URL path %q: %w", parsedURL.Path, err)
 	}
 
-	canonicalURL := fmt.Sprintf("https://github.com/%s/%s/%s/%d", owner, repo, kind, number)
+	ref, err := buildRef(host, owner, repo, kind, number)
+	if err != nil {
+		return gh.ResourceRef{}, err
+	}
+	ref.CommentAnchor = commentAnchor(parsedURL.Fragment)
+	return ref, nil
+}
 
-	return gh.ResourceRef{
-		Owner:  owner,
-		Repo:   repo,
-		Number: number,
-		Type:   resourceType,
-		URL:    canonicalURL,
-	}, nil
+// resolveHost returns the canonical web host for rawHost, folding www.github.com into
+// github.com, and reports whether path addresses the REST API rather than the web UI.
+func (p *defaultParser) resolveHost(rawHost, path string) (host string, api bool, err error) {
+	host = strings.ToLower(rawHost)
+	switch {
+	case host == apiHost:
+		return gh.DefaultHost, true, nil
+	case host != "" && gh.IsDefaultHost(host):
+		return gh.DefaultHost, false, nil
+	}
+	if _, ok := p.enterpriseHosts[host]; ok {
+		return host, strings.HasPrefix(strings.TrimLeft(path, "/"), "api/v3/"), nil
+	}
+	return "", false, fmt.Errorf("validate URL host %q: %w", host, invalid("unsupported host"))
+}
+
+// webSegmentsFromAPIPath maps /repos/{owner}/{repo}/{issues|pulls|discussions}/{number}
+// (prefixed with /api/v3 on enterprise hosts) onto web UI path segments.
+func webSegmentsFromAPIPath(host string, segments []string) ([]string, error) {
+	if host != gh.DefaultHost {
+		segments = segments[2:] // drop "api", "v3"
+	}
+	if len(segments) != 5 || segments[0] != "repos" {
+		return nil, fmt.Errorf("validate API path: %w", invalid("API URL must be /repos/{owner}/{repo}/{issues|pulls|discussions}/{number}"))
+	}
+	kind := segments[3]
+	if kind == "pulls" {
+		kind = "pull"
+	}
+	return []string{segments[1], segments[2], kind, segments[4]}, nil
 }
 
-func splitAndValidatePath(rawPath string) (owner, repo, kind string, number int, err error) {
-	segments := splitPathSegments(rawPath)
+// trimPullRequestTab drops the /files, /commits or /checks tab suffix from pull request URLs.
+func trimPullRequestTab(segments []string) ([]string, error) {
+	if len(segments) != 5 {
+		return segments, nil
+	}
+	if segments[2] != "pull" {
+		return nil, fmt.Errorf("validate path segments: %w", invalid(fmt.Sprintf("unsupported %s subpath %q", segments[2], segments[4])))
+	}
+	switch segments[4] {
+	case "files", "commits", "checks":
+		return segments[:4], nil
+	default:
+		return nil, fmt.Errorf("validate path segments: %w", invalid(fmt.Sprintf("unsupported pull request subpath %q; expected files, commits or checks", segments[4])))
+	}
+}
+
+func splitAndValidatePath(segments []string) (owner, repo, kind string, number int, err error) {
 	if len(segments) != 4 {
 		return "", "", "", 0, fmt.Errorf("validate path segments: %w", invalid("path must be /{owner}/{repo}/{kind}/{number}"))
 	}
@@ -72,14 +156,38 @@ func splitAndValidatePath(rawPath string) (owner, repo, kind string, number int,
 		return "", "", "", 0, fmt.Errorf("validate owner/repo: %w", invalid("owner/repo must not be empty"))
 	}
 
-	number, parseErr := strconv.Atoi(numberText)
-	if parseErr != nil || number <= 0 {
-		return "", "", "", 0, fmt.Errorf("validate resource number %q: %w", numberText, invalid("resource number must be a positive integer"))
+	number, err = parseNumber(numberText)
+	if err != nil {
+		return "", "", "", 0, err
 	}
 
 	return owner, repo, kind, number, nil
 }
 
+func parseNumber(numberText string) (int, error) {
+	number, err := strconv.Atoi(numberText)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("validate resource number %q: %w", numberText, invalid("resource number must be a positive integer"))
+	}
+	return number, nil
+}
+
+func buildRef(host, owner, repo, kind string, number int) (gh.ResourceRef, error) {
+	resourceType, err := resolveResourceType(kind)
+	if err != nil {
+		return gh.ResourceRef{}, fmt.Errorf("resolve resource kind %q: %w", kind, err)
+	}
+
+	return gh.ResourceRef{
+		Owner:  owner,
+		Repo:   repo,
+		Number: number,
+		Type:   resourceType,
+		URL:    fmt.Sprintf("https://%s/%s/%s/%s/%d", host, owner, repo, kind, number),
+		Host:   host,
+	}, nil
+}
+
 func splitPathSegments(rawPath string) []string {
 	trimmed := strings.Trim(rawPath, "/")
 	if trimmed == "" {
@@ -101,6 +209,17 @@ func resolveResourceType(kind string) (gh.ResourceType, error) {
 	}
 }
 
+// commentAnchor keeps fragments that point at a single comment and drops everything else.
+func commentAnchor(fragment string) string {
+	for _, prefix := range []string{"issuecomment-", "discussion_r", "discussioncomment-", "pullrequestreview-"} {
+		id, ok := strings.CutPrefix(fragment, prefix)
+		if ok && id != "" && isDigits(id) {
+			return fragment
+		}
+	}
+	return ""
+}
+
 func invalid(reason string) error {
 	return fmt.Errorf("%w: %s", ErrInvalidGitHubURL, reason)
 }
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	gh "github.com/johnqtcg/issue2md/internal/github"
@@ -24,6 +26,7 @@ func TestParse(t *testing.T) {
 				Number: 123,
 				Type:   gh.ResourceIssue,
 				URL:    "https://github.com/octo/repo/issues/123",
+				Host:   "github.com",
 			},
 		},
 		{
@@ -35,6 +38,7 @@ func TestParse(t *testing.T) {
 				Number: 42,
 				Type:   gh.ResourcePullRequest,
 				URL:    "https://github.com/octo/repo/pull/42",
+				Host:   "github.com",
 			},
 		},
 		{
@@ -46,8 +50,38 @@ func TestParse(t *testing.T) {
 				Number: 99,
 				Type:   gh.ResourceDiscussion,
 				URL:    "https://github.com/octo/repo/discussions/99",
+				Host:   "github.com",
 			},
 		},
+		{
+			name:   "www host is canonicalized",
+			rawURL: "https://www.github.com/octo/repo/issues/5",
+			wantRef: gh.ResourceRef{
+				Owner:  "octo",
+				Repo:   "repo",
+				Number: 5,
+				Type:   gh.ResourceIssue,
+				URL:    "https://github.com/octo/repo/issues/5",
+				Host:   "github.com",
+			},
+		},
+		{
+			name:   "allowed enterprise host keeps host",
+			rawURL: "https://GHE.example.com/corp/app/pull/7",
+			wantRef: gh.ResourceRef{
+				Owner:  "corp",
+				Repo:   "app",
+				Number: 7,
+				Type:   gh.ResourcePullRequest,
+				URL:    "https://ghe.example.com/corp/app/pull/7",
+				Host:   "ghe.example.com",
+			},
+		},
+		{
+			name:    "unlisted enterprise host",
+			rawURL:  "https://ghe.other.com/corp/app/pull/7",
+			wantErr: true,
+		},
 		{
 			name:    "invalid host",
 			rawURL:  "https://gitlab.com/octo/repo/issues/1",
@@ -64,8 +98,133 @@ func TestParse(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "pr subresource path is rejected",
-			rawURL:  "https://github.com/octo/repo/pull/2/files",
+			name:   "pr files tab",
+			rawURL: "https://github.com/octo/repo/pull/2/files",
+			wantRef: gh.ResourceRef{
+				Owner:  "octo",
+				Repo:   "repo",
+				Number: 2,
+				Type:   gh.ResourcePullRequest,
+				URL:    "https://github.com/octo/repo/pull/2",
+				Host:   "github.com",
+			},
+		},
+		{
+			name:   "pr checks tab",
+			rawURL: "https://github.com/octo/repo/pull/2/checks",
+			wantRef: gh.ResourceRef{
+				Owner:  "octo",
+				Repo:   "repo",
+				Number: 2,
+				Type:   gh.ResourcePullRequest,
+				URL:    "https://github.com/octo/repo/pull/2",
+				Host:   "github.com",
+			},
+		},
+		{
+			name:    "pr unknown tab is rejected",
+			rawURL:  "https://github.com/octo/repo/pull/2/reviews",
+			wantErr: true,
+		},
+		{
+			name:   "issue comment fragment is kept",
+			rawURL: "https://github.com/octo/repo/issues/7#issuecomment-1234",
+			wantRef: gh.ResourceRef{
+				Owner:         "octo",
+				Repo:          "repo",
+				Number:        7,
+				Type:          gh.ResourceIssue,
+				URL:           "https://github.com/octo/repo/issues/7",
+				Host:          "github.com",
+				CommentAnchor: "issuecomment-1234",
+			},
+		},
+		{
+			name:   "review comment fragment on files tab",
+			rawURL: "https://github.com/octo/repo/pull/8/files#discussion_r99",
+			wantRef: gh.ResourceRef{
+				Owner:         "octo",
+				Repo:          "repo",
+				Number:        8,
+				Type:          gh.ResourcePullRequest,
+				URL:           "https://github.com/octo/repo/pull/8",
+				Host:          "github.com",
+				CommentAnchor: "discussion_r99",
+			},
+		},
+		{
+			name:   "api pulls url",
+			rawURL: "https://api.github.com/repos/octo/repo/pulls/5",
+			wantRef: gh.ResourceRef{
+				Owner:  "octo",
+				Repo:   "repo",
+				Number: 5,
+				Type:   gh.ResourcePullRequest,
+				URL:    "https://github.com/octo/repo/pull/5",
+				Host:   "github.com",
+			},
+		},
+		{
+			name:   "enterprise api issues url",
+			rawURL: "https://ghe.example.com/api/v3/repos/corp/app/issues/3",
+			wantRef: gh.ResourceRef{
+				Owner:  "corp",
+				Repo:   "app",
+				Number: 3,
+				Type:   gh.ResourceIssue,
+				URL:    "https://ghe.example.com/corp/app/issues/3",
+				Host:   "ghe.example.com",
+			},
+		},
+		{
+			name:    "api url with extra segments",
+			rawURL:  "https://api.github.com/repos/octo/repo/issues/5/comments",
+			wantErr: true,
+		},
+		{
+			name:   "owner repo short reference",
+			rawURL: "cli/cli#123",
+			wantRef: gh.ResourceRef{
+				Owner:  "cli",
+				Repo:   "cli",
+				Number: 123,
+				Type:   gh.ResourceIssue,
+				URL:    "https://github.com/cli/cli/issues/123",
+				Host:   "github.com",
+			},
+		},
+		{
+			name:   "repo short reference uses default owner",
+			rawURL: "other#4",
+			wantRef: gh.ResourceRef{
+				Owner:  "octo",
+				Repo:   "other",
+				Number: 4,
+				Type:   gh.ResourceIssue,
+				URL:    "https://github.com/octo/other/issues/4",
+				Host:   "github.com",
+			},
+		},
+		{
+			name:   "number short reference uses default repo",
+			rawURL: "#9",
+			wantRef: gh.ResourceRef{
+				Owner:  "octo",
+				Repo:   "repo",
+				Number: 9,
+				Type:   gh.ResourceIssue,
+				URL:    "https://github.com/octo/repo/issues/9",
+				Host:   "github.com",
+			},
+		},
+		{
+			name:    "short reference with zero number",
+			rawURL:  "octo/repo#0",
+			wantErr: true,
+		},
+		{
+			name:    "url without scheme",
+			rawURL:  "github.com/octo/repo/issues/1",
 			wantErr: true,
 		},
 		{
@@ -80,7 +239,7 @@ func TestParse(t *testing.T) {
 		},
 	}
 
-	p := New()
+	p := NewWithOptions(Options{AllowedHosts: []string{"ghe.example.com"}, DefaultRepo: "octo/repo"})
 	for _, tc := range tcs {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -102,3 +261,71 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseShortReferenceDefaultRepo(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name        string
+		defaultRepo string
+		wantURL     string
+		wantErr     bool
+	}{
+		{name: "allowed enterprise host", defaultRepo: "GHE.example.com/corp/app", wantURL: "https://ghe.example.com/corp/app/issues/12"},
+		{name: "github.com host", defaultRepo: "github.com/octo/repo", wantURL: "https://github.com/octo/repo/issues/12"},
+		{name: "host outside the allowlist", defaultRepo: "evil.example/o/r", wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewWithOptions(Options{DefaultRepo: tc.defaultRepo, AllowedHosts: []string{"ghe.example.com"}}).Parse("#12")
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidGitHubURL) {
+					t.Fatalf("Parse error = %v, want ErrInvalidGitHubURL", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse error = %v, want nil", err)
+			}
+			if got.URL != tc.wantURL {
+				t.Fatalf("Parse URL = %q, want %q", got.URL, tc.wantURL)
+			}
+		})
+	}
+}
+
+func TestParseReportsPreciseErrors(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		rawURL  string
+		wantMsg string
+	}{
+		{name: "short reference without default repo", rawURL: "repo#1", wantMsg: "requires owner/repo or a default repository"},
+		{name: "unknown pull request tab", rawURL: "https://github.com/octo/repo/pull/2/reviews", wantMsg: `unsupported pull request subpath "reviews"`},
+		{name: "issue subpath", rawURL: "https://github.com/octo/repo/issues/1/comments", wantMsg: `unsupported issues subpath "comments"`},
+		{name: "api shape", rawURL: "https://api.github.com/octo/repo/issues/1", wantMsg: "API URL must be /repos/"},
+		{name: "missing scheme", rawURL: "github.com/octo/repo/issues/1", wantMsg: "expected an https:// URL"},
+	}
+
+	p := New()
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := p.Parse(tc.rawURL)
+			if !errors.Is(err, ErrInvalidGitHubURL) {
+				t.Fatalf("Parse(%q) error = %v, want ErrInvalidGitHubURL", tc.rawURL, err)
+			}
+			if !strings.Contains(err.Error(), tc.wantMsg) {
+				t.Fatalf("Parse(%q) error = %q, want it to contain %q", tc.rawURL, err, tc.wantMsg)
+			}
+		})
+	}
+}
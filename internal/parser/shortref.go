@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	gh "github.com/johnqtcg/issue2md/internal/github"
+)
+
+// isShortReference reports whether raw looks like owner/repo#N, repo#N or #N rather than a URL.
+func isShortReference(raw string) bool {
+	if strings.Contains(raw, "://") {
+		return false
+	}
+	left, number, ok := strings.Cut(raw, "#")
+	return ok && isDigits(number) && strings.Count(left, "/") <= 1
+}
+
+// parseShortReference resolves gh-style references. GitHub serves pull requests under
+// /issues/N as well, so short references are normalized to issue URLs.
+func (p *defaultParser) parseShortReference(raw string) (gh.ResourceRef, error) {
+	left, numberText, _ := strings.Cut(raw, "#")
+	number, err := parseNumber(numberText)
+	if err != nil {
+		return gh.ResourceRef{}, err
+	}
+
+	host, owner, repo, err := p.resolveShortRepo(left)
+	if err != nil {
+		return gh.ResourceRef{}, fmt.Errorf("resolve short reference %q: %w", raw, err)
+	}
+	if !isValidName(owner) || !isValidName(repo) {
+		return gh.ResourceRef{}, fmt.Errorf("validate short reference %q: %w", raw, invalid("owner and repo may only contain letters, digits, '-', '_' and '.'"))
+	}
+	return buildRef(host, owner, repo, "issues", number)
+}
+
+func (p *defaultParser) resolveShortRepo(left string) (host, owner, repo string, err error) {
+	if owner, repo, ok := strings.Cut(left, "/"); ok {
+		return gh.DefaultHost, owner, repo, nil
+	}
+
+	host, defaultOwner, defaultRepo, err := splitDefaultRepo(p.defaultRepo)
+	if err != nil {
+		return "", "", "", err
+	}
+	// The default repository host is held to the same allowlist as URL hosts.
+	if host, _, err = p.resolveHost(host, ""); err != nil {
+		return "", "", "", err
+	}
+	if left == "" {
+		return host, defaultOwner, defaultRepo, nil
+	}
+	return host, defaultOwner, left, nil
+}
+
+// splitDefaultRepo parses a [HOST/]OWNER/REPO default repository value.
+func splitDefaultRepo(value string) (host, owner, repo string, err error) {
+	if value == "" {
+		return "", "", "", invalid("short reference requires owner/repo or a default repository")
+	}
+	parts := strings.Split(value, "/")
+	switch {
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return gh.DefaultHost, parts[0], parts[1], nil
+	case len(parts) == 3 && parts[0] != "" && parts[1] != "" && parts[2] != "":
+		return strings.ToLower(parts[0]), parts[1], parts[2], nil
+	default:
+		return "", "", "", invalid(fmt.Sprintf("default repository %q must be [HOST/]OWNER/REPO", value))
+	}
+}
+
+func isValidName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
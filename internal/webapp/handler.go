@@ -2,10 +2,12 @@ package webapp
 
 import (
 	"errors"
+	"fmt"
 	"html/template"
 	"io/fs"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/johnqtcg/issue2md/internal/converter"
@@ -18,6 +20,9 @@ import (
 const (
 	DefaultOpenAPISpecPath     = "docs/swagger.json"
 	maxConvertRequestBodyBytes = 1 << 20
+	// htmlContentSecurityPolicy allows the embedded stylesheet and remote images of an HTML
+	// export and nothing else.
+	htmlContentSecurityPolicy = "default-src 'none'; style-src 'unsafe-inline'; img-src http: https:; base-uri 'none'; form-action 'none'"
 )
 
 // Deps defines dependencies for building the web HTTP handler.
@@ -85,14 +90,17 @@ func (h *webHandler) handleIndex(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleConvert converts a GitHub resource URL to markdown.
+// handleConvert converts a GitHub resource URL to markdown, or to a standalone HTML page.
 // @Summary Convert GitHub URL to Markdown
-// @Description Fetch one GitHub issue, pull request, or discussion and render it as markdown.
+// @Description Fetch one GitHub issue, pull request, or discussion and render it as markdown, or as a self-contained HTML page with format=html.
 // @Tags convert
 // @Accept application/x-www-form-urlencoded
 // @Produce plain
+// @Produce html
 // @Param url formData string true "GitHub issue/pull/discussion URL"
-// @Success 200 {string} string "markdown body"
+// @Param include_patches formData bool false "Embed pull request file patches as diff blocks"
+// @Param format formData string false "Response document: markdown (default) or html" Enums(markdown, html)
+// @Success 200 {string} string "markdown body or HTML page"
 // @Failure 400 {string} string "invalid request"
 // @Failure 401 {string} string "unauthorized"
 // @Failure 403 {string} string "forbidden"
@@ -129,25 +137,53 @@ func (h *webHandler) handleConvert(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := h.fetcher.Fetch(r.Context(), ref, gh.FetchOptions{IncludeComments: true})
+	includePatches, err := formBool(r, "include_patches")
 	if err != nil {
-		http.Error(w, "fetch github resource failed", fetchHTTPStatusFromError(err))
+		http.Error(w, "invalid include_patches", http.StatusBadRequest)
+		return
+	}
+	format, err := formFormat(r)
+	if err != nil {
+		http.Error(w, "invalid format", http.StatusBadRequest)
 		return
 	}
 
-	markdown, err := h.renderer.Render(r.Context(), data, converter.RenderOptions{
+	data, err := h.fetcher.Fetch(r.Context(), ref, gh.FetchOptions{
 		IncludeComments: true,
-		IncludeSummary:  true,
+		IncludeFiles:    true,
+		IncludeCommits:  true,
+		IncludeChecks:   true,
+	})
+	if err != nil {
+		http.Error(w, "fetch github resource failed", fetchHTTPStatusFromError(err))
+		return
+	}
+
+	document, err := h.renderer.Render(r.Context(), data, converter.RenderOptions{
+		Format:           format,
+		IncludeComments:  true,
+		IncludeSummary:   true,
+		IncludeFiles:     true,
+		IncludePatches:   includePatches,
+		IncludeCommits:   true,
+		IncludeChecks:    true,
+		IncludeReactions: true,
 	})
 	if err != nil {
-		http.Error(w, "render markdown failed", http.StatusInternalServerError)
+		http.Error(w, "render "+string(format)+" failed", http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	w.Header().Set("X-Content-Type-Options", "nosniff")
-	// #nosec G705 -- markdown is returned as text/plain with nosniff, not rendered as HTML.
-	if _, err := w.Write(markdown); err != nil {
+	if format == converter.FormatHTML {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		// The page is sanitized already; the policy also keeps any script from running.
+		w.Header().Set("Content-Security-Policy", htmlContentSecurityPolicy)
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	// #nosec G705 -- markdown is returned as text/plain; HTML pages are sanitized and served under a script-free CSP.
+	if _, err := w.Write(document); err != nil {
 		http.Error(w, "write response failed", http.StatusInternalServerError)
 	}
 }
@@ -213,6 +249,35 @@ func (h *webHandler) handleSwaggerUI(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// formFormat reads the optional format form field; only markdown and html are served.
+func formFormat(r *http.Request) (converter.Format, error) {
+	switch format := converter.Format(strings.TrimSpace(r.FormValue("format"))); format {
+	case "", converter.FormatMarkdown:
+		return converter.FormatMarkdown, nil
+	case converter.FormatHTML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("parse form field %q: unsupported format %q", "format", format)
+	}
+}
+
+// formBool reads an optional boolean form field; HTML checkboxes submit "on".
+func formBool(r *http.Request, name string) (bool, error) {
+	value := strings.TrimSpace(r.FormValue(name))
+	switch value {
+	case "":
+		return false, nil
+	case "on":
+		return true, nil
+	default:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("parse form field %q: %w", name, err)
+		}
+		return parsed, nil
+	}
+}
+
 func mustSubFS(root fs.FS, dir string) fs.FS {
 	sub, err := fs.Sub(root, dir)
 	if err != nil {
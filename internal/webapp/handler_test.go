@@ -80,6 +80,133 @@ func TestNewHandlerConvertFlow(t *testing.T) {
 	}
 }
 
+func TestNewHandlerConvertIncludePatchesOption(t *testing.T) {
+	t.Parallel()
+
+	rawURL := "https://github.com/octo/repo/pull/2"
+	ref := gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 2, Type: gh.ResourcePullRequest, URL: rawURL}
+
+	tcs := []struct {
+		name        string
+		value       string
+		wantStatus  int
+		wantPatches bool
+	}{
+		{name: "absent", value: "", wantStatus: http.StatusOK},
+		{name: "checkbox", value: "on", wantStatus: http.StatusOK, wantPatches: true},
+		{name: "true", value: "true", wantStatus: http.StatusOK, wantPatches: true},
+		{name: "false", value: "false", wantStatus: http.StatusOK},
+		{name: "invalid", value: "maybe", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fetcher := &fakeWebFetcher{data: gh.IssueData{Meta: gh.Metadata{Type: gh.ResourcePullRequest}}}
+			renderer := &fakeWebRenderer{content: []byte("# markdown")}
+			h := NewHandler(Deps{
+				Parser:   &fakeWebParser{ref: ref},
+				Fetcher:  fetcher,
+				Renderer: renderer,
+			})
+
+			form := url.Values{}
+			form.Set("url", rawURL)
+			if tc.value != "" {
+				form.Set("include_patches", tc.value)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/convert", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			if len(fetcher.gotOpts) != 1 || !fetcher.gotOpts[0].IncludeFiles {
+				t.Fatalf("fetch opts = %#v, want include files", fetcher.gotOpts)
+			}
+			if len(renderer.gotOpts) != 1 || renderer.gotOpts[0].IncludePatches != tc.wantPatches || !renderer.gotOpts[0].IncludeReactions {
+				t.Fatalf("render opts = %#v, want IncludePatches=%t with reactions", renderer.gotOpts, tc.wantPatches)
+			}
+		})
+	}
+}
+
+func TestNewHandlerConvertFormat(t *testing.T) {
+	t.Parallel()
+
+	rawURL := "https://github.com/octo/repo/issues/3"
+	ref := gh.ResourceRef{Owner: "octo", Repo: "repo", Number: 3, Type: gh.ResourceIssue, URL: rawURL}
+	data := gh.IssueData{
+		Meta:        gh.Metadata{Type: gh.ResourceIssue, Title: "Injected", Number: 3, State: "open", Author: "mallory", URL: rawURL},
+		Description: "Hi <script>alert(1)</script> <img src=x onerror=alert(2)>",
+	}
+
+	tcs := []struct {
+		name            string
+		value           string
+		wantContentType string
+		wantBody        string
+		wantStatus      int
+		wantCSP         bool
+	}{
+		{name: "absent", wantStatus: http.StatusOK, wantContentType: "text/plain; charset=utf-8", wantBody: "# Injected"},
+		{name: "markdown", value: "markdown", wantStatus: http.StatusOK, wantContentType: "text/plain; charset=utf-8", wantBody: "# Injected"},
+		{name: "html", value: "html", wantStatus: http.StatusOK, wantContentType: "text/html; charset=utf-8", wantBody: "<h1>Injected</h1>", wantCSP: true},
+		{name: "json is not served", value: "json", wantStatus: http.StatusBadRequest},
+		{name: "unknown", value: "pdf", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHandler(Deps{
+				Parser:   &fakeWebParser{ref: ref},
+				Fetcher:  &fakeWebFetcher{data: data},
+				Renderer: converter.NewRenderer(nil),
+			})
+
+			form := url.Values{}
+			form.Set("url", rawURL)
+			if tc.value != "" {
+				form.Set("format", tc.value)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/convert", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); got != tc.wantContentType {
+				t.Fatalf("content type = %q, want %q", got, tc.wantContentType)
+			}
+			if got := rec.Header().Get("Content-Security-Policy") != ""; got != tc.wantCSP {
+				t.Fatalf("has content security policy = %t, want %t", got, tc.wantCSP)
+			}
+			body := rec.Body.String()
+			if !strings.Contains(body, tc.wantBody) {
+				t.Fatalf("body missing %q\n%s", tc.wantBody, body)
+			}
+			if tc.wantCSP && (strings.Contains(body, "<script") || strings.Contains(body, "onerror")) {
+				t.Fatalf("html body kept active content\n%s", body)
+			}
+		})
+	}
+}
+
 func TestNewHandlerOpenAPISpecUnavailable(t *testing.T) {
 	t.Parallel()
 
@@ -475,14 +602,15 @@ func (f *fakeWebParser) Parse(rawURL string) (gh.ResourceRef, error) {
 }
 
 type fakeWebFetcher struct {
-	err  error
-	data gh.IssueData
+	err     error
+	gotOpts []gh.FetchOptions
+	data    gh.IssueData
 }
 
 func (f *fakeWebFetcher) Fetch(ctx context.Context, ref gh.ResourceRef, opts gh.FetchOptions) (gh.IssueData, error) {
 	_ = ctx
 	_ = ref
-	_ = opts
+	f.gotOpts = append(f.gotOpts, opts)
 	if f.err != nil {
 		return gh.IssueData{}, f.err
 	}
@@ -492,12 +620,13 @@ func (f *fakeWebFetcher) Fetch(ctx context.Context, ref gh.ResourceRef, opts gh.
 type fakeWebRenderer struct {
 	err     error
 	content []byte
+	gotOpts []converter.RenderOptions
 }
 
 func (f *fakeWebRenderer) Render(ctx context.Context, data gh.IssueData, opts converter.RenderOptions) ([]byte, error) {
 	_ = ctx
 	_ = data
-	_ = opts
+	f.gotOpts = append(f.gotOpts, opts)
 	if f.err != nil {
 		return nil, f.err
 	}
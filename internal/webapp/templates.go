@@ -42,6 +42,9 @@ const DefaultIndexTemplate = `<!doctype html>
     <form method="post" action="/convert">
       <label for="url">GitHub URL</label>
       <input id="url" name="url" type="url" required value="{{ .URL }}">
+      <label><input name="include_patches" type="checkbox"> Include pull request diffs</label>
+      <label for="format">Format</label>
+      <select id="format" name="format"><option value="markdown">Markdown</option><option value="html">HTML page</option></select>
       <button type="submit">Convert</button>
     </form>
     {{ if .Error }}<p class="error">{{ .Error }}</p>{{ end }}